@@ -0,0 +1,195 @@
+package app
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cast"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+
+	evmante "github.com/cosmos/evm/ante"
+	cosmosante "github.com/cosmos/evm/ante/cosmos"
+	evmantedecorators "github.com/cosmos/evm/ante/evm"
+	antetypes "github.com/cosmos/evm/ante/types"
+	srvflags "github.com/cosmos/evm/server/flags"
+	feemarkettypes "github.com/cosmos/evm/x/feemarket/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/impersonate"
+	"mirrorvault/x/feeabs"
+)
+
+// ethereumTxExtensionOption is the extension option of the Cosmos txs
+// wrapping a MsgEthereumTx.
+const ethereumTxExtensionOption = "/cosmos.evm.vm.v1.ExtensionOptionsEthereumTx"
+
+// dynamicFeeTxExtensionOption is the extension option of the Cosmos txs
+// setting a max priority price, the tip cap of their fee.
+const dynamicFeeTxExtensionOption = "/cosmos.evm.ante.v1.ExtensionOptionDynamicFeeTx"
+
+// NewAnteHandler returns the ante handler, which routes Ethereum txs to the
+// Cosmos EVM decorators and Cosmos txs to the SDK decorators.
+//
+// The Cosmos chain is the SDK default decorator chain, except that signature
+// gas is charged with the Cosmos EVM consumer. That consumer understands
+// eth_secp256k1 public keys, whose VerifySignature accepts both plain
+// signatures and EIP-712 typed-data signatures over a
+// SIGN_MODE_LEGACY_AMINO_JSON sign doc, which is what MetaMask produces
+// through eth_signTypedData_v4.
+//
+// Cosmos txs pay fees like Ethereum txs, see newCosmosAnteHandler.
+func NewAnteHandler(app *App) (sdk.AnteHandler, error) {
+	if app.txConfig == nil {
+		return nil, errorsmod.Wrap(errortypes.ErrLogic, "tx config is required for the ante handler")
+	}
+
+	maxTxGasWanted := cast.ToUint64(app.appOpts.Get(srvflags.EVMMaxTxGasWanted))
+	impersonated, err := impersonate.Addresses(app.appOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+		cosmosAnteHandler := func() (sdk.Context, error) {
+			anteHandler, err := app.newCosmosAnteHandler(ctx, tx)
+			if err != nil {
+				return ctx, err
+			}
+			return anteHandler(ctx, tx, simulate)
+		}
+
+		txWithExtensions, ok := tx.(ante.HasExtensionOptionsTx)
+		if !ok || len(txWithExtensions.GetExtensionOptions()) == 0 {
+			return cosmosAnteHandler()
+		}
+
+		switch typeURL := txWithExtensions.GetExtensionOptions()[0].GetTypeUrl(); typeURL {
+		case ethereumTxExtensionOption:
+			return app.newEVMAnteHandler(ctx, maxTxGasWanted, impersonated)(ctx, tx, simulate)
+		case dynamicFeeTxExtensionOption:
+			return cosmosAnteHandler()
+		default:
+			return ctx, errorsmod.Wrapf(
+				errortypes.ErrUnknownExtensionOptions,
+				"rejecting tx with unsupported extension option: %s", typeURL,
+			)
+		}
+	}, nil
+}
+
+// newCosmosAnteHandler returns the ante handler of Cosmos txs, built with the
+// current fee market params.
+//
+// Fees follow EIP-1559 as for Ethereum txs: the fee over the gas limit is the
+// fee cap, which must cover the fee market base fee, and the fee charged is
+// the gas limit times the base fee plus a tip, at most the max priority price
+// of an ExtensionOptionDynamicFeeTx, unlimited without. The tip sets the
+// mempool priority. The gas limit is added to the block gas wanted the next
+// base fee is computed from, and the post handler refunds the unused gas.
+//
+// A tx with a fee granter pays from its feegrant allowance, which is how
+// sponsorships pay, see the sponsor module. A tx whose fee is a single coin
+// of a fee token pays in that token, see feeabs: the fee is checked at its
+// worth in the EVM denom. Fee tokens can't be paid by a fee granter.
+func (app *App) newCosmosAnteHandler(ctx sdk.Context, tx sdk.Tx) (sdk.AnteHandler, error) {
+	feemarketParams := app.FeeMarketKeeper.GetParams(ctx)
+
+	feeDecorators := []sdk.AnteDecorator{
+		cosmosante.NewMinGasPriceDecorator(&feemarketParams),
+		ante.NewConsumeGasForTxSizeDecorator(app.AuthKeeper),
+		ante.NewDeductFeeDecorator(app.AuthKeeper, app.BankKeeper, app.FeeGrantKeeper, txFeeChecker(&feemarketParams)),
+	}
+	if feeTx, ok := tx.(sdk.FeeTx); ok {
+		token, isFeeToken, err := app.FeeAbsKeeper.FeeToken(ctx, feeTx.GetFee())
+		if err != nil {
+			return nil, err
+		}
+		if isFeeToken {
+			feeDecorators = []sdk.AnteDecorator{
+				feeabs.NewNativeFeeDecorator(token, cosmosante.NewMinGasPriceDecorator(&feemarketParams)),
+				ante.NewConsumeGasForTxSizeDecorator(app.AuthKeeper),
+				feeabs.NewDeductFeeDecorator(app.FeeAbsKeeper, app.AuthKeeper, token, txFeeChecker(&feemarketParams)),
+			}
+		}
+	}
+
+	decorators := []sdk.AnteDecorator{
+		// MsgEthereumTx must only be sent wrapped in an Ethereum tx, which
+		// authz can't grant or execute either
+		cosmosante.NewRejectMessagesDecorator(),
+		cosmosante.NewAuthzLimiterDecorator(sdk.MsgTypeURL(&evmtypes.MsgEthereumTx{})),
+		ante.NewSetUpContextDecorator(),
+		ante.NewExtensionOptionsDecorator(antetypes.HasDynamicFeeExtensionOption),
+		ante.NewValidateBasicDecorator(),
+		ante.NewTxTimeoutHeightDecorator(),
+		ante.NewValidateMemoDecorator(app.AuthKeeper),
+	}
+	decorators = append(decorators, feeDecorators...)
+	decorators = append(decorators,
+		ante.NewSetPubKeyDecorator(app.AuthKeeper),
+		ante.NewValidateSigCountDecorator(app.AuthKeeper),
+		ante.NewSigGasConsumeDecorator(app.AuthKeeper, evmante.SigVerificationGasConsumer),
+		ante.NewSigVerificationDecorator(app.AuthKeeper, app.txConfig.SignModeHandler()),
+		ante.NewIncrementSequenceDecorator(app.AuthKeeper),
+		evmantedecorators.NewGasWantedDecorator(app.EVMKeeper, app.FeeMarketKeeper, &feemarketParams),
+	)
+
+	return sdk.ChainAnteDecorators(decorators...), nil
+}
+
+// txFeeChecker returns the fee checker of Cosmos txs: the EIP-1559 fee of the
+// Cosmos EVM dynamic fee checker, whose fee cap must also reach the node
+// minimum gas prices in CheckTx, as the EVM mempool fee check does.
+func txFeeChecker(feemarketParams *feemarkettypes.Params) ante.TxFeeChecker {
+	dynamicFeeChecker := evmantedecorators.NewDynamicFeeChecker(feemarketParams)
+
+	return func(ctx sdk.Context, tx sdk.Tx) (sdk.Coins, int64, error) {
+		feeTx, ok := tx.(sdk.FeeTx)
+		if !ok {
+			return nil, 0, errorsmod.Wrap(errortypes.ErrTxDecode, "tx must be a FeeTx")
+		}
+
+		if ctx.IsCheckTx() && !ctx.MinGasPrices().IsZero() {
+			gas := math.LegacyNewDecFromInt(math.NewIntFromUint64(feeTx.GetGas()))
+			requiredFees := make(sdk.Coins, 0, len(ctx.MinGasPrices()))
+			for _, gp := range ctx.MinGasPrices() {
+				requiredFees = append(requiredFees, sdk.NewCoin(gp.Denom, gp.Amount.Mul(gas).Ceil().RoundInt()))
+			}
+			if !feeTx.GetFee().IsAnyGTE(requiredFees) {
+				return nil, 0, errorsmod.Wrapf(errortypes.ErrInsufficientFee, "insufficient fees; got: %s required: %s", feeTx.GetFee(), requiredFees)
+			}
+		}
+
+		return dynamicFeeChecker(ctx, tx)
+	}
+}
+
+// newEVMAnteHandler returns the ante handler of Ethereum txs, built with the
+// current EVM and fee market params. The txs of the impersonated addresses
+// run as them whatever key signed them, see the impersonate package.
+func (app *App) newEVMAnteHandler(ctx sdk.Context, maxTxGasWanted uint64, impersonated []common.Address) sdk.AnteHandler {
+	evmParams := app.EVMKeeper.GetParams(ctx)
+	feemarketParams := app.FeeMarketKeeper.GetParams(ctx)
+
+	var decorators []sdk.AnteDecorator
+	if len(impersonated) > 0 {
+		decorators = append(decorators, impersonate.NewDecorator(impersonated))
+	}
+	decorators = append(decorators,
+		evmantedecorators.NewEVMMonoDecorator(
+			app.AuthKeeper,
+			app.FeeMarketKeeper,
+			app.EVMKeeper,
+			maxTxGasWanted,
+			&evmParams,
+			&feemarketParams,
+		),
+		evmante.NewTxListenerDecorator(app.onPendingTx),
+	)
+
+	return sdk.ChainAnteDecorators(decorators...)
+}
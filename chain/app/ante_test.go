@@ -0,0 +1,153 @@
+package app_test
+
+import (
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+	"cosmossdk.io/x/feegrant"
+
+	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	antetypes "github.com/cosmos/evm/ante/types"
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+
+	"mirrorvault/app"
+)
+
+// TestCosmosTxFees checks Cosmos txs pay the fee market base fee, feed the
+// block gas wanted and get their unused gas refunded, to the fee granter and
+// its allowance when there is one. The EVM global config allows a single app
+// per process, so all cases share it.
+func TestCosmosTxFees(t *testing.T) {
+	a, err := app.NewInMemory(log.NewNopLogger(), simtestutil.AppOptionsMap{})
+	require.NoError(t, err)
+
+	// fund a key from the validator operator
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	sender := sdk.AccAddress(priv.PubKey().Address())
+
+	ctx := a.NewUncachedContext(false, cmtproto.Header{Height: 1})
+	vals, err := a.StakingKeeper.GetAllValidators(ctx)
+	require.NoError(t, err)
+	valAddr, err := sdk.ValAddressFromBech32(vals[0].OperatorAddress)
+	require.NoError(t, err)
+	funds := sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 500_000))
+	require.NoError(t, a.BankKeeper.SendCoins(ctx, sdk.AccAddress(valAddr), sender, funds))
+	accNum := a.AuthKeeper.GetAccount(ctx, sender).GetAccountNumber()
+
+	baseFee := a.FeeMarketKeeper.GetBaseFee(ctx)
+	require.True(t, baseFee.IsPositive())
+	require.True(t, baseFee.LTE(app.DefaultBaseFee))
+
+	const gasLimit = 200_000
+	newTx := func(sequence uint64, granter sdk.AccAddress, gasPrice math.LegacyDec, maxPriorityPrice *math.LegacyDec) []byte {
+		txBuilder := a.TxConfig().NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(sender, sender, sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 1)))))
+		txBuilder.SetGasLimit(gasLimit)
+		txBuilder.SetFeeGranter(granter)
+		txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(app.BaseDenom, gasPrice.MulInt64(gasLimit).Ceil().TruncateInt())))
+		if maxPriorityPrice != nil {
+			opt, err := codectypes.NewAnyWithValue(&antetypes.ExtensionOptionDynamicFeeTx{MaxPriorityPrice: *maxPriorityPrice})
+			require.NoError(t, err)
+			txBuilder.(authtx.ExtensionOptionsTxBuilder).SetExtensionOptions(opt)
+		}
+
+		signMode := signing.SignMode_SIGN_MODE_DIRECT
+		require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+			PubKey:   priv.PubKey(),
+			Data:     &signing.SingleSignatureData{SignMode: signMode},
+			Sequence: sequence,
+		}))
+		sig, err := clienttx.SignWithPrivKey(ctx, signMode, authsigning.SignerData{
+			ChainID:       app.InMemoryChainID,
+			AccountNumber: accNum,
+			Sequence:      sequence,
+			Address:       sender.String(),
+			PubKey:        priv.PubKey(),
+		}, txBuilder, priv, a.TxConfig(), sequence)
+		require.NoError(t, err)
+		require.NoError(t, txBuilder.SetSignatures(sig))
+
+		txBytes, err := a.TxConfig().TxEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+		return txBytes
+	}
+
+	// the base fee only drops in an empty block, so twice the current one
+	// covers it, and a zero max priority price pays the base fee alone
+	zero := math.LegacyZeroDec()
+	res, err := a.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height: 2,
+		Time:   time.Now(),
+		Txs: [][]byte{
+			newTx(0, nil, baseFee.QuoInt64(2), nil),
+			newTx(0, nil, baseFee.MulInt64(2), &zero),
+		},
+	})
+	require.NoError(t, err)
+	_, err = a.Commit()
+	require.NoError(t, err)
+
+	below, paid := res.TxResults[0], res.TxResults[1]
+	require.Equal(t, errortypes.ErrInsufficientFee.ABCICode(), below.Code, below.Log)
+	require.Zero(t, paid.Code, paid.Log)
+	require.Less(t, uint64(paid.GasUsed), uint64(gasLimit))
+
+	ctx = a.NewUncachedContext(false, cmtproto.Header{Height: 2})
+	blockBaseFee := a.FeeMarketKeeper.GetBaseFee(ctx)
+	charged := blockBaseFee.MulInt64(gasLimit).Ceil().TruncateInt()
+	refund := charged.MulRaw(gasLimit - paid.GasUsed).QuoRaw(gasLimit)
+	require.True(t, refund.IsPositive())
+	require.Equal(t, funds.AmountOf(app.BaseDenom).Sub(charged).Add(refund),
+		a.BankKeeper.GetBalance(ctx, sender, app.BaseDenom).Amount)
+
+	// the gas limit feeds the base fee of the next block
+	require.GreaterOrEqual(t, a.FeeMarketKeeper.GetBlockGasWanted(ctx), uint64(gasLimit/2))
+
+	// the validator operator pays the fees of the key
+	granter := sdk.AccAddress(valAddr)
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 100_000))
+	require.NoError(t, a.FeeGrantKeeper.GrantAllowance(ctx, granter, sender, &feegrant.BasicAllowance{SpendLimit: spendLimit}))
+	granterBalance := a.BankKeeper.GetBalance(ctx, granter, app.BaseDenom).Amount
+	senderBalance := a.BankKeeper.GetBalance(ctx, sender, app.BaseDenom).Amount
+
+	res, err = a.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height: 3,
+		Time:   time.Now(),
+		Txs:    [][]byte{newTx(1, granter, blockBaseFee.MulInt64(2), &zero)},
+	})
+	require.NoError(t, err)
+	_, err = a.Commit()
+	require.NoError(t, err)
+
+	granted := res.TxResults[0]
+	require.Zero(t, granted.Code, granted.Log)
+
+	ctx = a.NewUncachedContext(false, cmtproto.Header{Height: 3})
+	charged = a.FeeMarketKeeper.GetBaseFee(ctx).MulInt64(gasLimit).Ceil().TruncateInt()
+	refund = charged.MulRaw(gasLimit - granted.GasUsed).QuoRaw(gasLimit)
+	require.True(t, refund.IsPositive())
+	require.Equal(t, granterBalance.Sub(charged).Add(refund), a.BankKeeper.GetBalance(ctx, granter, app.BaseDenom).Amount)
+	require.Equal(t, senderBalance, a.BankKeeper.GetBalance(ctx, sender, app.BaseDenom).Amount)
+
+	// the allowance is only charged for the gas used
+	allowance, err := a.FeeGrantKeeper.GetAllowance(ctx, granter, sender)
+	require.NoError(t, err)
+	require.Equal(t, spendLimit.AmountOf(app.BaseDenom).Sub(charged).Add(refund),
+		allowance.(*feegrant.BasicAllowance).SpendLimit.AmountOf(app.BaseDenom))
+}
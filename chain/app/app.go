@@ -1,8 +1,11 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"io"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cast"
 
 	clienthelpers "cosmossdk.io/client/v2/helpers"
@@ -10,54 +13,84 @@ import (
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/log"
 	storetypes "cosmossdk.io/store/types"
+	feegrantkeeper "cosmossdk.io/x/feegrant/keeper"
+	"cosmossdk.io/x/tx/signing"
 
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/cosmos/cosmos-sdk/baseapp"
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/codec/legacy"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/runtime"
 	"github.com/cosmos/cosmos-sdk/server"
 	"github.com/cosmos/cosmos-sdk/server/api"
 	"github.com/cosmos/cosmos-sdk/server/config"
 	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/types/module"
 	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
-	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	authzkeeper "github.com/cosmos/cosmos-sdk/x/authz/keeper"
 	bankkeeper "github.com/cosmos/cosmos-sdk/x/bank/keeper"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	consensuskeeper "github.com/cosmos/cosmos-sdk/x/consensus/keeper"
 	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
 	"github.com/cosmos/cosmos-sdk/x/genutil"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
 	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
 
 	// Cosmos EVM imports
-	"github.com/cosmos/evm/x/erc20"
 	erc20keeper "github.com/cosmos/evm/x/erc20/keeper"
-	erc20types "github.com/cosmos/evm/x/erc20/types"
-	"github.com/cosmos/evm/x/feemarket"
 	feemarketkeeper "github.com/cosmos/evm/x/feemarket/keeper"
-	feemarkettypes "github.com/cosmos/evm/x/feemarket/types"
-	"github.com/cosmos/evm/x/precisebank"
 	precisebankkeeper "github.com/cosmos/evm/x/precisebank/keeper"
-	precisebanktypes "github.com/cosmos/evm/x/precisebank/types"
-	"github.com/cosmos/evm/x/vm"
 	evmkeeper "github.com/cosmos/evm/x/vm/keeper"
 	evmtypes "github.com/cosmos/evm/x/vm/types"
 
+	evmcryptocodec "github.com/cosmos/evm/crypto/codec"
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	evmeip712 "github.com/cosmos/evm/ethereum/eip712"
+	evmserver "github.com/cosmos/evm/server"
 	srvflags "github.com/cosmos/evm/server/flags"
 
 	"mirrorvault/docs"
+	"mirrorvault/gascost"
+	gascosttypes "mirrorvault/gascost/types"
+	"mirrorvault/identity"
+	identitytypes "mirrorvault/identity/types"
+	"mirrorvault/impersonate"
+	"mirrorvault/network"
+	"mirrorvault/walletconfig"
+	bridgekeeper "mirrorvault/x/bridge/keeper"
+	callbackskeeper "mirrorvault/x/callbacks/keeper"
+	dispatchkeeper "mirrorvault/x/dispatch/keeper"
+	"mirrorvault/x/feeabs"
+	feeabskeeper "mirrorvault/x/feeabs/keeper"
+	"mirrorvault/x/feesplit"
+	feesplitkeeper "mirrorvault/x/feesplit/keeper"
+	sponsorkeeper "mirrorvault/x/sponsor/keeper"
 )
 
 const (
 	// Name is the name of the application.
 	Name = "mirrorvault"
 	// AccountAddressPrefix is the prefix for accounts addresses.
-	AccountAddressPrefix = "mirror"
+	AccountAddressPrefix = network.Bech32Prefix
 	// ChainCoinType is the coin type of the chain.
-	ChainCoinType = 60
+	ChainCoinType = network.CoinType
+	// BaseDenom is the denom of the native coin.
+	BaseDenom = network.BaseDenom
+	// DisplayDenom is the human readable denom of the native coin.
+	DisplayDenom = network.DisplayDenom
+	// BaseDenomDecimals is the exponent between BaseDenom and DisplayDenom.
+	BaseDenomDecimals = network.BaseDenomDecimals
+	// ExtendedDenom is the 18 decimals denom of the native coin in the EVM.
+	ExtendedDenom = network.ExtendedDenom
+	// ExtendedDenomDecimals is the exponent between ExtendedDenom and
+	// DisplayDenom.
+	ExtendedDenomDecimals = network.ExtendedDenomDecimals
 )
 
 // DefaultNodeHome default home directories for the application daemon
@@ -66,6 +99,7 @@ var DefaultNodeHome string
 var (
 	_ runtime.AppI            = (*App)(nil)
 	_ servertypes.Application = (*App)(nil)
+	_ evmserver.Application   = (*App)(nil)
 )
 
 // App extends an ABCI application, but with most of its parameters exported.
@@ -77,6 +111,11 @@ type App struct {
 	appCodec          codec.Codec
 	txConfig          client.TxConfig
 	interfaceRegistry codectypes.InterfaceRegistry
+	appOpts           servertypes.AppOptions
+	clientCtx         client.Context
+
+	// pendingTxListeners are notified of EVM txs entering the mempool
+	pendingTxListeners []func(common.Hash)
 
 	// keepers
 	AuthKeeper            authkeeper.AccountKeeper
@@ -84,12 +123,21 @@ type App struct {
 	StakingKeeper         *stakingkeeper.Keeper
 	DistrKeeper           distrkeeper.Keeper
 	ConsensusParamsKeeper consensuskeeper.Keeper
+	FeeGrantKeeper        feegrantkeeper.Keeper
+	AuthzKeeper           authzkeeper.Keeper
+	GovKeeper             *govkeeper.Keeper
 
 	// Cosmos EVM keepers
 	FeeMarketKeeper   feemarketkeeper.Keeper
 	PreciseBankKeeper precisebankkeeper.Keeper
 	EVMKeeper         *evmkeeper.Keeper
 	Erc20Keeper       erc20keeper.Keeper
+	FeeSplitKeeper    feesplitkeeper.Keeper
+	FeeAbsKeeper      feeabskeeper.Keeper
+	SponsorKeeper     sponsorkeeper.Keeper
+	DispatchKeeper    dispatchkeeper.Keeper
+	CallbacksKeeper   callbackskeeper.Keeper
+	BridgeKeeper      bridgekeeper.Keeper
 
 	// simulation manager
 	sm *module.SimulationManager
@@ -102,23 +150,48 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	// the ante handler amino encodes the signer keys to charge for tx size
+	legacy.Cdc.RegisterConcrete(&ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName, nil)
+	legacy.Cdc.RegisterConcrete(&ethsecp256k1.PrivKey{}, ethsecp256k1.PrivKeyName, nil)
 }
 
 // AppConfig returns the default app config.
 func AppConfig() depinject.Config {
 	return depinject.Configs(
 		appConfig,
+		depinject.Provide(
+			// EVM custom signers - needed for MsgEthereumTx
+			ProvideMsgEthereumTxCustomGetSigner,
+		),
 		depinject.Supply(
-			// Supply EVM custom signers globally - needed for MsgEthereumTx
-			evmtypes.MsgEthereumTxCustomGetSigner,
 			// supply custom module basics
 			map[string]module.AppModuleBasic{
 				genutiltypes.ModuleName: genutil.NewAppModuleBasic(genutiltypes.DefaultMessageValidator),
+				banktypes.ModuleName:    bankAppModuleBasic{},
+				govtypes.ModuleName:     govAppModuleBasic{gov.NewAppModuleBasic(nil)},
 			},
 		),
+		depinject.Invoke(RegisterEthCrypto),
 	)
 }
 
+// ProvideMsgEthereumTxCustomGetSigner provides the signer of MsgEthereumTx.
+// CustomGetSigner is a many-per-container type, so it has to be provided
+// rather than supplied.
+func ProvideMsgEthereumTxCustomGetSigner() signing.CustomGetSigner {
+	return evmtypes.MsgEthereumTxCustomGetSigner
+}
+
+// RegisterEthCrypto registers the eth_secp256k1 key types so that accounts,
+// transactions and keyrings can carry Ethereum keys.
+func RegisterEthCrypto(registry codectypes.InterfaceRegistry, cdc *codec.LegacyAmino) {
+	evmcryptocodec.RegisterInterfaces(registry)
+	evmeip712.RegisterInterfaces(registry)
+	cdc.RegisterConcrete(&ethsecp256k1.PubKey{}, ethsecp256k1.PubKeyName, nil)
+	cdc.RegisterConcrete(&ethsecp256k1.PrivKey{}, ethsecp256k1.PrivKeyName, nil)
+}
+
 // New returns a reference to an initialized App.
 func New(
 	logger log.Logger,
@@ -129,7 +202,7 @@ func New(
 	baseAppOptions ...func(*baseapp.BaseApp),
 ) *App {
 	var (
-		app        = &App{}
+		app        = &App{appOpts: appOpts}
 		appBuilder *runtime.AppBuilder
 
 		// merge the AppConfig and other configuration in one config
@@ -160,28 +233,27 @@ func New(
 		&app.StakingKeeper,
 		&app.DistrKeeper,
 		&app.ConsensusParamsKeeper,
+		&app.FeeGrantKeeper,
+		&app.AuthzKeeper,
+		&app.GovKeeper,
 	); err != nil {
 		panic(err)
 	}
 
+	// the network profile gives the defaults of the app options
+	profile, err := network.Select(cast.ToString(appOpts.Get(network.FlagNetwork)))
+	if err != nil {
+		panic(err)
+	}
+
 	// Get EVM Chain ID from app options
 	evmChainID := cast.ToUint64(appOpts.Get(srvflags.EVMChainID))
 	if evmChainID == 0 {
-		evmChainID = 7777 // default EVM chain ID for mirror-vault
+		evmChainID = profile.EVMChainID
 	}
 
-	// Register EVM store keys manually (cosmos/evm doesn't support depinject yet)
-	// Note: cosmos/evm modules use standard KV and transient keys, no object keys
-	evmStoreKeys := storetypes.NewKVStoreKeys(
-		evmtypes.StoreKey,
-		feemarkettypes.StoreKey,
-		erc20types.StoreKey,
-		precisebanktypes.StoreKey,
-	)
-	evmTransientKeys := storetypes.NewTransientStoreKeys(
-		evmtypes.TransientKey,
-		feemarkettypes.TransientKey,
-	)
+	// EIP-712 signature verification decodes sign docs with the app codecs
+	evmeip712.SetEncodingConfig(app.legacyAmino, app.interfaceRegistry, evmChainID)
 
 	// add to default baseapp options
 	// enable optimistic execution
@@ -190,84 +262,39 @@ func New(
 	// build app
 	app.App = appBuilder.Build(db, traceStore, baseAppOptions...)
 
-	// Now initialize EVM keepers after app is built
-	// These keepers cannot be initialized via depinject (cosmos/evm doesn't support it yet)
+	// register the Cosmos EVM modules, which don't support depinject yet
+	if err := app.registerEVMModules(appOpts, evmChainID); err != nil {
+		panic(err)
+	}
 
-	// Mount EVM store keys to the multistore
-	for _, key := range evmStoreKeys {
-		app.App.MountStore(key, storetypes.StoreTypeDB)
+	// the node must run as the network it is configured for
+	if err := profile.Check(app.ChainID(), evmChainID, StaticPrecompileAddresses()); err != nil {
+		panic(err)
 	}
-	for _, key := range evmTransientKeys {
-		app.App.MountStore(key, storetypes.StoreTypeTransient)
+	// impersonation skips the signature checks of Ethereum txs
+	if impersonated, err := impersonate.Addresses(appOpts); err != nil {
+		panic(err)
+	} else if len(impersonated) > 0 && profile.Name != network.Localnet {
+		panic(fmt.Errorf("%s network can't impersonate addresses, only a %s can", profile.Name, network.Localnet))
 	}
 
-	// FeeMarket keeper - manages EIP-1559 base fee
-	app.FeeMarketKeeper = feemarketkeeper.NewKeeper(
-		app.appCodec,
-		authtypes.NewModuleAddress(govtypes.ModuleName),
-		evmStoreKeys[feemarkettypes.StoreKey],
-		evmTransientKeys[feemarkettypes.TransientKey],
-	)
-
-	// PreciseBank keeper - enables 18-decimal precision for EVM compatibility
-	app.PreciseBankKeeper = precisebankkeeper.NewKeeper(
-		app.appCodec,
-		evmStoreKeys[precisebanktypes.StoreKey],
-		app.BankKeeper,
-		app.AuthKeeper,
-	)
-
-	// Get tracer from app options
-	tracer := cast.ToString(appOpts.Get(srvflags.EVMTracer))
-
-	// Collect all store keys for EVM keeper
-	// Get keys map from CommitMultiStore
-	allKeys := make(map[string]*storetypes.KVStoreKey)
-	for key, value := range evmStoreKeys {
-		allKeys[key] = value
+	// Replace the default SDK ante handler so eth_secp256k1 (and EIP-712)
+	// signatures are accepted on Cosmos txs
+	anteHandler, err := NewAnteHandler(app)
+	if err != nil {
+		panic(err)
 	}
+	app.SetAnteHandler(anteHandler)
+	app.SetPostHandler(NewPostHandler(app))
 
-	// EVM keeper - core execution engine
-	app.EVMKeeper = evmkeeper.NewKeeper(
-		app.appCodec,
-		evmStoreKeys[evmtypes.StoreKey],
-		evmTransientKeys[evmtypes.TransientKey],
-		allKeys,
-		authtypes.NewModuleAddress(govtypes.ModuleName),
-		app.AuthKeeper,
-		app.PreciseBankKeeper,
-		app.StakingKeeper,
-		&app.FeeMarketKeeper,
-		&app.ConsensusParamsKeeper,
-		nil, // Erc20Keeper will be set after initialization
-		evmChainID,
-		tracer,
-	)
-
-	// ERC20 keeper - handles native<->ERC20 conversion
-	// NOTE: We need IBC TransferKeeper for erc20 module, but we don't have IBC integrated yet.
-	// For now, pass nil - this means IBC-related erc20 features won't work until Phase 2.
-	app.Erc20Keeper = erc20keeper.NewKeeper(
-		evmStoreKeys[erc20types.StoreKey],
-		app.appCodec,
-		authtypes.NewModuleAddress(govtypes.ModuleName),
-		app.AuthKeeper,
-		app.BankKeeper,
-		app.EVMKeeper,
-		app.StakingKeeper,
-		nil, // TransferKeeper - will be added when we integrate IBC
-	)
-
-	// Create EVM modules for later wiring
-	// Note: These modules cannot be registered with depinject ModuleManager
-	// They will be handled through custom routing in the BaseApp
-	_ = vm.NewAppModule(app.EVMKeeper, app.AuthKeeper, app.BankKeeper, app.AuthKeeper.AddressCodec())
-	_ = feemarket.NewAppModule(app.FeeMarketKeeper)
-	_ = erc20.NewAppModule(app.Erc20Keeper, app.AuthKeeper)
-	_ = precisebank.NewAppModule(app.PreciseBankKeeper, app.BankKeeper, app.AuthKeeper)
-
-	// TODO: Wire EVM modules into msg/query routing
-	// For now, keepers are initialized and will be available for AnteHandler and mempool
+	// register the gRPC queries of the attestations and storage costs, which
+	// aren't modules
+	gasCostEngine, err := app.GasCostEngine()
+	if err != nil {
+		panic(err)
+	}
+	gascosttypes.RegisterQueryServer(app.GRPCQueryRouter(), gascost.NewQueryServer(gasCostEngine))
+	identitytypes.RegisterQueryServer(app.GRPCQueryRouter(), identity.NewQueryServer())
 
 	/****  Module Options ****/
 
@@ -286,6 +313,9 @@ func New(
 	// 	return app.App.InitChainer(ctx, req)
 	// })
 
+	// the genesis modules must agree on the native coin denoms
+	app.SetInitChainer(app.initChainer)
+
 	if err := app.Load(loadLatest); err != nil {
 		panic(err)
 	}
@@ -339,6 +369,41 @@ func (app *App) RegisterAPIRoutes(apiSvr *api.Server, apiConfig config.APIConfig
 		panic(err)
 	}
 
+	// register identity attestation routes.
+	identity.RegisterRoutes(apiSvr.Router)
+
+	// register wallet onboarding routes.
+	walletCfg, err := WalletConfig(app.appOpts, apiSvr.ClientCtx.ChainID)
+	if err != nil {
+		panic(err)
+	}
+	walletconfig.RegisterRoutes(apiSvr.Router, walletCfg)
+
+	// register the fee quote gRPC-gateway route, the gas cost query isn't
+	// a module.
+	if err := gascosttypes.RegisterQueryHandlerClient(context.Background(), apiSvr.GRPCGatewayRouter, gascosttypes.NewQueryClient(apiSvr.ClientCtx)); err != nil {
+		panic(err)
+	}
+
+	// register storage cost estimation routes.
+	gasCostEngine, err := app.GasCostEngine()
+	if err != nil {
+		panic(err)
+	}
+	gascost.RegisterRoutes(apiSvr.Router, gasCostEngine, func() (sdk.Context, error) {
+		return app.CreateQueryContext(0, false)
+	})
+
+	// register burned supply routes.
+	feesplit.RegisterRoutes(apiSvr.Router, app.FeeSplitKeeper, app.BankKeeper, func() (sdk.Context, error) {
+		return app.CreateQueryContext(0, false)
+	})
+
+	// register fee token routes.
+	feeabs.RegisterRoutes(apiSvr.Router, app.FeeAbsKeeper, func() (sdk.Context, error) {
+		return app.CreateQueryContext(0, false)
+	})
+
 	// register app's OpenAPI routes.
 	docs.RegisterOpenAPIService(Name, apiSvr.Router)
 }
@@ -4,20 +4,29 @@ import (
 	runtimev1alpha1 "cosmossdk.io/api/cosmos/app/runtime/v1alpha1"
 	appv1alpha1 "cosmossdk.io/api/cosmos/app/v1alpha1"
 	authmodulev1 "cosmossdk.io/api/cosmos/auth/module/v1"
+	authzmodulev1 "cosmossdk.io/api/cosmos/authz/module/v1"
 	bankmodulev1 "cosmossdk.io/api/cosmos/bank/module/v1"
 	consensusmodulev1 "cosmossdk.io/api/cosmos/consensus/module/v1"
 	distrmodulev1 "cosmossdk.io/api/cosmos/distribution/module/v1"
+	feegrantmodulev1 "cosmossdk.io/api/cosmos/feegrant/module/v1"
 	genutilmodulev1 "cosmossdk.io/api/cosmos/genutil/module/v1"
+	govmodulev1 "cosmossdk.io/api/cosmos/gov/module/v1"
 	stakingmodulev1 "cosmossdk.io/api/cosmos/staking/module/v1"
 	txconfigv1 "cosmossdk.io/api/cosmos/tx/config/v1"
 	"cosmossdk.io/depinject/appconfig"
+	"cosmossdk.io/x/feegrant"
+	_ "cosmossdk.io/x/feegrant/module" // import for side-effects
 
-	_ "github.com/cosmos/cosmos-sdk/x/bank" // import for side-effects
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	_ "github.com/cosmos/cosmos-sdk/x/authz/module" // import for side-effects
+	_ "github.com/cosmos/cosmos-sdk/x/bank"         // import for side-effects
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
 	_ "github.com/cosmos/cosmos-sdk/x/consensus" // import for side-effects
 	consensustypes "github.com/cosmos/cosmos-sdk/x/consensus/types"
 	_ "github.com/cosmos/cosmos-sdk/x/distribution" // import for side-effects
 	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+	_ "github.com/cosmos/cosmos-sdk/x/gov" // import for side-effects
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	_ "github.com/cosmos/cosmos-sdk/x/staking" // import for side-effects
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
@@ -27,7 +36,19 @@ import (
 	_ "github.com/cosmos/cosmos-sdk/x/auth/tx/config" // import for side-effects
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
-	// Cosmos EVM types for store keys
+
+	// Cosmos EVM modules are wired manually, see evm.go
+	erc20types "github.com/cosmos/evm/x/erc20/types"
+	feemarkettypes "github.com/cosmos/evm/x/feemarket/types"
+	precisebanktypes "github.com/cosmos/evm/x/precisebank/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	bridgetypes "mirrorvault/x/bridge/types"
+	callbackstypes "mirrorvault/x/callbacks/types"
+	dispatchtypes "mirrorvault/x/dispatch/types"
+	feeabstypes "mirrorvault/x/feeabs/types"
+	feesplittypes "mirrorvault/x/feesplit/types"
+	sponsortypes "mirrorvault/x/sponsor/types"
 )
 
 var (
@@ -37,6 +58,14 @@ var (
 		{Account: minttypes.ModuleName, Permissions: []string{authtypes.Minter}},
 		{Account: stakingtypes.BondedPoolName, Permissions: []string{authtypes.Burner, stakingtypes.ModuleName}},
 		{Account: stakingtypes.NotBondedPoolName, Permissions: []string{authtypes.Burner, stakingtypes.ModuleName}},
+		{Account: govtypes.ModuleName, Permissions: []string{authtypes.Burner}},
+		{Account: evmtypes.ModuleName, Permissions: []string{authtypes.Minter, authtypes.Burner}},
+		{Account: feemarkettypes.ModuleName},
+		{Account: erc20types.ModuleName, Permissions: []string{authtypes.Minter, authtypes.Burner}},
+		{Account: precisebanktypes.ModuleName, Permissions: []string{authtypes.Minter, authtypes.Burner}},
+		{Account: feesplittypes.ModuleName, Permissions: []string{authtypes.Burner}},
+		{Account: feeabstypes.ModuleName},
+		{Account: callbackstypes.ModuleName},
 	}
 
 	// blocked account addresses
@@ -45,8 +74,15 @@ var (
 		distrtypes.ModuleName,
 		stakingtypes.BondedPoolName,
 		stakingtypes.NotBondedPoolName,
+		evmtypes.ModuleName,
+		feemarkettypes.ModuleName,
+		erc20types.ModuleName,
+		precisebanktypes.ModuleName,
+		feesplittypes.ModuleName,
+		callbackstypes.ModuleName,
 		// We allow the following module accounts to receive funds:
 		// govtypes.ModuleName
+		// feeabstypes.ModuleName, whose reserve anyone can top up
 	}
 
 	// application configuration (used by depinject)
@@ -59,6 +95,7 @@ var (
 					// NOTE: upgrade module is required to be prioritized
 					PreBlockers: []string{
 						authtypes.ModuleName,
+						evmtypes.ModuleName,
 						// this line is used by starport scaffolding # stargate/app/preBlockers
 					},
 					// During begin block slashing happens after distr.BeginBlocker so that
@@ -68,12 +105,29 @@ var (
 					BeginBlockers: []string{
 						distrtypes.ModuleName,
 						stakingtypes.ModuleName,
+						authz.ModuleName,
+						// the fee market computes the base fee before the EVM reads it
+						feemarkettypes.ModuleName,
+						evmtypes.ModuleName,
 						// chain modules
 						// this line is used by starport scaffolding # stargate/app/beginBlockers
 					},
 					EndBlockers: []string{
+						govtypes.ModuleName,
 						stakingtypes.ModuleName,
+						feegrant.ModuleName,
+						evmtypes.ModuleName,
+						// the fee market records the block gas after the EVM
+						feemarkettypes.ModuleName,
 						// chain modules
+						// the fees of the block are split before distribution
+						// allocates them at the next begin block
+						feesplittypes.ModuleName,
+						// credits are granted for the unlocks the EVM txs of the block logged
+						bridgetypes.ModuleName,
+						// contracts are called back on the events of the block,
+						// the credits granted included
+						callbackstypes.ModuleName,
 						// this line is used by starport scaffolding # stargate/app/endBlockers
 					},
 					// The following is mostly only needed when ModuleName != StoreKey name.
@@ -92,8 +146,23 @@ var (
 						banktypes.ModuleName,
 						distrtypes.ModuleName,
 						stakingtypes.ModuleName,
+						govtypes.ModuleName,
+						// the EVM and fee market must be initialized before genutil delivers
+						// the gentxs, which go through the EVM aware ante handler
+						evmtypes.ModuleName,
+						feemarkettypes.ModuleName,
+						erc20types.ModuleName,
+						precisebanktypes.ModuleName,
+						authz.ModuleName,
+						feegrant.ModuleName,
 						genutiltypes.ModuleName,
 						// chain modules
+						feesplittypes.ModuleName,
+						feeabstypes.ModuleName,
+						sponsortypes.ModuleName,
+						dispatchtypes.ModuleName,
+						callbackstypes.ModuleName,
+						bridgetypes.ModuleName,
 						// this line is used by starport scaffolding # stargate/app/initGenesis
 					},
 				}),
@@ -125,8 +194,11 @@ var (
 				}),
 			},
 			{
-				Name:   "tx",
-				Config: appconfig.WrapAny(&txconfigv1.Config{}),
+				Name: "tx",
+				Config: appconfig.WrapAny(&txconfigv1.Config{
+					// the ante handler is set in app.go, see NewAnteHandler
+					SkipAnteHandler: true,
+				}),
 			},
 			{
 				Name:   genutiltypes.ModuleName,
@@ -136,10 +208,22 @@ var (
 				Name:   distrtypes.ModuleName,
 				Config: appconfig.WrapAny(&distrmodulev1.Module{}),
 			},
+			{
+				Name:   govtypes.ModuleName,
+				Config: appconfig.WrapAny(&govmodulev1.Module{}),
+			},
 			{
 				Name:   consensustypes.ModuleName,
 				Config: appconfig.WrapAny(&consensusmodulev1.Module{}),
 			},
+			{
+				Name:   authz.ModuleName,
+				Config: appconfig.WrapAny(&authzmodulev1.Module{}),
+			},
+			{
+				Name:   feegrant.ModuleName,
+				Config: appconfig.WrapAny(&feegrantmodulev1.Module{}),
+			},
 			// this line is used by starport scaffolding # stargate/app/moduleConfig
 		},
 	})
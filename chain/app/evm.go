@@ -0,0 +1,359 @@
+package app
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cast"
+
+	"cosmossdk.io/core/appmodule"
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	feegrantkeeper "cosmossdk.io/x/feegrant/keeper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdkmempool "github.com/cosmos/cosmos-sdk/types/mempool"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	evmmempool "github.com/cosmos/evm/mempool"
+	srvflags "github.com/cosmos/evm/server/flags"
+	"github.com/cosmos/evm/x/erc20"
+	erc20keeper "github.com/cosmos/evm/x/erc20/keeper"
+	erc20types "github.com/cosmos/evm/x/erc20/types"
+	"github.com/cosmos/evm/x/feemarket"
+	feemarketkeeper "github.com/cosmos/evm/x/feemarket/keeper"
+	feemarkettypes "github.com/cosmos/evm/x/feemarket/types"
+	"github.com/cosmos/evm/x/precisebank"
+	precisebankkeeper "github.com/cosmos/evm/x/precisebank/keeper"
+	precisebanktypes "github.com/cosmos/evm/x/precisebank/types"
+	"github.com/cosmos/evm/x/vm"
+	evmkeeper "github.com/cosmos/evm/x/vm/keeper"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/predeploy"
+	"mirrorvault/x/bridge"
+	bridgekeeper "mirrorvault/x/bridge/keeper"
+	bridgetypes "mirrorvault/x/bridge/types"
+	"mirrorvault/x/callbacks"
+	callbackskeeper "mirrorvault/x/callbacks/keeper"
+	callbackstypes "mirrorvault/x/callbacks/types"
+	"mirrorvault/x/dispatch"
+	dispatchkeeper "mirrorvault/x/dispatch/keeper"
+	dispatchtypes "mirrorvault/x/dispatch/types"
+	"mirrorvault/x/feeabs"
+	feeabskeeper "mirrorvault/x/feeabs/keeper"
+	feeabstypes "mirrorvault/x/feeabs/types"
+	"mirrorvault/x/feesplit"
+	feesplitkeeper "mirrorvault/x/feesplit/keeper"
+	feesplittypes "mirrorvault/x/feesplit/types"
+	"mirrorvault/x/sponsor"
+	sponsorkeeper "mirrorvault/x/sponsor/keeper"
+	sponsortypes "mirrorvault/x/sponsor/types"
+)
+
+// registerEVMModules builds the Cosmos EVM keepers and registers their
+// modules. cosmos/evm doesn't support depinject yet, so this follows the
+// manual wiring used for non app-wiring modules.
+func (app *App) registerEVMModules(appOpts servertypes.AppOptions, evmChainID uint64) error {
+	// Note: cosmos/evm modules use standard KV and transient keys, no object keys
+	storeKeys := storetypes.NewKVStoreKeys(
+		evmtypes.StoreKey,
+		feemarkettypes.StoreKey,
+		erc20types.StoreKey,
+		precisebanktypes.StoreKey,
+		feesplittypes.StoreKey,
+		feeabstypes.StoreKey,
+		sponsortypes.StoreKey,
+		dispatchtypes.StoreKey,
+		callbackstypes.StoreKey,
+		bridgetypes.StoreKey,
+	)
+	transientKeys := storetypes.NewTransientStoreKeys(
+		evmtypes.TransientKey,
+		feemarkettypes.TransientKey,
+		bridgetypes.TransientKey,
+	)
+
+	for _, key := range storeKeys {
+		if err := app.RegisterStores(key); err != nil {
+			return err
+		}
+	}
+	for _, key := range transientKeys {
+		if err := app.RegisterStores(key); err != nil {
+			return err
+		}
+	}
+
+	// the EVM snapshots the stores precompiles write to, which are those of
+	// the app modules too
+	evmStoreKeys := make(map[string]*storetypes.KVStoreKey, len(storeKeys))
+	for _, key := range app.GetStoreKeys() {
+		if kvKey, ok := key.(*storetypes.KVStoreKey); ok {
+			evmStoreKeys[kvKey.Name()] = kvKey
+		}
+	}
+
+	authority := authtypes.NewModuleAddress(govtypes.ModuleName)
+
+	// FeeMarket keeper - manages EIP-1559 base fee
+	app.FeeMarketKeeper = feemarketkeeper.NewKeeper(
+		app.appCodec,
+		authority,
+		storeKeys[feemarkettypes.StoreKey],
+		transientKeys[feemarkettypes.TransientKey],
+	)
+
+	// PreciseBank keeper - enables 18-decimal precision for EVM compatibility
+	app.PreciseBankKeeper = precisebankkeeper.NewKeeper(
+		app.appCodec,
+		storeKeys[precisebanktypes.StoreKey],
+		app.BankKeeper,
+		app.AuthKeeper,
+	)
+
+	// EVM keeper - core execution engine
+	app.EVMKeeper = evmkeeper.NewKeeper(
+		app.appCodec,
+		storeKeys[evmtypes.StoreKey],
+		transientKeys[evmtypes.TransientKey],
+		evmStoreKeys,
+		authority,
+		app.AuthKeeper,
+		app.PreciseBankKeeper,
+		app.StakingKeeper,
+		&app.FeeMarketKeeper,
+		&app.ConsensusParamsKeeper,
+		&app.Erc20Keeper,
+		evmChainID,
+		cast.ToString(appOpts.Get(srvflags.EVMTracer)),
+	)
+
+	// ERC20 keeper - handles native<->ERC20 conversion
+	// NOTE: IBC is not integrated, so the transfer keeper is nil and the
+	// IBC related erc20 features are unavailable.
+	app.Erc20Keeper = erc20keeper.NewKeeper(
+		storeKeys[erc20types.StoreKey],
+		app.appCodec,
+		authority,
+		app.AuthKeeper,
+		app.BankKeeper,
+		app.EVMKeeper,
+		app.StakingKeeper,
+		nil,
+	)
+
+	// FeeSplit keeper - burns and splits the fees the fee market charges
+	app.FeeSplitKeeper = feesplitkeeper.NewKeeper(
+		runtime.NewKVStoreService(storeKeys[feesplittypes.StoreKey]),
+		authority.String(),
+		app.appCodec,
+		app.BankKeeper,
+		app.DistrKeeper,
+		app.StakingKeeper,
+		app.FeeMarketKeeper,
+	)
+
+	// FeeAbs keeper - converts fees paid in fee tokens
+	app.FeeAbsKeeper = feeabskeeper.NewKeeper(
+		runtime.NewKVStoreService(storeKeys[feeabstypes.StoreKey]),
+		authority.String(),
+		app.appCodec,
+		app.AuthKeeper,
+		app.BankKeeper,
+		app.Erc20Keeper,
+	)
+
+	// Sponsor keeper - grants feegrant allowances restricted to vault messages
+	app.SponsorKeeper = sponsorkeeper.NewKeeper(
+		runtime.NewKVStoreService(storeKeys[sponsortypes.StoreKey]),
+		authority.String(),
+		app.appCodec,
+		app.FeeGrantKeeper,
+		feegrantkeeper.NewMsgServerImpl(app.FeeGrantKeeper),
+		app.MsgServiceRouter(),
+	)
+
+	// Dispatch keeper - executes the native messages contracts dispatch
+	app.DispatchKeeper = dispatchkeeper.NewKeeper(
+		runtime.NewKVStoreService(storeKeys[dispatchtypes.StoreKey]),
+		authority.String(),
+		app.appCodec,
+		app.MsgServiceRouter(),
+	)
+
+	// Callbacks keeper - calls the contracts subscribed to module events at
+	// the end of the block
+	app.CallbacksKeeper = callbackskeeper.NewKeeper(
+		runtime.NewKVStoreService(storeKeys[callbackstypes.StoreKey]),
+		authority.String(),
+		app.appCodec,
+		app.EVMKeeper,
+		app.AuthKeeper,
+	)
+
+	// Bridge keeper - grants the storage credits of the Unlocked logs in log
+	// mode, which the EVM hooks record, and notifies the callbacks of them
+	app.BridgeKeeper = bridgekeeper.NewKeeper(
+		runtime.NewKVStoreService(storeKeys[bridgetypes.StoreKey]),
+		runtime.NewTransientStoreService(transientKeys[bridgetypes.TransientKey]),
+		authority.String(),
+		app.appCodec,
+		app.CallbacksKeeper,
+	)
+	app.EVMKeeper.SetHooks(evmkeeper.NewMultiEvmHooks(app.BridgeKeeper.Hooks()))
+
+	app.registerStaticPrecompiles()
+
+	return app.RegisterModules(
+		evmAppModule{vm.NewAppModule(app.EVMKeeper, app.AuthKeeper, app.BankKeeper, app.AuthKeeper.AddressCodec())},
+		feemarketAppModule{feemarket.NewAppModule(app.FeeMarketKeeper)},
+		erc20.NewAppModule(app.Erc20Keeper, app.AuthKeeper),
+		precisebank.NewAppModule(app.PreciseBankKeeper, app.BankKeeper, app.AuthKeeper),
+		feesplit.NewAppModule(app.FeeSplitKeeper),
+		feeabs.NewAppModule(app.FeeAbsKeeper),
+		sponsor.NewAppModule(app.SponsorKeeper),
+		dispatch.NewAppModule(app.DispatchKeeper),
+		callbacks.NewAppModule(app.CallbacksKeeper),
+		bridge.NewAppModule(app.BridgeKeeper),
+	)
+}
+
+// RegisterEVM registers the Cosmos EVM modules, and the fee split, fee
+// abstraction, sponsorship, dispatch, callbacks and bridge modules wired
+// along them, on the client side.
+// Since they don't support dependency injection, they are registered manually
+// both in the app and in the CLI.
+func RegisterEVM(registry codectypes.InterfaceRegistry) map[string]appmodule.AppModule {
+	modules := map[string]appmodule.AppModule{
+		evmtypes.ModuleName:         evmAppModule{vm.NewAppModule(nil, authkeeper.AccountKeeper{}, nil, nil)},
+		feemarkettypes.ModuleName:   feemarketAppModule{feemarket.NewAppModule(feemarketkeeper.Keeper{})},
+		erc20types.ModuleName:       erc20.NewAppModule(erc20keeper.Keeper{}, authkeeper.AccountKeeper{}),
+		precisebanktypes.ModuleName: precisebank.NewAppModule(precisebankkeeper.Keeper{}, nil, authkeeper.AccountKeeper{}),
+		feesplittypes.ModuleName:    feesplit.NewAppModule(feesplitkeeper.Keeper{}),
+		feeabstypes.ModuleName:      feeabs.NewAppModule(feeabskeeper.Keeper{}),
+		sponsortypes.ModuleName:     sponsor.NewAppModule(sponsorkeeper.Keeper{}),
+		dispatchtypes.ModuleName:    dispatch.NewAppModule(dispatchkeeper.Keeper{}),
+		callbackstypes.ModuleName:   callbacks.NewAppModule(callbackskeeper.Keeper{}),
+		bridgetypes.ModuleName:      bridge.NewAppModule(bridgekeeper.Keeper{}),
+	}
+
+	for _, m := range modules {
+		if mr, ok := m.(module.AppModuleBasic); ok {
+			mr.RegisterInterfaces(registry)
+		}
+	}
+
+	return modules
+}
+
+// NewEVMGenesisState returns the default EVM genesis: the EVM runs on
+// BaseDenom, which precisebank extends to ExtendedDenom with 18 decimals,
+// with the app's static precompiles active and the default predeploys.
+func NewEVMGenesisState() *evmtypes.GenesisState {
+	genState := evmtypes.DefaultGenesisState()
+	genState.Params.EvmDenom = BaseDenom
+	genState.Params.ExtendedDenomOptions = &evmtypes.ExtendedDenomOptions{ExtendedDenom: ExtendedDenom}
+	genState.Params.ActiveStaticPrecompiles = StaticPrecompileAddresses()
+	for _, contract := range predeploy.Defaults() {
+		genState.Preinstalls = append(genState.Preinstalls, contract.Preinstall())
+	}
+
+	return genState
+}
+
+// NewDenomMetadata returns the bank metadata of BaseDenom and of
+// ExtendedDenom, its 18 decimals form in precisebank. The EVM reads the
+// decimals of its denom from it, so it must be set in genesis, and wallets
+// and SIGN_MODE_TEXTUAL display amounts with it.
+func NewDenomMetadata() []banktypes.Metadata {
+	return []banktypes.Metadata{{
+		Description: "The native coin of " + Name,
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: BaseDenom, Exponent: 0},
+			{Denom: DisplayDenom, Exponent: BaseDenomDecimals},
+		},
+		Base:    BaseDenom,
+		Display: DisplayDenom,
+		Name:    DisplayDenom,
+		Symbol:  DisplayDenom,
+	}, {
+		Description: "The native coin of " + Name + " with the 18 decimals of the EVM",
+		DenomUnits: []*banktypes.DenomUnit{
+			{Denom: ExtendedDenom, Exponent: 0},
+			{Denom: BaseDenom, Exponent: ExtendedDenomDecimals - BaseDenomDecimals},
+			{Denom: DisplayDenom, Exponent: ExtendedDenomDecimals},
+		},
+		Base:    ExtendedDenom,
+		Display: DisplayDenom,
+		Name:    DisplayDenom,
+		Symbol:  DisplayDenom,
+	}}
+}
+
+// DefaultBaseFee is the genesis base fee, in BaseDenom per gas. The fee
+// market keeps its prices in the bank denom, which the EVM sees times 10^12:
+// 0.01umvlt is 10 gwei, and the low gas price step of Keplr.
+var DefaultBaseFee = math.LegacyNewDecWithPrec(1, 2)
+
+// NewFeeMarketGenesisState returns the default fee market genesis with
+// DefaultBaseFee. The module default of 10^9 suits 18 decimals coins only.
+func NewFeeMarketGenesisState() *feemarkettypes.GenesisState {
+	genState := feemarkettypes.DefaultGenesisState()
+	genState.Params.BaseFee = DefaultBaseFee
+
+	return genState
+}
+
+// feemarketAppModule is the feemarket module with the app's default genesis.
+type feemarketAppModule struct {
+	feemarket.AppModule
+}
+
+// DefaultGenesis returns NewFeeMarketGenesisState.
+func (feemarketAppModule) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	return cdc.MustMarshalJSON(NewFeeMarketGenesisState())
+}
+
+// evmAppModule is the vm module with the app's default genesis.
+type evmAppModule struct {
+	vm.AppModule
+}
+
+// DefaultGenesis returns NewEVMGenesisState.
+func (evmAppModule) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	return cdc.MustMarshalJSON(NewEVMGenesisState())
+}
+
+// RegisterPendingTxListener registers a listener notified with the hash of
+// every EVM tx accepted by CheckTx. The JSON-RPC server uses it for
+// pending tx subscriptions.
+func (app *App) RegisterPendingTxListener(listener func(common.Hash)) {
+	app.pendingTxListeners = append(app.pendingTxListeners, listener)
+}
+
+// onPendingTx notifies the pending tx listeners.
+func (app *App) onPendingTx(hash common.Hash) {
+	for _, listener := range app.pendingTxListeners {
+		listener(hash)
+	}
+}
+
+// GetMempool returns the EVM mempool. The app uses the CometBFT mempool and
+// doesn't enable the experimental EVM mempool, so it is always nil.
+func (app *App) GetMempool() sdkmempool.ExtMempool {
+	return (*evmmempool.ExperimentalEVMMempool)(nil)
+}
+
+// SetClientCtx sets the client context the node serves its APIs with.
+func (app *App) SetClientCtx(clientCtx client.Context) {
+	app.clientCtx = clientCtx
+}
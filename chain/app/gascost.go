@@ -0,0 +1,143 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtcrypto "github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	cmttypes "github.com/cometbft/cometbft/types"
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/spf13/cast"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"mirrorvault/gascost"
+)
+
+// InMemoryChainID is the chain id of NewInMemory apps.
+const InMemoryChainID = "mirror-vault-inmemory"
+
+// GasCostEngine returns the storage cost engine on the app keepers.
+func (app *App) GasCostEngine() (gascost.Engine, error) {
+	minGasPrices, err := sdk.ParseDecCoins(cast.ToString(app.appOpts.Get("minimum-gas-prices")))
+	if err != nil {
+		return gascost.Engine{}, fmt.Errorf("invalid minimum gas prices: %w", err)
+	}
+
+	return gascost.Engine{
+		EVMKeeper:       app.EVMKeeper,
+		FeeMarketKeeper: app.FeeMarketKeeper,
+		AccountKeeper:   app.AuthKeeper,
+		StakingKeeper:   app.StakingKeeper,
+		MinGasPrices:    minGasPrices,
+		TxConfig:        app.txConfig,
+		Simulate:        app.Simulate,
+	}, nil
+}
+
+// NewInMemory returns an app on an in-memory database, past its first block
+// with a single validator and the default genesis otherwise. Tools measuring
+// execution, like gas compare, run against it. Like any App, there can only
+// be one per process.
+func NewInMemory(logger log.Logger, appOpts servertypes.AppOptions) (*App, error) {
+	app := New(logger, dbm.NewMemDB(), nil, true, appOpts, baseapp.SetChainID(InMemoryChainID))
+
+	valKey := ed25519.GenPrivKey()
+	appState, err := app.inMemoryGenesis(valKey.PubKey())
+	if err != nil {
+		return nil, err
+	}
+	stateBytes, err := json.Marshal(appState)
+	if err != nil {
+		return nil, err
+	}
+
+	consensusParams := cmttypes.DefaultConsensusParams().ToProto()
+	if _, err := app.InitChain(&abci.RequestInitChain{
+		ChainId:         InMemoryChainID,
+		ConsensusParams: &consensusParams,
+		AppStateBytes:   stateBytes,
+		InitialHeight:   1,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to init chain: %w", err)
+	}
+
+	if _, err := app.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height:          1,
+		Time:            time.Now(),
+		ProposerAddress: valKey.PubKey().Address(),
+	}); err != nil {
+		return nil, fmt.Errorf("failed to finalize block: %w", err)
+	}
+	if _, err := app.Commit(); err != nil {
+		return nil, err
+	}
+
+	return app, nil
+}
+
+// inMemoryGenesis returns the default genesis with one validator bonding
+// BaseDenom, whose operator is also its delegator. The operator holds as
+// much again, to pay for the txs simulated from it.
+func (app *App) inMemoryGenesis(valPubKey cmtcrypto.PubKey) (map[string]json.RawMessage, error) {
+	cdc := app.appCodec
+	appState := app.DefaultGenesis()
+
+	pk, err := cryptocodec.FromCmtPubKeyInterface(valPubKey)
+	if err != nil {
+		return nil, err
+	}
+	pkAny, err := codectypes.NewAnyWithValue(pk)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := sdk.AccAddress(valPubKey.Address())
+	valAddr := sdk.ValAddress(operator)
+	bonded := sdk.DefaultPowerReduction
+
+	authGenState := authtypes.NewGenesisState(authtypes.DefaultParams(), []authtypes.GenesisAccount{authtypes.NewBaseAccount(operator, nil, 0, 0)})
+	appState[authtypes.ModuleName] = cdc.MustMarshalJSON(authGenState)
+
+	stakingGenState := stakingtypes.DefaultGenesisState()
+	stakingGenState.Validators = []stakingtypes.Validator{{
+		OperatorAddress:   valAddr.String(),
+		ConsensusPubkey:   pkAny,
+		Status:            stakingtypes.Bonded,
+		Tokens:            bonded,
+		DelegatorShares:   math.LegacyOneDec(),
+		UnbondingTime:     time.Unix(0, 0).UTC(),
+		Commission:        stakingtypes.NewCommission(math.LegacyZeroDec(), math.LegacyZeroDec(), math.LegacyZeroDec()),
+		MinSelfDelegation: math.ZeroInt(),
+	}}
+	stakingGenState.Delegations = []stakingtypes.Delegation{stakingtypes.NewDelegation(operator.String(), valAddr.String(), math.LegacyOneDec())}
+	appState[stakingtypes.ModuleName] = cdc.MustMarshalJSON(stakingGenState)
+
+	// the bonded pool holds the bonded tokens
+	bondedCoins := sdk.NewCoins(sdk.NewCoin(BaseDenom, bonded))
+	bankGenState := NewBankGenesisState()
+	bankGenState.Balances = []banktypes.Balance{{
+		Address: authtypes.NewModuleAddress(stakingtypes.BondedPoolName).String(),
+		Coins:   bondedCoins,
+	}, {
+		Address: operator.String(),
+		Coins:   bondedCoins,
+	}}
+	bankGenState.Supply = bondedCoins.Add(bondedCoins...)
+	appState[banktypes.ModuleName] = cdc.MustMarshalJSON(bankGenState)
+
+	return appState, nil
+}
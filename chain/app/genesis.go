@@ -2,6 +2,23 @@ package app
 
 import (
 	"encoding/json"
+	"fmt"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+
+	"github.com/cosmos/gogoproto/proto"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
 )
 
 // GenesisState of the blockchain is represented here as a map of raw json
@@ -12,3 +29,154 @@ import (
 // the ModuleBasicManager which populates json from each BasicModule
 // object provided to it during init.
 type GenesisState map[string]json.RawMessage
+
+// NewBankGenesisState returns the default bank genesis with the metadata of
+// the native coin, see NewDenomMetadata.
+func NewBankGenesisState() *banktypes.GenesisState {
+	genState := banktypes.DefaultGenesisState()
+	genState.DenomMetadata = NewDenomMetadata()
+
+	return genState
+}
+
+// NewGovGenesisState returns the default gov genesis with its deposits in
+// BaseDenom rather than the SDK's default bond denom.
+func NewGovGenesisState() *govv1.GenesisState {
+	genState := govv1.DefaultGenesisState()
+	genState.Params.MinDeposit = sdk.NewCoins(sdk.NewCoin(BaseDenom, govv1.DefaultMinDepositTokens))
+	genState.Params.ExpeditedMinDeposit = sdk.NewCoins(sdk.NewCoin(BaseDenom, govv1.DefaultMinExpeditedDepositTokens))
+
+	return genState
+}
+
+// initChainer initializes the chain from a genesis whose denoms pass
+// ValidateGenesisDenoms.
+func (app *App) initChainer(ctx sdk.Context, req *abci.RequestInitChain) (*abci.ResponseInitChain, error) {
+	var genState GenesisState
+	if err := json.Unmarshal(req.AppStateBytes, &genState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal genesis: %w", err)
+	}
+	if err := ValidateGenesisDenoms(app.appCodec, genState); err != nil {
+		return nil, fmt.Errorf("invalid genesis: %w", err)
+	}
+
+	return app.App.InitChainer(ctx, req)
+}
+
+// bankAppModuleBasic is the bank module basic with the app's default
+// genesis.
+type bankAppModuleBasic struct {
+	bank.AppModuleBasic
+}
+
+// DefaultGenesis returns NewBankGenesisState.
+func (bankAppModuleBasic) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	return cdc.MustMarshalJSON(NewBankGenesisState())
+}
+
+// govAppModuleBasic is the gov module basic with the app's default genesis.
+type govAppModuleBasic struct {
+	gov.AppModuleBasic
+}
+
+// DefaultGenesis returns NewGovGenesisState.
+func (govAppModuleBasic) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	return cdc.MustMarshalJSON(NewGovGenesisState())
+}
+
+// ValidateGenesisDenoms checks the modules of genState agree on the native
+// coin: staking bonds BaseDenom, mint mints it and gov deposits are in it
+// if the genesis has their sections, the EVM runs on it extended to
+// ExtendedDenom, and the bank metadata of both denoms has their decimals.
+// The fee market has no denom of its own: its base fee and min gas price
+// are in BaseDenom per gas, at its decimals.
+func ValidateGenesisDenoms(cdc codec.JSONCodec, genState map[string]json.RawMessage) error {
+	var stakingGenState stakingtypes.GenesisState
+	if err := unmarshalGenesis(cdc, genState, stakingtypes.ModuleName, &stakingGenState); err != nil {
+		return err
+	}
+	if denom := stakingGenState.Params.BondDenom; denom != BaseDenom {
+		return fmt.Errorf("staking bond denom is %s, expected %s", denom, BaseDenom)
+	}
+
+	if _, ok := genState[minttypes.ModuleName]; ok {
+		var mintGenState minttypes.GenesisState
+		if err := unmarshalGenesis(cdc, genState, minttypes.ModuleName, &mintGenState); err != nil {
+			return err
+		}
+		if denom := mintGenState.Params.MintDenom; denom != BaseDenom {
+			return fmt.Errorf("mint denom is %s, expected %s", denom, BaseDenom)
+		}
+	}
+
+	if _, ok := genState[govtypes.ModuleName]; ok {
+		var govGenState govv1.GenesisState
+		if err := unmarshalGenesis(cdc, genState, govtypes.ModuleName, &govGenState); err != nil {
+			return err
+		}
+		if govGenState.Params != nil {
+			deposits := sdk.NewCoins(govGenState.Params.MinDeposit...).Add(govGenState.Params.ExpeditedMinDeposit...)
+			for _, coin := range deposits {
+				if coin.Denom != BaseDenom {
+					return fmt.Errorf("gov deposit denom is %s, expected %s", coin.Denom, BaseDenom)
+				}
+			}
+		}
+	}
+
+	var evmGenState evmtypes.GenesisState
+	if err := unmarshalGenesis(cdc, genState, evmtypes.ModuleName, &evmGenState); err != nil {
+		return err
+	}
+	if denom := evmGenState.Params.EvmDenom; denom != BaseDenom {
+		return fmt.Errorf("EVM denom is %s, expected %s", denom, BaseDenom)
+	}
+	if opts := evmGenState.Params.ExtendedDenomOptions; opts == nil || opts.ExtendedDenom != ExtendedDenom {
+		return fmt.Errorf("EVM extended denom is %v, expected %s", opts, ExtendedDenom)
+	}
+
+	var bankGenState banktypes.GenesisState
+	if err := unmarshalGenesis(cdc, genState, banktypes.ModuleName, &bankGenState); err != nil {
+		return err
+	}
+	for denom, decimals := range map[string]uint32{BaseDenom: BaseDenomDecimals, ExtendedDenom: ExtendedDenomDecimals} {
+		if err := validateDenomMetadata(bankGenState.DenomMetadata, denom, decimals); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateDenomMetadata checks metadata has denom, displayed with decimals.
+func validateDenomMetadata(metadata []banktypes.Metadata, denom string, decimals uint32) error {
+	for _, m := range metadata {
+		if m.Base != denom {
+			continue
+		}
+		for _, unit := range m.DenomUnits {
+			if unit.Denom == m.Display {
+				if unit.Exponent != decimals {
+					return fmt.Errorf("bank metadata of %s displays %s with %d decimals, expected %d", denom, m.Display, unit.Exponent, decimals)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("bank metadata of %s has no unit for its display denom %s", denom, m.Display)
+	}
+
+	return fmt.Errorf("no bank metadata for %s", denom)
+}
+
+// unmarshalGenesis unmarshals the genesis of moduleName, which must be set.
+func unmarshalGenesis(cdc codec.JSONCodec, genState map[string]json.RawMessage, moduleName string, out proto.Message) error {
+	bz, ok := genState[moduleName]
+	if !ok {
+		return fmt.Errorf("no %s genesis", moduleName)
+	}
+	if err := cdc.UnmarshalJSON(bz, out); err != nil {
+		return fmt.Errorf("failed to unmarshal %s genesis: %w", moduleName, err)
+	}
+
+	return nil
+}
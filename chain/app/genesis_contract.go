@@ -0,0 +1,275 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	precisebanktypes "github.com/cosmos/evm/x/precisebank/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// GenesisContract is a contract to place in the EVM genesis.
+type GenesisContract struct {
+	Address common.Address
+	// Code is the runtime bytecode.
+	Code    []byte
+	Storage map[common.Hash]common.Hash
+	// Balance is in ExtendedDenom, the wei of the EVM.
+	Balance math.Int
+	// Nonce is the sequence of its account.
+	Nonce uint64
+}
+
+// AddGenesisContract adds contract to genState: an account in auth, its code
+// and storage in the EVM and its balance, see AddGenesisBalance. The address
+// must not be taken by an account, a module account or a precompile.
+func AddGenesisContract(cdc codec.Codec, genState map[string]json.RawMessage, contract GenesisContract) error {
+	if len(contract.Code) == 0 {
+		return fmt.Errorf("contract %s has no code", contract.Address)
+	}
+
+	var evmGenState evmtypes.GenesisState
+	if err := unmarshalGenesis(cdc, genState, evmtypes.ModuleName, &evmGenState); err != nil {
+		return err
+	}
+	if err := checkGenesisAddressFree(&evmGenState, contract.Address); err != nil {
+		return err
+	}
+
+	return addGenesisEVMAccounts(cdc, genState, &evmGenState, []GenesisContract{contract})
+}
+
+// addGenesisEVMAccounts adds accounts to genState: their code and storage,
+// if any, to evmGenState, which it writes back, an account in auth and their
+// balances. Each genesis state is decoded, validated and encoded once.
+func addGenesisEVMAccounts(cdc codec.Codec, genState map[string]json.RawMessage, evmGenState *evmtypes.GenesisState, accounts []GenesisContract) error {
+	contracts := false
+	for _, account := range accounts {
+		if !isContract(account) {
+			continue
+		}
+		storage := make(evmtypes.Storage, 0, len(account.Storage))
+		for key, value := range account.Storage {
+			storage = append(storage, evmtypes.NewState(key, value))
+		}
+		sort.Slice(storage, func(i, j int) bool { return storage[i].Key < storage[j].Key })
+
+		evmGenState.Accounts = append(evmGenState.Accounts, evmtypes.GenesisAccount{
+			Address: account.Address.Hex(),
+			Code:    common.Bytes2Hex(account.Code),
+			Storage: storage,
+		})
+		contracts = true
+	}
+	if contracts {
+		if err := evmGenState.Validate(); err != nil {
+			return err
+		}
+		genState[evmtypes.ModuleName] = cdc.MustMarshalJSON(evmGenState)
+	}
+
+	if err := addGenesisAccounts(cdc, genState, accounts); err != nil {
+		return err
+	}
+	return addGenesisBalances(cdc, genState, accounts)
+}
+
+// checkGenesisAddressFree returns an error if addr is a module account, a
+// static precompile, or an account or preinstall of the EVM genesis.
+func checkGenesisAddressFree(evmGenState *evmtypes.GenesisState, addr common.Address) error {
+	for name := range GetMaccPerms() {
+		if common.BytesToAddress(authtypes.NewModuleAddress(name)) == addr {
+			return fmt.Errorf("%s is the %s module account", addr, name)
+		}
+	}
+	if slices.Contains(StaticPrecompileAddresses(), addr.Hex()) {
+		return fmt.Errorf("%s is a static precompile", addr)
+	}
+	for _, acc := range evmGenState.Accounts {
+		if common.HexToAddress(acc.Address) == addr {
+			return fmt.Errorf("%s is already an EVM genesis account", addr)
+		}
+	}
+	for _, preinstall := range evmGenState.Preinstalls {
+		if common.HexToAddress(preinstall.Address) == addr {
+			return fmt.Errorf("%s is the %s preinstall", addr, preinstall.Name)
+		}
+	}
+
+	return nil
+}
+
+// addGenesisAccounts adds a base account of each of accounts, with its
+// nonce as sequence, to the auth genesis.
+func addGenesisAccounts(cdc codec.Codec, genState map[string]json.RawMessage, accounts []GenesisContract) error {
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, genState)
+	genAccounts, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return err
+	}
+
+	taken := make(map[string]bool, len(genAccounts)+len(accounts))
+	for _, acc := range genAccounts {
+		taken[acc.GetAddress().String()] = true
+	}
+	for _, account := range accounts {
+		addr := sdk.AccAddress(account.Address.Bytes())
+		if taken[addr.String()] {
+			return fmt.Errorf("%s is already a genesis account", addr)
+		}
+		taken[addr.String()] = true
+		genAccounts = append(genAccounts, authtypes.NewBaseAccount(addr, nil, 0, account.Nonce))
+	}
+
+	if authGenState.Accounts, err = authtypes.PackAccounts(authtypes.SanitizeGenesisAccounts(genAccounts)); err != nil {
+		return err
+	}
+	genState[authtypes.ModuleName] = cdc.MustMarshalJSON(&authGenState)
+
+	return nil
+}
+
+// AddGenesisBalance adds amount of ExtendedDenom to the genesis balance of
+// addr: its whole BaseDenom in bank and the rest as a precisebank fractional
+// balance. As when precisebank mints, its reserve holds the BaseDenom backing
+// the fractional balances and the remainder. A nil or zero amount adds
+// nothing.
+func AddGenesisBalance(cdc codec.JSONCodec, genState map[string]json.RawMessage, addr sdk.AccAddress, amount math.Int) error {
+	return addGenesisBalances(cdc, genState, []GenesisContract{{Address: common.BytesToAddress(addr), Balance: amount}})
+}
+
+// addGenesisBalances adds the balance of each of accounts as
+// AddGenesisBalance does, decoding and encoding bank and precisebank once.
+func addGenesisBalances(cdc codec.JSONCodec, genState map[string]json.RawMessage, accounts []GenesisContract) error {
+	conversionFactor := math.NewIntWithDecimal(1, ExtendedDenomDecimals-BaseDenomDecimals)
+
+	integers := make(map[string]math.Int)
+	fractionals := make(map[string]math.Int)
+	var order []string
+	for _, account := range accounts {
+		amount := account.Balance
+		if amount.IsNil() || amount.IsZero() {
+			continue
+		}
+		if amount.IsNegative() {
+			return fmt.Errorf("negative balance %s%s", amount, ExtendedDenom)
+		}
+		addr := sdk.AccAddress(account.Address.Bytes()).String()
+		if _, ok := fractionals[addr]; !ok {
+			integers[addr], fractionals[addr] = math.ZeroInt(), math.ZeroInt()
+			order = append(order, addr)
+		}
+		integers[addr] = integers[addr].Add(amount.Quo(conversionFactor))
+		fractionals[addr] = fractionals[addr].Add(amount.Mod(conversionFactor))
+	}
+	if len(order) == 0 {
+		return nil
+	}
+
+	var precisebankGenState precisebanktypes.GenesisState
+	if err := unmarshalGenesis(cdc, genState, precisebanktypes.ModuleName, &precisebankGenState); err != nil {
+		return err
+	}
+	var bankGenState banktypes.GenesisState
+	if err := unmarshalGenesis(cdc, genState, banktypes.ModuleName, &bankGenState); err != nil {
+		return err
+	}
+
+	// the fractional balances of accounts are merged into theirs, then the
+	// new ones are appended in order
+	balances := make(precisebanktypes.FractionalBalances, 0, len(precisebankGenState.Balances)+len(order))
+	for _, bal := range precisebankGenState.Balances {
+		if fractional, ok := fractionals[bal.Address]; ok {
+			fractionals[bal.Address] = fractional.Add(bal.Amount)
+			continue
+		}
+		balances = append(balances, bal)
+	}
+	for _, addr := range order {
+		fractional := fractionals[addr]
+		integers[addr] = integers[addr].Add(fractional.Quo(conversionFactor))
+		if fractional = fractional.Mod(conversionFactor); fractional.IsPositive() {
+			balances = append(balances, precisebanktypes.NewFractionalBalance(addr, fractional))
+		}
+	}
+
+	total := balances.SumAmount()
+	remainder := conversionFactor.Sub(total.Mod(conversionFactor)).Mod(conversionFactor)
+	reserve := total.Add(remainder).Quo(conversionFactor)
+	precisebankGenState.Balances = balances
+	precisebankGenState.Remainder = remainder
+
+	reserveAddr := authtypes.NewModuleAddress(precisebanktypes.ModuleName).String()
+	if _, ok := integers[reserveAddr]; !ok {
+		integers[reserveAddr] = math.ZeroInt()
+		order = append(order, reserveAddr)
+	}
+	integers[reserveAddr] = integers[reserveAddr].Add(reserve.Sub(bankBalance(&bankGenState, reserveAddr)))
+	addBankBalances(&bankGenState, order, integers)
+
+	genState[precisebanktypes.ModuleName] = cdc.MustMarshalJSON(&precisebankGenState)
+	genState[banktypes.ModuleName] = cdc.MustMarshalJSON(&bankGenState)
+
+	return nil
+}
+
+// bankBalance returns the BaseDenom genesis balance of addr.
+func bankBalance(bankGenState *banktypes.GenesisState, addr string) math.Int {
+	for _, bal := range bankGenState.Balances {
+		if bal.Address == addr {
+			return bal.Coins.AmountOf(BaseDenom)
+		}
+	}
+	return math.ZeroInt()
+}
+
+// addBankBalances adds amounts[addr], which may be negative, of BaseDenom to
+// the genesis balance of each addr of order and to the supply, unless bank
+// computes it.
+func addBankBalances(bankGenState *banktypes.GenesisState, order []string, amounts map[string]math.Int) {
+	coins := func(amount math.Int) sdk.Coins {
+		return sdk.NewCoins(sdk.NewCoin(BaseDenom, amount.Abs()))
+	}
+	add := func(c sdk.Coins, amount math.Int) sdk.Coins {
+		if amount.IsNegative() {
+			return c.Sub(coins(amount)...)
+		}
+		return c.Add(coins(amount)...)
+	}
+
+	balances := make([]banktypes.Balance, 0, len(bankGenState.Balances)+len(order))
+	found := make(map[string]bool, len(order))
+	for _, bal := range bankGenState.Balances {
+		if amount, ok := amounts[bal.Address]; ok {
+			found[bal.Address] = true
+			if bal.Coins = add(bal.Coins, amount); bal.Coins.IsZero() {
+				continue
+			}
+		}
+		balances = append(balances, bal)
+	}
+	total := math.ZeroInt()
+	for _, addr := range order {
+		amount := amounts[addr]
+		total = total.Add(amount)
+		if !found[addr] && !amount.IsZero() {
+			balances = append(balances, banktypes.Balance{Address: addr, Coins: add(sdk.NewCoins(), amount)})
+		}
+	}
+
+	bankGenState.Balances = banktypes.SanitizeGenesisBalances(balances)
+	if !bankGenState.Supply.Empty() && !total.IsZero() {
+		bankGenState.Supply = add(bankGenState.Supply, total)
+	}
+}
@@ -0,0 +1,88 @@
+package app
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// ImportEVMState adds the accounts of an EVM state dump to genState, each as
+// AddGenesisContract does, except that accounts without code may be genesis
+// accounts already, which then get the balance. Dumps of a dev node hold
+// predeploys too, so a preinstall with the same code only gets the balance.
+//
+// It adds nothing if an address is a module account, a static precompile or
+// a contract of genState, and reports all of them.
+func ImportEVMState(cdc codec.Codec, genState map[string]json.RawMessage, accounts []GenesisContract) error {
+	var evmGenState evmtypes.GenesisState
+	if err := unmarshalGenesis(cdc, genState, evmtypes.ModuleName, &evmGenState); err != nil {
+		return err
+	}
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, genState)
+	existing, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	if err != nil {
+		return err
+	}
+
+	var collisions []error
+	for _, account := range accounts {
+		if isPreinstall(&evmGenState, account) {
+			continue
+		}
+		if err := checkGenesisAddressFree(&evmGenState, account.Address); err != nil {
+			collisions = append(collisions, err)
+			continue
+		}
+		if isContract(account) && existing.Contains(sdk.AccAddress(account.Address.Bytes())) {
+			collisions = append(collisions, fmt.Errorf("%s is already a genesis account", account.Address))
+		}
+	}
+	if len(collisions) > 0 {
+		return fmt.Errorf("%d addresses are taken: %w", len(collisions), errors.Join(collisions...))
+	}
+
+	var balances, evmAccounts []GenesisContract
+	for _, account := range accounts {
+		if isPreinstall(&evmGenState, account) || (!isContract(account) && existing.Contains(sdk.AccAddress(account.Address.Bytes()))) {
+			balances = append(balances, account)
+		} else {
+			evmAccounts = append(evmAccounts, account)
+		}
+	}
+	if err := addGenesisEVMAccounts(cdc, genState, &evmGenState, evmAccounts); err != nil {
+		return fmt.Errorf("failed to import the accounts: %w", err)
+	}
+	if err := addGenesisBalances(cdc, genState, balances); err != nil {
+		return fmt.Errorf("failed to import the balances: %w", err)
+	}
+
+	return nil
+}
+
+// isContract returns whether account has code or storage.
+func isContract(account GenesisContract) bool {
+	return len(account.Code) > 0 || len(account.Storage) > 0
+}
+
+// isPreinstall returns whether account is a preinstall of evmGenState, with
+// the same code and no storage.
+func isPreinstall(evmGenState *evmtypes.GenesisState, account GenesisContract) bool {
+	if len(account.Storage) > 0 {
+		return false
+	}
+	for _, preinstall := range evmGenState.Preinstalls {
+		if common.HexToAddress(preinstall.Address) == account.Address {
+			return bytes.Equal(common.FromHex(preinstall.Code), account.Code)
+		}
+	}
+	return false
+}
@@ -0,0 +1,239 @@
+package app_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	precisebanktypes "github.com/cosmos/evm/x/precisebank/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/app"
+	"mirrorvault/predeploy"
+)
+
+func TestValidateGenesisDenoms(t *testing.T) {
+	require.Equal(t, app.BaseDenom, sdk.DefaultBondDenom)
+	cdc := codec.NewProtoCodec(codectypes.NewInterfaceRegistry())
+
+	newGenesis := func() map[string]json.RawMessage {
+		return map[string]json.RawMessage{
+			stakingtypes.ModuleName: cdc.MustMarshalJSON(stakingtypes.DefaultGenesisState()),
+			evmtypes.ModuleName:     cdc.MustMarshalJSON(app.NewEVMGenesisState()),
+			banktypes.ModuleName:    cdc.MustMarshalJSON(app.NewBankGenesisState()),
+		}
+	}
+	require.NoError(t, app.ValidateGenesisDenoms(cdc, newGenesis()))
+	require.NoError(t, app.NewBankGenesisState().Validate())
+
+	for name, modify := range map[string]func(genState map[string]json.RawMessage){
+		"bond denom": func(genState map[string]json.RawMessage) {
+			stakingGenState := stakingtypes.DefaultGenesisState()
+			stakingGenState.Params.BondDenom = "stake"
+			genState[stakingtypes.ModuleName] = cdc.MustMarshalJSON(stakingGenState)
+		},
+		"mint denom": func(genState map[string]json.RawMessage) {
+			mintGenState := minttypes.DefaultGenesisState()
+			mintGenState.Params.MintDenom = "stake"
+			genState[minttypes.ModuleName] = cdc.MustMarshalJSON(mintGenState)
+		},
+		"evm denom": func(genState map[string]json.RawMessage) {
+			evmGenState := app.NewEVMGenesisState()
+			evmGenState.Params.EvmDenom = "aatom"
+			genState[evmtypes.ModuleName] = cdc.MustMarshalJSON(evmGenState)
+		},
+		"extended denom": func(genState map[string]json.RawMessage) {
+			evmGenState := app.NewEVMGenesisState()
+			evmGenState.Params.ExtendedDenomOptions = nil
+			genState[evmtypes.ModuleName] = cdc.MustMarshalJSON(evmGenState)
+		},
+		"no metadata": func(genState map[string]json.RawMessage) {
+			genState[banktypes.ModuleName] = cdc.MustMarshalJSON(banktypes.DefaultGenesisState())
+		},
+		"extended decimals": func(genState map[string]json.RawMessage) {
+			bankGenState := app.NewBankGenesisState()
+			bankGenState.DenomMetadata[1].DenomUnits[2].Exponent = 6
+			genState[banktypes.ModuleName] = cdc.MustMarshalJSON(bankGenState)
+		},
+		"no evm": func(genState map[string]json.RawMessage) {
+			delete(genState, evmtypes.ModuleName)
+		},
+	} {
+		genState := newGenesis()
+		modify(genState)
+		require.Error(t, app.ValidateGenesisDenoms(cdc, genState), name)
+	}
+
+	// a mint genesis minting the native coin is fine
+	genState := newGenesis()
+	mintGenState := minttypes.DefaultGenesisState()
+	mintGenState.Params.MintDenom = app.BaseDenom
+	genState[minttypes.ModuleName] = cdc.MustMarshalJSON(mintGenState)
+	require.NoError(t, app.ValidateGenesisDenoms(cdc, genState))
+}
+
+func TestAddGenesisContract(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	authtypes.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	genState := map[string]json.RawMessage{
+		evmtypes.ModuleName:         cdc.MustMarshalJSON(app.NewEVMGenesisState()),
+		banktypes.ModuleName:        cdc.MustMarshalJSON(app.NewBankGenesisState()),
+		precisebanktypes.ModuleName: cdc.MustMarshalJSON(precisebanktypes.DefaultGenesisState()),
+	}
+	contract := app.GenesisContract{
+		Address: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Code:    common.FromHex("602a60005260206000f3"),
+		Storage: map[common.Hash]common.Hash{{}: common.HexToHash("0x2a")},
+		// 1umvlt and a fractional 1amvlt
+		Balance: math.NewInt(1_000_000_000_001),
+	}
+	require.NoError(t, app.AddGenesisContract(cdc, genState, contract))
+
+	var evmGenState evmtypes.GenesisState
+	cdc.MustUnmarshalJSON(genState[evmtypes.ModuleName], &evmGenState)
+	require.Len(t, evmGenState.Accounts, 1)
+	require.Equal(t, "602a60005260206000f3", evmGenState.Accounts[0].Code)
+	require.Equal(t, common.HexToHash("0x2a").Hex(), evmGenState.Accounts[0].Storage[0].Value)
+
+	addr := sdk.AccAddress(contract.Address.Bytes())
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, genState)
+	accounts, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	require.NoError(t, err)
+	require.True(t, accounts.Contains(addr))
+
+	// precisebank reserves a whole umvlt for the fractional balance
+	var precisebankGenState precisebanktypes.GenesisState
+	cdc.MustUnmarshalJSON(genState[precisebanktypes.ModuleName], &precisebankGenState)
+	require.Equal(t, math.NewInt(1), precisebankGenState.Balances.SumAmount())
+	require.Equal(t, math.NewInt(999_999_999_999), precisebankGenState.Remainder)
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, genState)
+	require.NoError(t, bankGenState.Validate())
+	coins := func(addr sdk.AccAddress) sdk.Coins {
+		for _, bal := range bankGenState.Balances {
+			if bal.Address == addr.String() {
+				return bal.Coins
+			}
+		}
+		return nil
+	}
+	require.Equal(t, "1"+app.BaseDenom, coins(addr).String())
+	require.Equal(t, "1"+app.BaseDenom, coins(authtypes.NewModuleAddress(precisebanktypes.ModuleName)).String())
+
+	// a second fractional balance completing a umvlt uses up the remainder
+	other := sdk.AccAddress(common.HexToAddress("0x2222222222222222222222222222222222222222").Bytes())
+	require.NoError(t, app.AddGenesisBalance(cdc, genState, other, math.NewInt(999_999_999_999)))
+	cdc.MustUnmarshalJSON(genState[precisebanktypes.ModuleName], &precisebankGenState)
+	require.True(t, precisebankGenState.Remainder.IsZero())
+	bankGenState = banktypes.GetGenesisStateFromAppState(cdc, genState)
+	require.Equal(t, "1"+app.BaseDenom, coins(authtypes.NewModuleAddress(precisebanktypes.ModuleName)).String())
+	require.Empty(t, coins(other))
+
+	for name, addr := range map[string]common.Address{
+		"taken":      contract.Address,
+		"module":     common.BytesToAddress(authtypes.NewModuleAddress(evmtypes.ModuleName)),
+		"precompile": common.HexToAddress(app.StaticPrecompileAddresses()[0]),
+		"preinstall": predeploy.VaultGateAddress,
+	} {
+		err := app.AddGenesisContract(cdc, genState, app.GenesisContract{Address: addr, Code: contract.Code})
+		require.Error(t, err, name)
+	}
+	require.Error(t, app.AddGenesisContract(cdc, genState, app.GenesisContract{Address: common.HexToAddress("0x33")}))
+}
+
+func TestImportEVMState(t *testing.T) {
+	registry := codectypes.NewInterfaceRegistry()
+	authtypes.RegisterInterfaces(registry)
+	cdc := codec.NewProtoCodec(registry)
+
+	genState := map[string]json.RawMessage{
+		evmtypes.ModuleName:         cdc.MustMarshalJSON(app.NewEVMGenesisState()),
+		banktypes.ModuleName:        cdc.MustMarshalJSON(app.NewBankGenesisState()),
+		precisebanktypes.ModuleName: cdc.MustMarshalJSON(precisebanktypes.DefaultGenesisState()),
+	}
+	funded := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	require.NoError(t, app.ImportEVMState(cdc, genState, []app.GenesisContract{{Address: funded, Balance: math.NewInt(5e12)}}))
+
+	gate := predeploy.Defaults()[0]
+	contract := app.GenesisContract{
+		Address: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+		Code:    common.FromHex("602a60005260206000f3"),
+		Nonce:   1,
+	}
+	eoa := app.GenesisContract{
+		Address: common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		Balance: math.NewInt(1),
+		Nonce:   7,
+	}
+
+	// collisions are all reported, and nothing is imported
+	feeCollector := common.BytesToAddress(authtypes.NewModuleAddress(authtypes.FeeCollectorName))
+	precompile := common.HexToAddress(app.StaticPrecompileAddresses()[0])
+	err := app.ImportEVMState(cdc, genState, []app.GenesisContract{
+		contract,
+		{Address: feeCollector, Balance: math.NewInt(1)},
+		{Address: precompile, Code: contract.Code},
+		{Address: gate.Address, Code: contract.Code},
+	})
+	require.ErrorContains(t, err, "3 addresses are taken")
+	require.ErrorContains(t, err, feeCollector.Hex())
+	require.ErrorContains(t, err, precompile.Hex())
+	require.ErrorContains(t, err, gate.Address.Hex())
+	require.NotContains(t, err.Error(), contract.Address.Hex())
+
+	var evmGenState evmtypes.GenesisState
+	cdc.MustUnmarshalJSON(genState[evmtypes.ModuleName], &evmGenState)
+	require.Empty(t, evmGenState.Accounts)
+
+	require.NoError(t, app.ImportEVMState(cdc, genState, []app.GenesisContract{
+		contract,
+		eoa,
+		// a funded genesis account and a predeploy get the balance
+		{Address: funded, Balance: math.NewInt(1e12)},
+		{Address: gate.Address, Code: gate.Code, Balance: math.NewInt(1e12)},
+	}))
+
+	cdc.MustUnmarshalJSON(genState[evmtypes.ModuleName], &evmGenState)
+	require.Len(t, evmGenState.Accounts, 1)
+	require.Equal(t, contract.Address.Hex(), evmGenState.Accounts[0].Address)
+
+	authGenState := authtypes.GetGenesisStateFromAppState(cdc, genState)
+	accounts, err := authtypes.UnpackAccounts(authGenState.Accounts)
+	require.NoError(t, err)
+	require.Len(t, accounts, 3)
+	for _, acc := range accounts {
+		switch common.BytesToAddress(acc.GetAddress()) {
+		case funded:
+			require.Zero(t, acc.GetSequence())
+		case contract.Address:
+			require.Equal(t, uint64(1), acc.GetSequence())
+		case eoa.Address:
+			require.Equal(t, uint64(7), acc.GetSequence())
+		default:
+			t.Fatalf("unexpected account %s", acc.GetAddress())
+		}
+	}
+
+	bankGenState := banktypes.GetGenesisStateFromAppState(cdc, genState)
+	balances := map[common.Address]string{}
+	for _, bal := range bankGenState.Balances {
+		balances[common.BytesToAddress(sdk.MustAccAddressFromBech32(bal.Address))] = bal.Coins.String()
+	}
+	require.Equal(t, "6"+app.BaseDenom, balances[funded])
+	require.Equal(t, "1"+app.BaseDenom, balances[gate.Address])
+	// the reserve of the 1amvlt of eoa
+	require.Equal(t, "1"+app.BaseDenom, balances[common.BytesToAddress(authtypes.NewModuleAddress(precisebanktypes.ModuleName))])
+}
@@ -0,0 +1,154 @@
+package app
+
+import (
+	"errors"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+	"cosmossdk.io/x/feegrant"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	evmantedecorators "github.com/cosmos/evm/ante/evm"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/feeabs"
+)
+
+// NewPostHandler returns the post handler, which refunds the unused gas of
+// Cosmos txs.
+func NewPostHandler(app *App) sdk.PostHandler {
+	return sdk.ChainPostDecorators(gasRefundDecorator{app})
+}
+
+// gasRefundDecorator refunds the fee of the gas a Cosmos tx didn't use, at
+// the price it paid, to the account the fee was deducted from. Fees paid in
+// a fee token are refunded in that token. A fee paid by a fee granter is
+// also credited back to the allowance it was deducted from. Ethereum txs are
+// refunded by the EVM.
+//
+// The SDK discards the state of failed txs, refunds included, so unlike
+// reverted Ethereum txs, failed Cosmos txs pay their whole gas limit.
+type gasRefundDecorator struct {
+	app *App
+}
+
+// PostHandle refunds the unused gas, then runs the next decorator.
+func (d gasRefundDecorator) PostHandle(ctx sdk.Context, tx sdk.Tx, simulate, success bool, next sdk.PostHandler) (sdk.Context, error) {
+	if err := d.refund(ctx, tx, simulate, success); err != nil {
+		return ctx, err
+	}
+
+	return next(ctx, tx, simulate, success)
+}
+
+func (d gasRefundDecorator) refund(ctx sdk.Context, tx sdk.Tx, simulate, success bool) error {
+	// genesis txs go through the SDK fee logic
+	if simulate || !success || ctx.BlockHeight() == 0 {
+		return nil
+	}
+	if txWithExtensions, ok := tx.(ante.HasExtensionOptionsTx); ok {
+		if opts := txWithExtensions.GetExtensionOptions(); len(opts) > 0 && opts[0].GetTypeUrl() == ethereumTxExtensionOption {
+			return nil
+		}
+	}
+
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return errorsmod.Wrap(errortypes.ErrTxDecode, "tx must be a FeeTx")
+	}
+	gasLimit, gasUsed := feeTx.GetGas(), ctx.GasMeter().GasConsumed()
+	if gasLimit == 0 || gasUsed >= gasLimit {
+		return nil
+	}
+
+	// like the EVM refund, it is not charged to the tx
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	// the fee deducted in the ante handler, with the same params
+	token, isFeeToken, err := d.app.FeeAbsKeeper.FeeToken(ctx, feeTx.GetFee())
+	if err != nil {
+		return err
+	}
+	checkedTx := feeTx
+	if isFeeToken {
+		checkedTx = feeabs.NewNativeFeeTx(feeTx, token)
+	}
+	feemarketParams := d.app.FeeMarketKeeper.GetParams(ctx)
+	denom := evmtypes.GetEVMCoinDenom()
+	fee, _, err := evmantedecorators.FeeChecker(ctx, &feemarketParams, denom, evmtypes.GetEthChainConfig(), checkedTx)
+	if err != nil {
+		return err
+	}
+
+	refund := fee.AmountOf(denom).Mul(math.NewIntFromUint64(gasLimit - gasUsed)).Quo(math.NewIntFromUint64(gasLimit))
+	if !refund.IsPositive() {
+		return nil
+	}
+
+	payer := sdk.AccAddress(feeTx.FeePayer())
+	if isFeeToken {
+		_, err := d.app.FeeAbsKeeper.RefundFee(ctx, payer, token, refund)
+		return err
+	}
+	refundCoins := sdk.NewCoins(sdk.NewCoin(denom, refund))
+	// as in the ante handler, a fee payer granting itself pays the fee
+	if granter := sdk.AccAddress(feeTx.FeeGranter()); granter != nil && !granter.Equals(payer) {
+		if err := d.restoreAllowance(ctx, granter, payer, refundCoins); err != nil {
+			return err
+		}
+		payer = granter
+	}
+
+	return d.app.BankKeeper.SendCoinsFromModuleToAccount(ctx, authtypes.FeeCollectorName, payer, refundCoins)
+}
+
+// restoreAllowance credits refund back to the allowance of grantee from
+// granter, which the ante handler deducted the whole fee from. An allowance
+// the fee used up was removed, and stays so.
+func (d gasRefundDecorator) restoreAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress, refund sdk.Coins) error {
+	allowance, err := d.app.FeeGrantKeeper.GetAllowance(ctx, granter, grantee)
+	if errors.Is(err, errortypes.ErrNotFound) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	allowance, err = creditAllowance(allowance, refund)
+	if err != nil {
+		return err
+	}
+
+	return d.app.FeeGrantKeeper.UpdateAllowance(ctx, granter, grantee, allowance)
+}
+
+// creditAllowance adds amount to what allowance can spend. Unlimited
+// allowances and allowances of other types are returned unchanged.
+func creditAllowance(allowance feegrant.FeeAllowanceI, amount sdk.Coins) (feegrant.FeeAllowanceI, error) {
+	switch a := allowance.(type) {
+	case *feegrant.BasicAllowance:
+		if !a.SpendLimit.Empty() {
+			a.SpendLimit = a.SpendLimit.Add(amount...)
+		}
+	case *feegrant.PeriodicAllowance:
+		if !a.Basic.SpendLimit.Empty() {
+			a.Basic.SpendLimit = a.Basic.SpendLimit.Add(amount...)
+		}
+		a.PeriodCanSpend = a.PeriodCanSpend.Add(amount...)
+	case *feegrant.AllowedMsgAllowance:
+		inner, err := a.GetAllowance()
+		if err != nil {
+			return nil, err
+		}
+		if inner, err = creditAllowance(inner, amount); err != nil {
+			return nil, err
+		}
+		return feegrant.NewAllowedMsgAllowance(inner, a.AllowedMessages)
+	}
+
+	return allowance, nil
+}
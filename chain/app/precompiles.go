@@ -0,0 +1,87 @@
+package app
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	distrkeeper "github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	govkeeper "github.com/cosmos/cosmos-sdk/x/gov/keeper"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+
+	evmaddress "github.com/cosmos/evm/encoding/address"
+	bankprecompile "github.com/cosmos/evm/precompiles/bank"
+	distrprecompile "github.com/cosmos/evm/precompiles/distribution"
+	govprecompile "github.com/cosmos/evm/precompiles/gov"
+	stakingprecompile "github.com/cosmos/evm/precompiles/staking"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	bech32precompile "mirrorvault/precompiles/bech32"
+	bridgeprecompile "mirrorvault/x/bridge/precompile"
+	callbacksprecompile "mirrorvault/x/callbacks/precompile"
+	dispatchprecompile "mirrorvault/x/dispatch/precompile"
+	feeabsprecompile "mirrorvault/x/feeabs/precompile"
+	sponsorprecompile "mirrorvault/x/sponsor/precompile"
+)
+
+// Addresses of the cosmos/evm precompiles the app registers.
+var (
+	stakingPrecompileAddress = common.HexToAddress(evmtypes.StakingPrecompileAddress)
+	distrPrecompileAddress   = common.HexToAddress(evmtypes.DistributionPrecompileAddress)
+	bankPrecompileAddress    = common.HexToAddress(evmtypes.BankPrecompileAddress)
+	govPrecompileAddress     = common.HexToAddress(evmtypes.GovPrecompileAddress)
+)
+
+// StaticPrecompileAddresses returns the addresses of the static precompiles
+// of the app, which the default EVM genesis activates. They are sorted, as
+// the EVM params require.
+func StaticPrecompileAddresses() []string {
+	return []string{
+		bridgeprecompile.Address.Hex(),
+		stakingPrecompileAddress.Hex(),
+		distrPrecompileAddress.Hex(),
+		bankPrecompileAddress.Hex(),
+		govPrecompileAddress.Hex(),
+		feeabsprecompile.Address.Hex(),
+		sponsorprecompile.Address.Hex(),
+		bech32precompile.Address.Hex(),
+		dispatchprecompile.Address.Hex(),
+		callbacksprecompile.Address.Hex(),
+	}
+}
+
+// registerStaticPrecompiles registers the static precompiles on the EVM
+// keeper. They only run once their address is in the active static
+// precompiles of the EVM params, which gov can update.
+func (app *App) registerStaticPrecompiles() {
+	app.EVMKeeper.RegisterStaticPrecompile(feeabsprecompile.Address, feeabsprecompile.NewPrecompile(app.FeeAbsKeeper, app.BankKeeper))
+	app.EVMKeeper.RegisterStaticPrecompile(sponsorprecompile.Address, sponsorprecompile.NewPrecompile(app.SponsorKeeper, app.BankKeeper))
+	app.EVMKeeper.RegisterStaticPrecompile(bech32precompile.Address, bech32precompile.NewPrecompile())
+	app.EVMKeeper.RegisterStaticPrecompile(dispatchprecompile.Address, dispatchprecompile.NewPrecompile(app.DispatchKeeper, app.BankKeeper))
+	app.EVMKeeper.RegisterStaticPrecompile(callbacksprecompile.Address, callbacksprecompile.NewPrecompile(app.CallbacksKeeper, app.BankKeeper))
+	app.EVMKeeper.RegisterStaticPrecompile(bridgeprecompile.Address, bridgeprecompile.NewPrecompile(app.BridgeKeeper, app.BankKeeper))
+
+	// the cosmos/evm precompiles take hex and bech32 account addresses
+	addrCodec := evmaddress.NewEvmCodec(AccountAddressPrefix)
+	app.EVMKeeper.RegisterStaticPrecompile(stakingPrecompileAddress, stakingprecompile.NewPrecompile(
+		*app.StakingKeeper,
+		stakingkeeper.NewMsgServerImpl(app.StakingKeeper),
+		stakingkeeper.NewQuerier(app.StakingKeeper),
+		app.BankKeeper,
+		addrCodec,
+	))
+	app.EVMKeeper.RegisterStaticPrecompile(distrPrecompileAddress, distrprecompile.NewPrecompile(
+		app.DistrKeeper,
+		distrkeeper.NewMsgServerImpl(app.DistrKeeper),
+		distrkeeper.NewQuerier(app.DistrKeeper),
+		*app.StakingKeeper,
+		app.BankKeeper,
+		addrCodec,
+	))
+	app.EVMKeeper.RegisterStaticPrecompile(bankPrecompileAddress, bankprecompile.NewPrecompile(app.BankKeeper, &app.Erc20Keeper))
+	app.EVMKeeper.RegisterStaticPrecompile(govPrecompileAddress, govprecompile.NewPrecompile(
+		govkeeper.NewMsgServerImpl(app.GovKeeper),
+		govkeeper.NewQueryServer(app.GovKeeper),
+		app.BankKeeper,
+		app.appCodec,
+		addrCodec,
+	))
+}
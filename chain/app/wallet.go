@@ -0,0 +1,53 @@
+package app
+
+import (
+	"fmt"
+
+	"github.com/spf13/cast"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	srvflags "github.com/cosmos/evm/server/flags"
+
+	"mirrorvault/network"
+	"mirrorvault/walletconfig"
+)
+
+// WalletConfig returns the wallet onboarding config of a node. The endpoints,
+// minimum gas prices and EVM chain ID are read from the node's config.toml
+// and app.toml through appOpts, the rest from its network profile.
+func WalletConfig(appOpts servertypes.AppOptions, chainID string) (walletconfig.Config, error) {
+	profile, err := network.Select(cast.ToString(appOpts.Get(network.FlagNetwork)))
+	if err != nil {
+		return walletconfig.Config{}, err
+	}
+
+	minGasPrices, err := sdk.ParseDecCoins(cast.ToString(appOpts.Get("minimum-gas-prices")))
+	if err != nil {
+		return walletconfig.Config{}, fmt.Errorf("invalid minimum gas prices: %w", err)
+	}
+
+	evmChainID := cast.ToUint64(appOpts.Get(srvflags.EVMChainID))
+	if evmChainID == 0 {
+		evmChainID = profile.EVMChainID
+	}
+
+	cfg := walletconfig.Config{
+		ChainName:    Name,
+		ChainID:      chainID,
+		EVMChainID:   evmChainID,
+		Bech32Prefix: profile.Bech32Prefix,
+		CoinType:     profile.CoinType,
+		BaseDenom:    profile.BaseDenom,
+		DisplayDenom: profile.DisplayDenom,
+		Decimals:     profile.BaseDenomDecimals,
+		RPC:          walletconfig.PublicURL(cast.ToString(appOpts.Get("rpc.laddr"))),
+		REST:         walletconfig.PublicURL(cast.ToString(appOpts.Get("api.address"))),
+		MinGasPrices: minGasPrices,
+	}
+	if cast.ToBool(appOpts.Get(srvflags.JSONRPCEnable)) {
+		cfg.JSONRPC = walletconfig.PublicURL(cast.ToString(appOpts.Get(srvflags.JSONRPCAddress)))
+	}
+
+	return cfg, nil
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/debug"
 	"github.com/cosmos/cosmos-sdk/client/flags"
-	"github.com/cosmos/cosmos-sdk/client/keys"
 	"github.com/cosmos/cosmos-sdk/client/pruning"
 	"github.com/cosmos/cosmos-sdk/client/rpc"
 	"github.com/cosmos/cosmos-sdk/client/snapshot"
@@ -22,9 +21,12 @@ import (
 	"github.com/cosmos/cosmos-sdk/types/module"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
-	genutilcli "github.com/cosmos/cosmos-sdk/x/genutil/client/cli"
+
+	evmserver "github.com/cosmos/evm/server"
+	evmserverconfig "github.com/cosmos/evm/server/config"
 
 	"mirrorvault/app"
+	"mirrorvault/impersonate"
 )
 
 func initRootCmd(
@@ -33,31 +35,47 @@ func initRootCmd(
 	basicManager module.BasicManager,
 ) {
 	rootCmd.AddCommand(
-		genutilcli.InitCmd(basicManager, app.DefaultNodeHome),
+		initCmd(basicManager),
 		NewInPlaceTestnetCmd(),
-		NewTestnetMultiNodeCmd(basicManager, banktypes.GenesisBalancesIterator{}),
+		NewTestnetCmd(basicManager, banktypes.GenesisBalancesIterator{}),
 		debug.Cmd(),
 		confixcmd.ConfigCommand(),
 		pruning.Cmd(newApp, app.DefaultNodeHome),
 		snapshot.Cmd(newApp),
 	)
 
-	server.AddCommandsWithStartCmdOptions(rootCmd, app.DefaultNodeHome, newApp, appExport, server.StartCmdOptions{
-		AddFlags: addModuleInitFlags,
-	})
+	// the Cosmos EVM start command also runs the JSON-RPC server
+	evmserver.AddCommands(rootCmd, newStartOptions(), appExport, addModuleInitFlags)
 
 	// add keybase, auxiliary RPC, query, genesis, and tx child commands
 	rootCmd.AddCommand(
 		server.StatusCommand(),
-		genutilcli.Commands(txConfig, basicManager, app.DefaultNodeHome),
+		genesisCommand(txConfig, basicManager),
 		queryCommand(),
 		txCommand(),
-		keys.Commands(),
+		keysCommand(),
+		identityCommand(),
+		walletConfigCmd(),
+		networkCmd(),
+		localnetCmd(basicManager),
+		faucetCommand(),
+		gasCommand(),
+		doctorCmd(basicManager),
 	)
 }
 
+// newStartOptions returns the options of the start command.
+func newStartOptions() evmserver.StartOptions {
+	return evmserver.StartOptions{
+		AppCreator:      newApp,
+		DefaultNodeHome: app.DefaultNodeHome,
+		DBOpener:        evmserverconfig.OpenDB,
+	}
+}
+
 // addModuleInitFlags adds more flags to the start command.
 func addModuleInitFlags(startCmd *cobra.Command) {
+	startCmd.Flags().String(impersonate.FlagImpersonate, "", "Comma separated 0x addresses whose Ethereum txs a localnet runs without their keys")
 }
 
 func queryCommand() *cobra.Command {
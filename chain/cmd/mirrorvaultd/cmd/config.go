@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"fmt"
+
 	cmtcfg "github.com/cometbft/cometbft/config"
 	serverconfig "github.com/cosmos/cosmos-sdk/server/config"
 	cosmosevmserverconfig "github.com/cosmos/evm/server/config"
+
+	"mirrorvault/network"
 )
 
 // initCometBFTConfig helps to override default CometBFT Config values.
@@ -25,27 +29,53 @@ type EVMAppConfig struct {
 	EVM     cosmosevmserverconfig.EVMConfig     `mapstructure:"evm"`
 	JSONRPC cosmosevmserverconfig.JSONRPCConfig `mapstructure:"json-rpc"`
 	TLS     cosmosevmserverconfig.TLSConfig     `mapstructure:"tls"`
+
+	// Network is the name of the network profile of the node.
+	Network string `mapstructure:"network"`
+	// Impersonate is the comma separated addresses whose Ethereum txs the
+	// node runs without their keys, on a localnet only.
+	Impersonate string `mapstructure:"impersonate"`
 }
 
+// networkConfigTemplate is the app.toml section of the network profile. Its
+// keys are top level, so it comes before the sections of the default template.
+const networkConfigTemplate = `###############################################################################
+###                           Network Profile                               ###
+###############################################################################
+
+# The network profile the node runs as. The --network flag overrides it, and
+# $MIRRORVAULT_NETWORK applies when it is empty.
+network = "{{ .Network }}"
+
+# Comma separated 0x addresses whose Ethereum txs a localnet runs without
+# checking their signatures, which the impersonate JSON-RPC namespace sends.
+# A single validator network only: other nodes reject the txs.
+impersonate = "{{ .Impersonate }}"
+
+`
+
 // initAppConfig helps to override default appConfig template and configs.
 // return "", nil if no custom configuration is required for the application.
-func initAppConfig() (string, interface{}) {
+// The chain settings are the ones of the network profile.
+func initAppConfig(profile network.Profile) (string, interface{}) {
 	// Optionally allow the chain developer to overwrite the SDK's default
 	// server config.
 	srvCfg := serverconfig.DefaultConfig()
 
-	// Set minimum gas prices to 0 for development
-	// In production, validators should set this to a non-zero value
-	srvCfg.MinGasPrices = "0umvlt"
+	// Zero on a localnet, validators of a public network can raise it
+	srvCfg.MinGasPrices = profile.MinGasPrices
+	srvCfg.API.Address = fmt.Sprintf("tcp://localhost:%d", profile.Ports.API)
+	srvCfg.GRPC.Address = fmt.Sprintf("localhost:%d", profile.Ports.GRPC)
 
 	// EVM configuration
 	evmCfg := cosmosevmserverconfig.DefaultEVMConfig()
-	evmCfg.EVMChainID = 7777 // Mirror Vault EVM chain ID
+	evmCfg.EVMChainID = profile.EVMChainID
 
 	// JSON-RPC configuration
 	jsonrpcCfg := cosmosevmserverconfig.DefaultJSONRPCConfig()
 	jsonrpcCfg.Enable = true
-	jsonrpcCfg.Address = "0.0.0.0:8545"
+	jsonrpcCfg.Address = fmt.Sprintf("0.0.0.0:%d", profile.Ports.JSONRPC)
+	jsonrpcCfg.WsAddress = fmt.Sprintf("127.0.0.1:%d", profile.Ports.JSONRPCWS)
 	jsonrpcCfg.API = []string{"eth", "net", "web3", "txpool", "debug"}
 	// Note: EnableUnsafeCORS was removed in v0.5.0 - use reverse proxy for CORS in production // Enable CORS for local development
 
@@ -57,10 +87,28 @@ func initAppConfig() (string, interface{}) {
 		EVM:     *evmCfg,
 		JSONRPC: *jsonrpcCfg,
 		TLS:     *tlsCfg,
+		Network: profile.Name,
 	}
 
-	// Extend the default template with EVM sections
-	customAppTemplate := serverconfig.DefaultConfigTemplate + cosmosevmserverconfig.DefaultEVMConfigTemplate
+	return appConfigTemplate(), customAppConfig
+}
+
+// appConfigTemplate returns the app.toml template, the default one extended
+// with the network profile and EVM sections.
+func appConfigTemplate() string {
+	return networkConfigTemplate + serverconfig.DefaultConfigTemplate + cosmosevmserverconfig.DefaultEVMConfigTemplate
+}
 
-	return customAppTemplate, customAppConfig
+// writeAppConfig writes appConfig to the app.toml file, with the network
+// profile and impersonated addresses of the node.
+func writeAppConfig(file string, appConfig cosmosevmserverconfig.Config, networkName, impersonated string) {
+	serverconfig.SetConfigTemplate(appConfigTemplate())
+	serverconfig.WriteConfigFile(file, EVMAppConfig{
+		Config:      appConfig.Config,
+		EVM:         appConfig.EVM,
+		JSONRPC:     appConfig.JSONRPC,
+		TLS:         appConfig.TLS,
+		Network:     networkName,
+		Impersonate: impersonated,
+	})
 }
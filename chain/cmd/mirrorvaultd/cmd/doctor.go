@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"text/tabwriter"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	evmserverconfig "github.com/cosmos/evm/server/config"
+
+	"mirrorvault/app"
+	"mirrorvault/doctor"
+	"mirrorvault/impersonate"
+	"mirrorvault/network"
+)
+
+const flagFix = "fix"
+
+// doctorCmd returns the command diagnosing the home of a node.
+func doctorCmd(basicManager module.BasicManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the home directory of a node and its host",
+		Long: `Diagnose the home directory of a node and its host, and print how to fix
+each problem:
+
+- the genesis, node key, validator key and validator state files are there;
+- the genesis is valid for the modules and denoms of the binary and the chain
+  id of the network profile, and its gentxs are signed for its chain id;
+- client.toml and app.toml agree with the genesis and the network profile on
+  the chain ids, and the minimum gas prices are in ` + app.BaseDenom + `;
+- the ports of the servers are free. The process holding one is asked for its
+  CometBFT /status, REST node info or JSON-RPC chain id, to tell which chain
+  it runs;
+- the keys of the keyring are eth_secp256k1 keys;
+- the host has the memory to build the binary.
+
+--fix fixes the safe problems: it creates a missing validator state at height
+0 when the node has no blocks or is a localnet, sets the EVM chain id and
+minimum gas prices of the network profile in app.toml, and on a localnet moves
+the ports of the node to free ones. The command fails while problems remain.`,
+		Example: fmt.Sprintf(`%[1]s doctor --home ~/.mirrorvault
+%[1]s doctor --home /tmp/localnet --keyring-backend test --fix`, app.Name+"d"),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+			profile, err := networkProfile(cmd)
+			if err != nil {
+				return err
+			}
+			appConfig, err := evmserverconfig.GetConfig(serverCtx.Viper)
+			if err != nil {
+				return err
+			}
+
+			configDir := filepath.Join(serverCtx.Config.RootDir, "config")
+			fix, _ := cmd.Flags().GetBool(flagFix)
+			findings, err := doctor.Run(cmd.Context(), doctor.Options{
+				Config:        serverCtx.Config,
+				AppConfig:     &appConfig,
+				Profile:       profile,
+				ClientChainID: clientCtx.ChainID,
+				Keyring:       clientCtx.Keyring,
+				Codec:         clientCtx.Codec,
+				TxConfig:      clientCtx.TxConfig,
+				BasicManager:  basicManager,
+				Fix:           fix,
+				WriteConfig: func() error {
+					cmtcfg.WriteConfigFile(filepath.Join(configDir, "config.toml"), serverCtx.Config)
+					writeAppConfig(filepath.Join(configDir, "app.toml"), appConfig,
+						serverCtx.Viper.GetString(network.FlagNetwork), serverCtx.Viper.GetString(impersonate.FlagImpersonate))
+					return nil
+				},
+			})
+			if err != nil {
+				return err
+			}
+
+			if err := printFindings(cmd, clientCtx, findings); err != nil {
+				return err
+			}
+
+			failed, fixable := doctor.Failed(findings)
+			if failed == 0 {
+				return nil
+			}
+			// the findings tell what is wrong, not the usage
+			cmd.SilenceUsage = true
+			if fixable > 0 {
+				return fmt.Errorf("%d problems found, --%s fixes %d of them", failed, flagFix, fixable)
+			}
+			return fmt.Errorf("%d problems found", failed)
+		},
+	}
+
+	cmd.Flags().Bool(flagFix, false, "Fix the safe problems")
+	cmd.Flags().String(flags.FlagKeyringBackend, "", "Keyring backend of the keys to check (default the one of client.toml)")
+	cmd.Flags().StringP(flags.FlagOutput, "o", flags.OutputFormatText, "Output format (text|json)")
+
+	return cmd
+}
+
+func printFindings(cmd *cobra.Command, clientCtx client.Context, findings []doctor.Finding) error {
+	if clientCtx.OutputFormat == flags.OutputFormatJSON {
+		bz, err := json.Marshal(findings)
+		if err != nil {
+			return err
+		}
+		return clientCtx.PrintRaw(bz)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tCHECK\tMESSAGE")
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", f.Status, f.Check, f.Message)
+		if f.Hint == "" {
+			continue
+		}
+		if f.Fixable {
+			fmt.Fprintf(w, "\t\tfix (--%s): %s\n", flagFix, f.Hint)
+		} else {
+			fmt.Fprintf(w, "\t\tfix: %s\n", f.Hint)
+		}
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/spf13/cobra"
+
+	"mirrorvault/eip712"
+)
+
+// applyEIP712SignMode handles `--sign-mode eip712`. The tx is built as a
+// SIGN_MODE_LEGACY_AMINO_JSON tx and the keyring is wrapped so that the amino
+// JSON sign doc is signed as EIP-712 typed data, exactly as MetaMask would.
+func applyEIP712SignMode(cmd *cobra.Command, clientCtx client.Context) (client.Context, error) {
+	signModeFlag := cmd.Flags().Lookup(flags.FlagSignMode)
+	if signModeFlag == nil || signModeFlag.Value.String() != eip712.SignMode {
+		return clientCtx, nil
+	}
+
+	if err := signModeFlag.Value.Set(flags.SignModeLegacyAminoJSON); err != nil {
+		return clientCtx, err
+	}
+
+	// the client config may have replaced the keyring chosen by the flags
+	clientCtx, err := client.ReadPersistentCommandFlags(clientCtx, cmd.Flags())
+	if err != nil {
+		return clientCtx, err
+	}
+
+	if clientCtx.Keyring == nil {
+		return clientCtx, errors.New("EIP-712 signing requires a keyring")
+	}
+
+	// tx commands re-read the persistent flags and rebuild the keyring when the
+	// backend flag was set; mark it as consumed so the wrapped keyring is kept.
+	if backendFlag := cmd.Flags().Lookup(flags.FlagKeyringBackend); backendFlag != nil {
+		backendFlag.Changed = false
+	}
+
+	return clientCtx.
+		WithSignModeStr(flags.SignModeLegacyAminoJSON).
+		WithKeyring(eip712.NewKeyring(clientCtx.Keyring)), nil
+}
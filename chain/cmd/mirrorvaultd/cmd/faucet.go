@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"mirrorvault/app"
+	"mirrorvault/faucet"
+)
+
+const (
+	flagListen       = "listen"
+	flagAmount       = "amount"
+	flagAddressLimit = "address-limit"
+	flagIPLimit      = "ip-limit"
+	flagLimitWindow  = "limit-window"
+	flagDailyCap     = "daily-cap"
+)
+
+// faucetCommand returns the faucet commands.
+func faucetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "faucet",
+		Short: "Development faucet subcommands",
+	}
+
+	cmd.AddCommand(faucetServeCmd())
+
+	return cmd
+}
+
+// faucetServeCmd returns the command serving a faucet funding accounts from
+// a keyring account.
+func faucetServeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve an HTTP faucet sending coins from a keyring account",
+		Long: fmt.Sprintf(`Serve an HTTP faucet sending coins from the --from keyring account.
+
+POST / with {"address": "0x…"} or {"address": "%[1]s1…"} signs and broadcasts a
+bank send of --amount to the account, waits for it to be included and
+returns the tx hash with the bank and EVM balances of the account. The same
+POST also takes a JSON-RPC call:
+
+  {"jsonrpc": "2.0", "id": 1, "method": "%[2]s", "params": ["0x…"]}
+
+so that Hardhat scripts can use it as a provider. GET / returns the faucet
+account and limits.
+
+Each address and each client IP may request --address-limit and --ip-limit
+times per --limit-window, and the faucet sends at most --daily-cap per UTC
+day. Requests over a limit get a 429, or a -32005 JSON-RPC error.`, app.AccountAddressPrefix, faucet.RPCMethod),
+		Example: fmt.Sprintf(`%[1]s faucet serve --from bob --keyring-backend test
+curl -X POST localhost:4500 -d '{"address": "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"}'`, app.Name+"d"),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			txf, err := tx.NewFactoryCLI(clientCtx, cmd.Flags())
+			if err != nil {
+				return err
+			}
+			sender, err := faucet.NewTxSender(clientCtx, txf)
+			if err != nil {
+				return err
+			}
+
+			amount, limits, err := faucetFlags(cmd)
+			if err != nil {
+				return err
+			}
+			f, err := faucet.New(sender, amount, limits)
+			if err != nil {
+				return err
+			}
+
+			listen, _ := cmd.Flags().GetString(flagListen)
+			srv := &http.Server{
+				Addr:              listen,
+				Handler:           f.Handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			info := f.Info()
+			cmd.Printf("faucet %s (%s) sending %s listening on %s\n", info.EthAddress, info.Address, info.Amount, listen)
+			if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	_ = cmd.MarkFlagRequired(flags.FlagFrom)
+	cmd.Flags().String(flagListen, ":4500", "Address the faucet listens on")
+	cmd.Flags().String(flagAmount, "10000000"+app.BaseDenom, "Coins sent per request")
+	cmd.Flags().Int(flagAddressLimit, 1, "Requests allowed per address per window, 0 for no limit")
+	cmd.Flags().Int(flagIPLimit, 10, "Requests allowed per client IP per window, 0 for no limit")
+	cmd.Flags().Duration(flagLimitWindow, time.Hour, "Window of the address and IP limits")
+	cmd.Flags().String(flagDailyCap, "1000000000"+app.BaseDenom, "Coins sent per UTC day at most, empty for no cap")
+
+	return cmd
+}
+
+func faucetFlags(cmd *cobra.Command) (sdk.Coins, faucet.Limits, error) {
+	amountStr, _ := cmd.Flags().GetString(flagAmount)
+	amount, err := sdk.ParseCoinsNormalized(amountStr)
+	if err != nil {
+		return nil, faucet.Limits{}, fmt.Errorf("invalid --%s: %w", flagAmount, err)
+	}
+
+	capStr, _ := cmd.Flags().GetString(flagDailyCap)
+	dailyCap, err := sdk.ParseCoinsNormalized(capStr)
+	if err != nil {
+		return nil, faucet.Limits{}, fmt.Errorf("invalid --%s: %w", flagDailyCap, err)
+	}
+
+	limits := faucet.Limits{DailyCap: dailyCap}
+	limits.PerAddress, _ = cmd.Flags().GetInt(flagAddressLimit)
+	limits.PerIP, _ = cmd.Flags().GetInt(flagIPLimit)
+	limits.Window, _ = cmd.Flags().GetDuration(flagLimitWindow)
+
+	return amount, limits, nil
+}
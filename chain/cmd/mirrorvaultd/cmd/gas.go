@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+
+	"mirrorvault/app"
+	"mirrorvault/gascost"
+)
+
+const flagSizes = "sizes"
+
+// gasCommand returns the gas commands.
+func gasCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "gas",
+		Short: "Gas cost subcommands",
+	}
+
+	cmd.AddCommand(gasCompareCmd())
+
+	return cmd
+}
+
+// gasCompareCmd returns the command comparing the cost of storing payloads
+// through the EVM and through a Cosmos KVStore.
+func gasCompareCmd() *cobra.Command {
+	defaultSizes := make([]string, len(gascost.DefaultSizes))
+	for i, size := range gascost.DefaultSizes {
+		defaultSizes[i] = fmt.Sprint(size)
+	}
+
+	cmd := &cobra.Command{
+		Use:   "compare",
+		Short: "Compare the cost of storing payloads with EVM SSTORE and with a Cosmos KVStore write",
+		Long: `Compare the cost of storing payloads of each --sizes bytes through both VMs.
+
+The command starts an in-memory app with the default genesis. On the EVM
+side it deploys a contract storing its calldata one word per slot and calls
+it with the payload. On the Cosmos side it writes the payload to a
+gas-metered KVStore, keyed by owner as a vault MsgStoreSecret does, and
+charges the tx size cost of carrying it. x/vault is not part of the app yet,
+so the message itself can't be executed.
+
+Gas is reported over the cost of an empty tx of each VM, with the fee it
+costs at the fee market base fee and at the min gas price, the higher of the
+fee market minimum and --minimum-gas-prices. The API server serves the same
+report, against the live state, at ` + gascost.Route + `.`,
+		Example: fmt.Sprintf("%s gas compare --sizes 32,1024,4096", app.Name+"d"),
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			sizesStr, _ := cmd.Flags().GetString(flagSizes)
+			sizes, err := gascost.ParseSizes(sizesStr)
+			if err != nil {
+				return err
+			}
+
+			minGasPrices, _ := cmd.Flags().GetString(server.FlagMinGasPrices)
+			a, err := app.NewInMemory(log.NewNopLogger(), simtestutil.AppOptionsMap{
+				server.FlagMinGasPrices: minGasPrices,
+			})
+			if err != nil {
+				return err
+			}
+
+			engine, err := a.GasCostEngine()
+			if err != nil {
+				return err
+			}
+			ctx, err := a.CreateQueryContext(0, false)
+			if err != nil {
+				return err
+			}
+			report, err := engine.Estimate(ctx, sizes)
+			if err != nil {
+				return err
+			}
+
+			return printGasReport(cmd, clientCtx, report)
+		},
+	}
+
+	cmd.Flags().String(flagSizes, strings.Join(defaultSizes, ","), "Comma separated payload sizes in bytes")
+	cmd.Flags().String(server.FlagMinGasPrices, "", "Node minimum gas prices the fees are computed at, if above the fee market minimum")
+	cmd.Flags().StringP(flags.FlagOutput, "o", flags.OutputFormatText, "Output format (text|json)")
+
+	return cmd
+}
+
+func printGasReport(cmd *cobra.Command, clientCtx client.Context, report gascost.Report) error {
+	if clientCtx.OutputFormat == flags.OutputFormatJSON {
+		bz, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		return clientCtx.PrintRaw(bz)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "base fee: %s/gas, min gas price: %s/gas\n\n", report.BaseFee, report.MinGasPrice)
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SIZE\tPATH\tGAS\tFEE AT BASE FEE\tFEE AT MIN GAS PRICE\tBYTES WRITTEN")
+	for _, e := range report.Estimates {
+		for _, row := range []struct {
+			path string
+			cost gascost.Cost
+		}{{"evm sstore", e.EVM}, {"cosmos kvstore", e.Cosmos}} {
+			fmt.Fprintf(w, "%d\t%s\t%d\t%s\t%s\t%d\n", e.Size, row.path, row.cost.GasUsed, row.cost.FeeAtBaseFee, row.cost.FeeAtMinGasPrice, row.cost.BytesWritten)
+		}
+	}
+
+	return w.Flush()
+}
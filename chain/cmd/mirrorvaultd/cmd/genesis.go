@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	genutilcli "github.com/cosmos/cosmos-sdk/x/genutil/client/cli"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"mirrorvault/app"
+)
+
+// initCmd returns the SDK init command, whose chain id defaults to the one
+// of the network profile.
+func initCmd(basicManager module.BasicManager) *cobra.Command {
+	cmd := genutilcli.InitCmd(basicManager, app.DefaultNodeHome)
+	cmd.PreRunE = func(cmd *cobra.Command, _ []string) error {
+		if cmd.Flags().Changed(flags.FlagChainID) || client.GetClientContextFromCmd(cmd).ChainID != "" {
+			return nil
+		}
+
+		profile, err := networkProfile(cmd)
+		if err != nil {
+			return err
+		}
+		return cmd.Flags().Set(flags.FlagChainID, profile.ChainID)
+	}
+
+	return cmd
+}
+
+// genesisCommand returns the SDK genesis commands, whose validate command
+// also checks the modules agree on the native coin denoms and the chain id
+// is the one of the network profile, add-contract and import-evm-state.
+func genesisCommand(txConfig client.TxConfig, basicManager module.BasicManager) *cobra.Command {
+	cmd := genutilcli.Commands(txConfig, basicManager, app.DefaultNodeHome)
+	cmd.AddCommand(addContractCmd(), importEVMStateCmd())
+
+	for _, sub := range cmd.Commands() {
+		if sub.Name() != "validate" {
+			continue
+		}
+		validate := sub.RunE
+		sub.RunE = func(cmd *cobra.Command, args []string) error {
+			if err := validateGenesisDenoms(cmd, args); err != nil {
+				return err
+			}
+			return validate(cmd, args)
+		}
+	}
+
+	return cmd
+}
+
+// validateGenesisDenoms runs app.ValidateGenesisDenoms and checks the chain
+// id of the genesis file of the validate command.
+func validateGenesisDenoms(cmd *cobra.Command, args []string) error {
+	genesisFile := server.GetServerContextFromCmd(cmd).Config.GenesisFile()
+	if len(args) > 0 {
+		genesisFile = args[0]
+	}
+
+	appGenesis, err := genutiltypes.AppGenesisFromFile(genesisFile)
+	if err != nil {
+		return fmt.Errorf("error validating genesis file %s: %w", genesisFile, err)
+	}
+	var genState map[string]json.RawMessage
+	if err := json.Unmarshal(appGenesis.AppState, &genState); err != nil {
+		return fmt.Errorf("error validating genesis file %s: failed to unmarshal app state: %w", genesisFile, err)
+	}
+
+	if err := app.ValidateGenesisDenoms(client.GetClientContextFromCmd(cmd).Codec, genState); err != nil {
+		return fmt.Errorf("error validating genesis file %s: %w", genesisFile, err)
+	}
+
+	profile, err := networkProfile(cmd)
+	if err != nil {
+		return err
+	}
+	if err := profile.CheckChainID(appGenesis.ChainID); err != nil {
+		return fmt.Errorf("error validating genesis file %s: %w", genesisFile, err)
+	}
+
+	return nil
+}
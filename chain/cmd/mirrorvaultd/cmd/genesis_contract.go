@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"mirrorvault/app"
+	"mirrorvault/faucet"
+	"mirrorvault/predeploy"
+)
+
+const (
+	// flagSlot is not "storage", which app.toml has a section of.
+	flagSlot    = "slot"
+	flagBalance = "balance"
+)
+
+// addContractCmd returns the command placing a contract in the EVM genesis.
+func addContractCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-contract [address] [bytecode]",
+		Short: "Add a contract with its code, storage and balance to genesis.json",
+		Long: fmt.Sprintf(`Add a contract to the EVM genesis at a fixed address, in 0x hex or bech32 form.
+
+The bytecode is the runtime (deployed) bytecode: 0x hex, or a file holding it
+or a Hardhat or Foundry artifact. Storage slots are set with --%[1]s
+slot=value, both 32 bytes words in hex, and the balance with --%[2]s, in %[3]s
+or in the 18 decimals %[4]s of the EVM.
+
+The address must be free: not a genesis account, a module account, a static
+precompile or a predeploy. New genesis already has the predeploys:
+%[5]s.`, flagSlot, flagBalance, app.BaseDenom, app.ExtendedDenom, predeployList()),
+		Example: fmt.Sprintf(`%[1]s genesis add-contract 0x1111111111111111111111111111111111111111 artifacts/contracts/Counter.sol/Counter.json --slot 0x0=0x2a --balance 1000000%[2]s`, app.Name+"d", app.BaseDenom),
+		Args:    cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			genFile := server.GetServerContextFromCmd(cmd).Config.GenesisFile()
+
+			addr, err := faucet.ParseAddress(args[0])
+			if err != nil {
+				return err
+			}
+			contract := app.GenesisContract{Address: common.BytesToAddress(addr)}
+
+			if contract.Code, err = readBytecode(args[1]); err != nil {
+				return err
+			}
+
+			slots, _ := cmd.Flags().GetStringSlice(flagSlot)
+			contract.Storage = make(map[common.Hash]common.Hash, len(slots))
+			for _, slot := range slots {
+				key, value, ok := strings.Cut(slot, "=")
+				if !ok {
+					return fmt.Errorf("storage %q is not slot=value", slot)
+				}
+				k, err := parseWord(key)
+				if err != nil {
+					return err
+				}
+				if contract.Storage[k], err = parseWord(value); err != nil {
+					return err
+				}
+			}
+
+			if balance, _ := cmd.Flags().GetString(flagBalance); balance != "" {
+				if contract.Balance, err = parseExtendedAmount(balance); err != nil {
+					return err
+				}
+			}
+
+			appState, appGenesis, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to read genesis: %w", err)
+			}
+			if err := app.AddGenesisContract(clientCtx.Codec, appState, contract); err != nil {
+				return err
+			}
+
+			if appGenesis.AppState, err = json.MarshalIndent(appState, "", "  "); err != nil {
+				return err
+			}
+			return genutil.ExportGenesisFile(appGenesis, genFile)
+		},
+	}
+
+	cmd.Flags().StringSlice(flagSlot, nil, "Storage slots of the contract, as slot=value")
+	cmd.Flags().String(flagBalance, "", fmt.Sprintf("Balance of the contract in %s or %s", app.BaseDenom, app.ExtendedDenom))
+
+	return cmd
+}
+
+// predeployList returns the names and addresses of the default predeploys.
+func predeployList() string {
+	var list []string
+	for _, contract := range predeploy.Defaults() {
+		list = append(list, fmt.Sprintf("%s at %s", contract.Name, contract.Address))
+	}
+	return strings.Join(list, ", ")
+}
+
+// readBytecode returns the runtime bytecode of arg: 0x hex, or a file of hex
+// or a contract artifact.
+func readBytecode(arg string) ([]byte, error) {
+	data := arg
+	if !strings.HasPrefix(arg, "0x") {
+		bz, err := os.ReadFile(arg)
+		if err != nil {
+			return nil, err
+		}
+		if json.Valid(bz) {
+			artifact, err := predeploy.ParseArtifact(bz)
+			if err != nil {
+				return nil, err
+			}
+			return artifact.DeployedBytecode, nil
+		}
+		data = strings.TrimSpace(string(bz))
+	}
+
+	code, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bytecode: %w", err)
+	}
+	if len(code) == 0 {
+		return nil, fmt.Errorf("empty bytecode")
+	}
+
+	return code, nil
+}
+
+// parseWord parses a 32 bytes word in hex, left padded with zeros.
+func parseWord(s string) (common.Hash, error) {
+	digits := strings.TrimPrefix(s, "0x")
+	if len(digits)%2 == 1 {
+		digits = "0" + digits
+	}
+	bz, err := hex.DecodeString(digits)
+	if err != nil || len(bz) > common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid 32 bytes word %q", s)
+	}
+	return common.BytesToHash(bz), nil
+}
+
+// parseExtendedAmount parses a coin of BaseDenom or ExtendedDenom into an
+// amount of ExtendedDenom.
+func parseExtendedAmount(s string) (math.Int, error) {
+	coin, err := sdk.ParseCoinNormalized(s)
+	if err != nil {
+		return math.Int{}, err
+	}
+
+	switch coin.Denom {
+	case app.ExtendedDenom:
+		return coin.Amount, nil
+	case app.BaseDenom:
+		return coin.Amount.Mul(math.NewIntWithDecimal(1, app.ExtendedDenomDecimals-app.BaseDenomDecimals)), nil
+	default:
+		return math.Int{}, fmt.Errorf("balance must be in %s or %s, got %s", app.BaseDenom, app.ExtendedDenom, coin.Denom)
+	}
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"mirrorvault/app"
+)
+
+// importEVMStateCmd returns the command adding the accounts of an EVM state
+// dump to genesis.
+func importEVMStateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-evm-state [dump.json]",
+		Short: "Add the accounts of an anvil or geth state dump to genesis.json",
+		Long: fmt.Sprintf(`Add the accounts of an EVM state dump to genesis.json: the state of
+anvil --dump-state or anvil_dumpState, in JSON or as the gzipped hex the RPC
+returns, or a geth genesis or its alloc, as Hardhat configures it.
+
+Each account gets a base account with its nonce as sequence. Its balance, in
+wei, is %[1]s: the whole %[2]s go to bank and the rest to precisebank.
+Code and storage go to the EVM genesis.
+
+No account is added if one takes a module account, a static precompile or a
+contract of genesis.json; all of them are reported. Accounts without code that
+are in genesis.json already get the balance, as do predeploys of the dump
+with the same code.`, app.ExtendedDenom, app.BaseDenom),
+		Example: fmt.Sprintf(`anvil --dump-state state.json
+%s genesis import-evm-state state.json`, app.Name+"d"),
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := client.GetClientContextFromCmd(cmd)
+			genFile := server.GetServerContextFromCmd(cmd).Config.GenesisFile()
+
+			bz, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			accounts, err := parseEVMState(bz)
+			if err != nil {
+				return fmt.Errorf("failed to parse %s: %w", args[0], err)
+			}
+
+			appState, appGenesis, err := genutiltypes.GenesisStateFromGenFile(genFile)
+			if err != nil {
+				return fmt.Errorf("failed to read genesis: %w", err)
+			}
+			if err := app.ImportEVMState(clientCtx.Codec, appState, accounts); err != nil {
+				return err
+			}
+
+			if appGenesis.AppState, err = json.MarshalIndent(appState, "", "  "); err != nil {
+				return err
+			}
+			if err := genutil.ExportGenesisFile(appGenesis, genFile); err != nil {
+				return err
+			}
+
+			contracts := 0
+			for _, account := range accounts {
+				if len(account.Code) > 0 {
+					contracts++
+				}
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "imported %d accounts, %d of them contracts\n", len(accounts), contracts)
+
+			return nil
+		},
+	}
+}
+
+// dumpAccount is an account of an anvil state dump or a geth alloc.
+type dumpAccount struct {
+	Balance *gethmath.HexOrDecimal256 `json:"balance"`
+	Nonce   gethmath.HexOrDecimal64   `json:"nonce"`
+	Code    hexutil.Bytes             `json:"code"`
+	// Storage is by slot, both quantities in anvil and words in geth.
+	Storage map[string]string `json:"storage"`
+}
+
+// parseEVMState parses the non-empty accounts of an EVM state dump, sorted
+// by address.
+func parseEVMState(bz []byte) ([]app.GenesisContract, error) {
+	bz, err := gunzipDump(bz)
+	if err != nil {
+		return nil, err
+	}
+
+	// anvil nests the accounts in its state, geth in its genesis
+	var dump struct {
+		Accounts map[string]dumpAccount `json:"accounts"`
+		Alloc    map[string]dumpAccount `json:"alloc"`
+	}
+	if err := json.Unmarshal(bz, &dump); err != nil {
+		return nil, err
+	}
+	alloc := dump.Accounts
+	if alloc == nil {
+		alloc = dump.Alloc
+	}
+	if alloc == nil {
+		if err := json.Unmarshal(bz, &alloc); err != nil {
+			return nil, err
+		}
+	}
+
+	accounts := make([]app.GenesisContract, 0, len(alloc))
+	for key, acc := range alloc {
+		if !common.IsHexAddress(key) {
+			return nil, fmt.Errorf("invalid address %q", key)
+		}
+		account := app.GenesisContract{
+			Address: common.HexToAddress(key),
+			Code:    acc.Code,
+			Storage: make(map[common.Hash]common.Hash, len(acc.Storage)),
+			Nonce:   uint64(acc.Nonce),
+		}
+		if acc.Balance != nil {
+			account.Balance = math.NewIntFromBigInt((*hexutil.Big)(acc.Balance).ToInt())
+		}
+		for slot, value := range acc.Storage {
+			k, err := parseWord(slot)
+			if err != nil {
+				return nil, err
+			}
+			v, err := parseWord(value)
+			if err != nil {
+				return nil, err
+			}
+			// the EVM deletes zero slots
+			if v != (common.Hash{}) {
+				account.Storage[k] = v
+			}
+		}
+
+		empty := len(account.Code) == 0 && len(account.Storage) == 0 && account.Nonce == 0
+		if empty && (account.Balance.IsNil() || account.Balance.IsZero()) {
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+	sort.Slice(accounts, func(i, j int) bool {
+		return bytes.Compare(accounts[i].Address.Bytes(), accounts[j].Address.Bytes()) < 0
+	})
+
+	return accounts, nil
+}
+
+// gunzipDump returns the JSON of the gzipped hex string anvil_dumpState
+// returns, and other dumps as they are.
+func gunzipDump(bz []byte) ([]byte, error) {
+	data := strings.Trim(strings.TrimSpace(string(bz)), `"`)
+	if !strings.HasPrefix(data, "0x1f8b") {
+		return bz, nil
+	}
+
+	gz, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil {
+		return nil, err
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
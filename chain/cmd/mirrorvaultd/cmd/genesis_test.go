@@ -0,0 +1,119 @@
+package cmd_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// runCLI runs the CLI in a child process and returns its output.
+func runCLI(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	c := exec.Command(os.Args[0], args...)
+	c.Env = append(os.Environ(), envRunCLI+"=1")
+	out, err := c.CombinedOutput()
+	return string(out), err
+}
+
+func TestImportEVMState(t *testing.T) {
+	home := t.TempDir()
+	out, err := runCLI(t, "init", "node", "--home", home)
+	require.NoError(t, err, out)
+
+	var create2 string
+	for _, preinstall := range evmtypes.DefaultPreinstalls {
+		if preinstall.Name == "Create2" {
+			create2 = preinstall.Code
+		}
+	}
+	contract := "0x1111111111111111111111111111111111111111"
+	// anvil --dump-state, whose dev node holds the CREATE2 factory
+	dump := `{
+  "block": {"number": "0x5"},
+  "accounts": {
+    "0x4e59b44847b379578588920ca78fbf26c0b4956c": {"nonce": 0, "balance": "0x0", "code": "` + create2 + `", "storage": {}},
+    "` + contract + `": {"nonce": 1, "balance": "0x0", "code": "0x602a60005260206000f3", "storage": {"0x0": "0x2a", "0x1": "0x0"}},
+    "0x2222222222222222222222222222222222222222": {"nonce": 3, "balance": "0x3635c9adc5dea00001", "code": "0x", "storage": {}},
+    "0x3333333333333333333333333333333333333333": {"nonce": 0, "balance": "0x0", "code": "0x", "storage": {}}
+  },
+  "best_block_number": "0x5"
+}`
+
+	// anvil_dumpState returns it gzipped, in hex
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, err = w.Write([]byte(dump))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	dumpFile := filepath.Join(home, "state.json")
+	require.NoError(t, os.WriteFile(dumpFile, []byte(`"0x`+hex.EncodeToString(gz.Bytes())+`"`), 0o600))
+
+	out, err = runCLI(t, "genesis", "import-evm-state", dumpFile, "--home", home)
+	require.NoError(t, err, out)
+	require.Contains(t, out, "imported 3 accounts, 2 of them contracts")
+	out, err = runCLI(t, "genesis", "validate", "--home", home)
+	require.NoError(t, err, out)
+
+	genFile := filepath.Join(home, "config", "genesis.json")
+	appState, _, err := genutiltypes.GenesisStateFromGenFile(genFile)
+	require.NoError(t, err)
+	var evmGenState struct {
+		Accounts []struct {
+			Address string
+			Code    string
+			Storage []struct{ Key, Value string }
+		}
+	}
+	require.NoError(t, json.Unmarshal(appState[evmtypes.ModuleName], &evmGenState))
+	require.Len(t, evmGenState.Accounts, 1)
+	require.Equal(t, common.HexToAddress(contract).Hex(), evmGenState.Accounts[0].Address)
+	require.Equal(t, "602a60005260206000f3", evmGenState.Accounts[0].Code)
+	// the zero slot is left out
+	require.Len(t, evmGenState.Accounts[0].Storage, 1)
+	require.Equal(t, common.Hash{}.Hex(), evmGenState.Accounts[0].Storage[0].Key)
+	require.Equal(t, common.HexToHash("0x2a").Hex(), evmGenState.Accounts[0].Storage[0].Value)
+
+	// 1000 ETH and 1 wei
+	bank := string(appState["bank"])
+	require.Contains(t, bank, `"amount": "1000000000"`)
+	precisebank := string(appState["precisebank"])
+	require.Contains(t, precisebank, `"remainder": "999999999999"`)
+
+	// a geth alloc taking a module account fails, and adds nothing
+	feeCollector := common.BytesToAddress(authtypes.NewModuleAddress(authtypes.FeeCollectorName)).Hex()
+	alloc := `{"alloc": {"` + feeCollector + `": {"balance": "1"}, "0x4444444444444444444444444444444444444444": {"balance": "1"}}}`
+	require.NoError(t, os.WriteFile(dumpFile, []byte(alloc), 0o600))
+	before, err := os.ReadFile(genFile)
+	require.NoError(t, err)
+	out, err = runCLI(t, "genesis", "import-evm-state", dumpFile, "--home", home)
+	require.Error(t, err)
+	require.Contains(t, out, feeCollector+" is the "+authtypes.FeeCollectorName+" module account")
+	after, err := os.ReadFile(genFile)
+	require.NoError(t, err)
+	require.Equal(t, before, after)
+}
+
+func TestValidateGenesisUnparsable(t *testing.T) {
+	home := t.TempDir()
+	out, err := runCLI(t, "init", "node", "--home", home)
+	require.NoError(t, err, out)
+
+	genFile := filepath.Join(home, "config", "genesis.json")
+	require.NoError(t, os.WriteFile(genFile, []byte(`{"app_state": "not an object"}`), 0o600))
+	out, err = runCLI(t, "genesis", "validate", "--home", home)
+	require.Error(t, err)
+	require.Contains(t, out, "error validating genesis file "+genFile)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"mirrorvault/identity"
+)
+
+// identityCommand returns the identity attestation subcommands.
+func identityCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "identity",
+		Short: "Cross-wallet identity attestation subcommands",
+	}
+
+	cmd.AddCommand(identityVerifyCmd())
+
+	return cmd
+}
+
+func identityVerifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify [attestation-file]",
+		Short: "Verify that an EIP-191 and an ADR-036 signature over the same payload come from the same account",
+		Long: `Verify reads an attestation JSON document holding a MetaMask personal_sign
+signature and a Keplr signArbitrary signature over the same payload, recovers
+both signer addresses and confirms they are the same 20 bytes.
+
+Pass "-" to read the attestation from stdin.
+
+Example attestation:
+{
+  "payload": "mirror vault identity",
+  "eth_signature": "0x...",
+  "cosmos_signature": {
+    "signer": "mirror1...",
+    "pub_key": {"type": "ethermint/PubKeyEthSecp256k1", "value": "..."},
+    "signature": "..."
+  }
+}`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var (
+				bz  []byte
+				err error
+			)
+			if args[0] == "-" {
+				bz, err = io.ReadAll(cmd.InOrStdin())
+			} else {
+				bz, err = os.ReadFile(args[0])
+			}
+			if err != nil {
+				return err
+			}
+
+			var att identity.Attestation
+			if err := json.Unmarshal(bz, &att); err != nil {
+				return fmt.Errorf("failed to parse attestation: %w", err)
+			}
+
+			res, err := identity.Verify(att)
+			if err != nil {
+				return err
+			}
+
+			out, err := json.MarshalIndent(res, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+			if !res.Match {
+				return fmt.Errorf("signatures belong to different accounts: %s != %s", res.EthAddress, res.CosmosAddress)
+			}
+
+			return nil
+		},
+	}
+}
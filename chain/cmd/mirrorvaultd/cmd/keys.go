@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/input"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	evmclient "github.com/cosmos/evm/client"
+	evmhd "github.com/cosmos/evm/crypto/hd"
+
+	"mirrorvault/app"
+	"mirrorvault/ethkeystore"
+	"mirrorvault/pairs"
+)
+
+const (
+	flagCount      = "count"
+	flagStartIndex = "start-index"
+	flagNamePrefix = "name-prefix"
+	flagRecover    = "recover"
+	flagExport     = "export"
+	flagFund       = "fund"
+	flagGenesis    = "genesis"
+	flagKDF        = "kdf"
+	flagLight      = "light"
+	flagOutputFile = "output-file"
+)
+
+// keysCommand returns the keyring subcommands, defaulting to eth_secp256k1
+// keys, with the mirror pair provisioning commands added.
+func keysCommand() *cobra.Command {
+	cmd := evmclient.KeyCommands(app.DefaultNodeHome, true)
+	cmd.AddCommand(
+		derivePairsCmd(),
+		importEthKeystoreCmd(),
+		exportEthKeystoreCmd(),
+	)
+
+	return cmd
+}
+
+func derivePairsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "derive-pairs",
+		Short: "Derive mirror pairs from one mnemonic, store them in the keyring and optionally fund them",
+		Long: `Derive --count eth_secp256k1 accounts along m/44'/60'/0'/0/i from one mnemonic
+and store them in the keyring as <name-prefix><i>. Each account is a mirror
+pair: the same key imported into MetaMask (0x address) and Keplr (bech32
+address).
+
+A new 24 word mnemonic is generated unless --recover is given, in which case
+it is read from stdin.
+
+Funding, with --fund <coins> per pair:
+  --genesis      add the pairs as genesis accounts to <home>/config/genesis.json
+  --from <key>   send the coins from a funded (e.g. faucet) account
+
+--export writes a bundle with the mnemonic (Keplr) and the private keys
+(MetaMask) of the pairs. It holds secrets, keep it safe.`,
+		Example: fmt.Sprintf(`%[1]s keys derive-pairs --count 3 --keyring-backend test --fund 1000000000%[2]s --genesis
+%[1]s keys derive-pairs --count 3 --recover --fund 10000000%[2]s --from bob --export pairs.json`, app.Name+"d", app.BaseDenom),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			count, _ := cmd.Flags().GetUint32(flagCount)
+			start, _ := cmd.Flags().GetUint32(flagStartIndex)
+			prefix, _ := cmd.Flags().GetString(flagNamePrefix)
+			if count == 0 {
+				return errors.New("--count must be positive")
+			}
+
+			mnemonic, generated, err := readOrGenerateMnemonic(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+
+			derived, err := pairs.Derive(mnemonic, prefix, start, count)
+			if err != nil {
+				return err
+			}
+
+			// fail before storing anything if a name is taken
+			for _, pair := range derived {
+				if _, err := clientCtx.Keyring.Key(pair.Name); err == nil {
+					return fmt.Errorf("key %s already exists", pair.Name)
+				}
+			}
+			for _, pair := range derived {
+				if _, err := clientCtx.Keyring.NewAccount(pair.Name, mnemonic, "", pair.HDPath, evmhd.EthSecp256k1); err != nil {
+					return fmt.Errorf("failed to store %s: %w", pair.Name, err)
+				}
+			}
+
+			if path, _ := cmd.Flags().GetString(flagExport); path != "" {
+				bz, err := json.MarshalIndent(pairs.Bundle{Mnemonic: mnemonic, Pairs: derived}, "", "  ")
+				if err != nil {
+					return err
+				}
+				if err := os.WriteFile(path, bz, 0o600); err != nil {
+					return err
+				}
+			}
+
+			if err := printPairs(cmd, clientCtx, derived, mnemonic, generated); err != nil {
+				return err
+			}
+
+			return fundPairs(cmd, clientCtx, derived)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+	cmd.Flags().Uint32(flagCount, 3, "Number of pairs to derive")
+	cmd.Flags().Uint32(flagStartIndex, 0, "Address index of the first pair")
+	cmd.Flags().String(flagNamePrefix, "pair", "Keyring name prefix, the address index is appended")
+	cmd.Flags().Bool(flagRecover, false, "Read the mnemonic from stdin instead of generating one")
+	cmd.Flags().String(flagExport, "", "Write the MetaMask/Keplr import bundle to this file")
+	cmd.Flags().String(flagFund, "", "Coins to fund each pair with")
+	cmd.Flags().Bool(flagGenesis, false, "Fund the pairs as genesis accounts instead of with a bank send from --from")
+
+	return cmd
+}
+
+// readOrGenerateMnemonic returns the mnemonic read from stdin when --recover is
+// set, and a new one otherwise.
+func readOrGenerateMnemonic(cmd *cobra.Command, clientCtx client.Context) (string, bool, error) {
+	if recoverKey, _ := cmd.Flags().GetBool(flagRecover); recoverKey {
+		mnemonic, err := input.GetString("Enter your bip39 mnemonic", bufio.NewReader(clientCtx.Input))
+		if err != nil {
+			return "", false, err
+		}
+		if !bip39.IsMnemonicValid(mnemonic) {
+			return "", false, errors.New("invalid mnemonic")
+		}
+		return mnemonic, false, nil
+	}
+
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return "", false, err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return "", false, err
+	}
+
+	return mnemonic, true, nil
+}
+
+func printPairs(cmd *cobra.Command, clientCtx client.Context, derived []pairs.Pair, mnemonic string, generated bool) error {
+	public := make([]pairs.Pair, len(derived))
+	for i, pair := range derived {
+		pair.PrivateKey = ""
+		public[i] = pair
+	}
+
+	if clientCtx.OutputFormat == flags.OutputFormatJSON {
+		out := pairs.Bundle{Pairs: public}
+		if generated {
+			out.Mnemonic = mnemonic
+		}
+		bz, err := json.Marshal(out)
+		if err != nil {
+			return err
+		}
+		return clientCtx.PrintRaw(bz)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tHD PATH\tETH ADDRESS\tCOSMOS ADDRESS")
+	for _, pair := range public {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", pair.Name, pair.HDPath, pair.EthAddress, pair.CosmosAddress)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if generated {
+		fmt.Fprintf(cmd.OutOrStdout(), "\n**Important** write this mnemonic phrase in a safe place.\nIt is the only way to recover the pairs.\n\n%s\n", mnemonic)
+	}
+
+	return nil
+}
+
+// fundPairs funds the pairs with --fund, either in the genesis file or with a
+// bank send from --from.
+func fundPairs(cmd *cobra.Command, clientCtx client.Context, derived []pairs.Pair) error {
+	amount, _ := cmd.Flags().GetString(flagFund)
+	if amount == "" {
+		return nil
+	}
+	coins, err := sdk.ParseCoinsNormalized(amount)
+	if err != nil {
+		return fmt.Errorf("invalid --%s: %w", flagFund, err)
+	}
+
+	if genesis, _ := cmd.Flags().GetBool(flagGenesis); genesis {
+		accounts := make([]genutil.GenesisAccount, len(derived))
+		for i, pair := range derived {
+			accounts[i] = genutil.GenesisAccount{Address: pair.CosmosAddress, Coins: coins}
+		}
+
+		genFile := filepath.Join(clientCtx.HomeDir, "config", "genesis.json")
+		return genutil.AddGenesisAccounts(clientCtx.Codec, clientCtx.TxConfig.SigningContext().AddressCodec(), accounts, false, genFile)
+	}
+
+	clientCtx, err = client.GetClientTxContext(cmd)
+	if err != nil {
+		return err
+	}
+	if clientCtx.FromAddress.Empty() {
+		return fmt.Errorf("--%s requires --%s or --%s", flagFund, flags.FlagFrom, flagGenesis)
+	}
+
+	msgs := make([]sdk.Msg, len(derived))
+	for i, pair := range derived {
+		to, err := sdk.AccAddressFromBech32(pair.CosmosAddress)
+		if err != nil {
+			return err
+		}
+		msgs[i] = banktypes.NewMsgSend(clientCtx.FromAddress, to, coins)
+	}
+
+	return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+}
+
+func importEthKeystoreCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "import-eth-keystore <name> <keystore-file>",
+		Short: "Import an Ethereum V3 JSON keystore (geth, Foundry, MetaMask) as an eth_secp256k1 key",
+		Long: `Decrypt an Ethereum V3 JSON keystore, scrypt or pbkdf2, and store its key in
+the keyring under <name>. The key keeps its address: the 0x address of the
+keystore and the bech32 address of the keyring entry are the same 20 bytes.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			keyjson, err := os.ReadFile(args[1])
+			if err != nil {
+				return err
+			}
+
+			password, err := input.GetPassword("Enter keystore password:", bufio.NewReader(clientCtx.Input))
+			if err != nil {
+				return err
+			}
+
+			record, err := ethkeystore.Import(clientCtx.Keyring, args[0], keyjson, password)
+			if err != nil {
+				return err
+			}
+
+			return printKeyAddresses(cmd, record)
+		},
+	}
+}
+
+func exportEthKeystoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export-eth-keystore <name>",
+		Short: "Export an eth_secp256k1 key as an Ethereum V3 JSON keystore",
+		Long: `Encrypt the private key stored under <name> into an Ethereum V3 JSON keystore
+that geth, Foundry (cast wallet import) and MetaMask can import. Only
+eth_secp256k1 keys can be exported, other key types have a different address
+on the EVM.
+
+The keystore is written to --output-file, or to stdout.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			kdf, _ := cmd.Flags().GetString(flagKDF)
+			light, _ := cmd.Flags().GetBool(flagLight)
+
+			buf := bufio.NewReader(clientCtx.Input)
+			password, err := input.GetPassword("Enter passphrase to encrypt the keystore:", buf)
+			if err != nil {
+				return err
+			}
+			repeated, err := input.GetPassword("Repeat the passphrase:", buf)
+			if err != nil {
+				return err
+			}
+			if password != repeated {
+				return errors.New("passphrases don't match")
+			}
+
+			keyjson, err := ethkeystore.Export(clientCtx.Keyring, args[0], password, ethkeystore.Options{KDF: kdf, Light: light})
+			if err != nil {
+				return err
+			}
+
+			if path, _ := cmd.Flags().GetString(flagOutputFile); path != "" {
+				return os.WriteFile(path, keyjson, 0o600)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout(), string(keyjson))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagKDF, ethkeystore.KDFScrypt, "Key derivation function (scrypt|pbkdf2)")
+	cmd.Flags().Bool(flagLight, false, "Use the light scrypt parameters, faster to decrypt but weaker")
+	cmd.Flags().String(flagOutputFile, "", "Write the keystore to this file instead of stdout")
+
+	return cmd
+}
+
+// printKeyAddresses prints both address forms of a keyring record.
+func printKeyAddresses(cmd *cobra.Command, record *keyring.Record) error {
+	addr, err := record.GetAddress()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "name: %s\neth_address: %s\ncosmos_address: %s\n", record.Name, common.BytesToAddress(addr), addr)
+	return nil
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	evmhd "github.com/cosmos/evm/crypto/hd"
+	evmserver "github.com/cosmos/evm/server"
+	evmserverconfig "github.com/cosmos/evm/server/config"
+
+	"mirrorvault/app"
+	"mirrorvault/impersonate"
+	"mirrorvault/localnet"
+	"mirrorvault/network"
+)
+
+const (
+	flagReset      = "reset"
+	flagPortOffset = "port-offset"
+)
+
+// localnetCmd initializes a single node network from a config.yml spec and
+// starts it.
+func localnetCmd(basicManager module.BasicManager) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "localnet [spec-file]",
+		Short: "Initialize and start a single node local network described by a config.yml spec",
+		Long: fmt.Sprintf(`Initialize and start a single node local network, without Ignite.
+
+The spec (default %[1]s) uses the Ignite config.yml format: the accounts are
+created in the test keyring of the home and funded in genesis, and the
+validator account bonds its stake in a gentx. The bond denom, bank metadata,
+EVM and fee market genesis are set for the native denom. Keys are recovered
+from the account mnemonic when one is given.
+
+An existing home is reused, so the chain resumes where it stopped. --reset
+deletes the home first.
+
+The node serves CometBFT RPC, REST, gRPC and JSON-RPC. --port-offset shifts
+all their ports, so several localnets can run side by side.`, localnet.DefaultSpecFile),
+		Example: fmt.Sprintf(`%[1]s localnet
+%[1]s localnet config.yml --reset --home /tmp/localnet --port-offset 100`, app.Name+"d"),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+			clientCtx, err := client.GetClientQueryContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			specFile := localnet.DefaultSpecFile
+			if len(args) == 1 {
+				specFile = args[0]
+			}
+			spec, err := localnet.LoadSpec(specFile)
+			if err != nil {
+				return err
+			}
+
+			home := serverCtx.Config.RootDir
+			if reset, _ := cmd.Flags().GetBool(flagReset); reset {
+				if err := os.RemoveAll(home); err != nil {
+					return err
+				}
+			}
+
+			offset, _ := cmd.Flags().GetInt(flagPortOffset)
+			ports := localnet.DefaultPorts().Offset(offset)
+			if err := writeLocalnetConfig(serverCtx, ports); err != nil {
+				return err
+			}
+
+			genFile := serverCtx.Config.GenesisFile()
+			if _, err := os.Stat(genFile); err == nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Reusing %s, use --%s to start over\n", home, flagReset)
+			} else {
+				kr, err := keyring.New(sdk.KeyringServiceName(), keyring.BackendTest, home, cmd.InOrStdin(), clientCtx.Codec, evmhd.EthSecp256k1Option())
+				if err != nil {
+					return err
+				}
+
+				res, err := localnet.Init(cmd.Context(), localnet.Options{
+					Spec:         spec,
+					Config:       serverCtx.Config,
+					Keyring:      kr,
+					Codec:        clientCtx.Codec,
+					TxConfig:     clientCtx.TxConfig,
+					BasicManager: basicManager,
+				})
+				if err != nil {
+					return err
+				}
+
+				if err := printLocalnet(cmd, clientCtx, res, spec); err != nil {
+					return err
+				}
+			}
+
+			fmt.Fprintf(cmd.ErrOrStderr(), "CometBFT RPC: %s\nREST: http://localhost:%d\nJSON-RPC: http://localhost:%d\n", serverCtx.Config.RPC.ListenAddress, ports.API, ports.JSONRPC)
+
+			return startLocalnet(cmd, home)
+		},
+	}
+
+	cmd.Flags().Bool(flagReset, false, "Delete the home before initializing the network")
+	cmd.Flags().Int(flagPortOffset, 0, "Number added to every listening port")
+
+	return cmd
+}
+
+// writeLocalnetConfig writes the CometBFT and app configs of the node with
+// ports, and reloads them in the server context.
+func writeLocalnetConfig(serverCtx *server.Context, ports localnet.Ports) error {
+	configDir := filepath.Join(serverCtx.Config.RootDir, "config")
+	if err := os.MkdirAll(configDir, nodeDirPerm); err != nil {
+		return err
+	}
+
+	ports.ApplyComet(serverCtx.Config)
+	cmtConfigFile := filepath.Join(configDir, "config.toml")
+	cmtcfg.WriteConfigFile(cmtConfigFile, serverCtx.Config)
+
+	appConfig, err := evmserverconfig.GetConfig(serverCtx.Viper)
+	if err != nil {
+		return err
+	}
+	ports.ApplyApp(&appConfig)
+
+	appConfigFile := filepath.Join(configDir, "app.toml")
+	// impersonation is for localnets, keep it
+	writeAppConfig(appConfigFile, appConfig, network.Localnet, serverCtx.Viper.GetString(impersonate.FlagImpersonate))
+
+	for _, file := range []string{cmtConfigFile, appConfigFile} {
+		serverCtx.Viper.SetConfigFile(file)
+		if err := serverCtx.Viper.MergeInConfig(); err != nil {
+			return fmt.Errorf("failed to reload %s: %w", file, err)
+		}
+	}
+
+	return nil
+}
+
+// startLocalnet runs the start command on home.
+func startLocalnet(cmd *cobra.Command, home string) error {
+	startCmd := evmserver.StartCmd(newStartOptions())
+	addModuleInitFlags(startCmd)
+	startCmd.SetContext(cmd.Context())
+	startCmd.SetOut(cmd.OutOrStdout())
+	startCmd.SetErr(cmd.ErrOrStderr())
+
+	if err := startCmd.Flags().Set(flags.FlagHome, home); err != nil {
+		return err
+	}
+	if startCmd.PreRunE == nil || startCmd.RunE == nil {
+		return errors.New("unexpected start command")
+	}
+	if err := startCmd.PreRunE(startCmd, nil); err != nil {
+		return err
+	}
+
+	return startCmd.RunE(startCmd, nil)
+}
+
+func printLocalnet(cmd *cobra.Command, clientCtx client.Context, res *localnet.Result, spec localnet.Spec) error {
+	if clientCtx.OutputFormat == flags.OutputFormatJSON {
+		bz, err := json.Marshal(res)
+		if err != nil {
+			return err
+		}
+		return clientCtx.PrintRaw(bz)
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tETH ADDRESS\tCOSMOS ADDRESS")
+	for _, acc := range res.Accounts {
+		name := acc.Name
+		if acc.Name == spec.Faucet.Name {
+			name += " (faucet)"
+		}
+		if acc.Name == spec.Validators[0].Name {
+			name += " (validator)"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, acc.EthAddress, acc.CosmosAddress)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, acc := range res.Accounts {
+		if acc.Mnemonic != "" {
+			fmt.Fprintf(cmd.OutOrStdout(), "\n%s mnemonic: %s\n", acc.Name, acc.Mnemonic)
+		}
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "\nChain %s initialized, the keys are in the test keyring (--keyring-backend test)\n", res.ChainID)
+
+	return nil
+}
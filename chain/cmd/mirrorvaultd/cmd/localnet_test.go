@@ -0,0 +1,184 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	clienthelpers "cosmossdk.io/client/v2/helpers"
+	svrcmd "github.com/cosmos/cosmos-sdk/server/cmd"
+
+	"mirrorvault/app"
+	"mirrorvault/cmd/mirrorvaultd/cmd"
+	"mirrorvault/localnet"
+	"mirrorvault/network"
+)
+
+// envRunCLI makes the test binary run the CLI with its arguments, so tests
+// can boot a node in a child process. The EVM keeps global state, so a
+// process can only run one app.
+const envRunCLI = "MIRRORVAULTD_TEST_RUN_CLI"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(envRunCLI) != "" {
+		rootCmd := cmd.NewRootCmd()
+		if err := svrcmd.Execute(rootCmd, clienthelpers.EnvPrefix, app.DefaultNodeHome); err != nil {
+			fmt.Fprintln(rootCmd.OutOrStderr(), err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// alice uses the Hardhat development mnemonic, aliceAddress is its account #0
+const aliceAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+const spec = `version: 1
+default_denom: umvlt
+accounts:
+- name: alice
+  coins:
+  - 1000000000umvlt
+  mnemonic: test test test test test test test test test test test junk
+- name: bob
+  coins:
+  - 1000000000umvlt
+faucet:
+  name: bob
+  coins:
+  - 10000000umvlt
+validators:
+- name: alice
+  bonded: 200000000umvlt
+`
+
+func TestLocalnet(t *testing.T) {
+	if testing.Short() {
+		t.Skip("boots a node")
+	}
+
+	dir := t.TempDir()
+	specFile := filepath.Join(dir, "config.yml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), 0o600))
+
+	offset := freePortOffset(t)
+	ports := localnet.DefaultPorts().Offset(offset)
+
+	var out bytes.Buffer
+	node := exec.Command(os.Args[0], "localnet", specFile,
+		"--home", filepath.Join(dir, "home"),
+		"--reset",
+		"--port-offset", strconv.Itoa(offset),
+	)
+	node.Env = append(os.Environ(), envRunCLI+"=1")
+	node.Stdout = &out
+	node.Stderr = &out
+	require.NoError(t, node.Start())
+
+	done := make(chan error, 1)
+	go func() { done <- node.Wait() }()
+	t.Cleanup(func() {
+		_ = node.Process.Signal(syscall.SIGTERM)
+		select {
+		case <-done:
+		case <-time.After(30 * time.Second):
+			_ = node.Process.Kill()
+			<-done
+		}
+		if t.Failed() {
+			t.Log(out.String())
+		}
+	})
+
+	url := fmt.Sprintf("http://127.0.0.1:%d", ports.JSONRPC)
+
+	// wait for a few blocks
+	require.Eventually(t, func() bool {
+		var height hexutil.Uint64
+		return jsonRPC(url, "eth_blockNumber", &height) == nil && height >= 2
+	}, 2*time.Minute, 500*time.Millisecond)
+
+	var chainID hexutil.Uint64
+	require.NoError(t, jsonRPC(url, "eth_chainId", &chainID))
+	require.Equal(t, uint64(network.MustGet(network.Localnet).EVMChainID), uint64(chainID))
+
+	// alice's genesis balance minus her bonded stake, seen from the EVM
+	// with 18 decimals
+	var balance hexutil.Big
+	require.NoError(t, jsonRPC(url, "eth_getBalance", &balance, aliceAddress, "latest"))
+	want, _ := new(big.Int).SetString("800000000000000000000", 10)
+	require.Equal(t, want, balance.ToInt())
+}
+
+// freePortOffset returns an offset for which all the localnet ports are free.
+func freePortOffset(t *testing.T) int {
+	t.Helper()
+
+	for offset := 20000 + os.Getpid()%100*10; offset < 30000; offset += 1000 {
+		ports := localnet.DefaultPorts().Offset(offset)
+		if portsFree(ports.P2P, ports.RPC, ports.ABCI, ports.Pprof, ports.Prometheus, ports.GRPC, ports.API, ports.JSONRPC, ports.JSONRPCWS, ports.JSONRPCMetrics, ports.GethMetrics) {
+			return offset
+		}
+	}
+	t.Fatal("no free ports")
+
+	return 0
+}
+
+func portsFree(ports ...int) bool {
+	for _, port := range ports {
+		l, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+		if err != nil {
+			return false
+		}
+		l.Close()
+	}
+
+	return true
+}
+
+func jsonRPC(url, method string, result any, params ...any) error {
+	if params == nil {
+		params = []any{}
+	}
+	req, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		return err
+	}
+
+	resp, err := http.Post(url, "application/json", bytes.NewReader(req))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var res struct {
+		Result json.RawMessage `json:"result"`
+		Error  *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return err
+	}
+	if res.Error != nil {
+		return fmt.Errorf("%s: %s", method, res.Error.Message)
+	}
+
+	return json.Unmarshal(res.Result, result)
+}
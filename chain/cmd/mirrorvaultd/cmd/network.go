@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"mirrorvault/network"
+)
+
+// addNetworkFlag adds the persistent flag selecting the network profile.
+func addNetworkFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(network.FlagNetwork, "", fmt.Sprintf("Network profile, one of %s (default the network of app.toml, $%s or %s)", strings.Join(network.Names(), ", "), network.EnvNetwork, network.DefaultName))
+}
+
+// networkProfile returns the network profile selected by the command flags.
+// Once the server context is set up, the flag falls back to app.toml.
+func networkProfile(cmd *cobra.Command) (network.Profile, error) {
+	name, _ := cmd.Flags().GetString(network.FlagNetwork)
+	return network.Select(name)
+}
+
+// networkCmd returns the command printing the network profiles.
+func networkCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:       fmt.Sprintf("network [%s]", strings.Join(network.Names(), "|")),
+		Short:     "Print the chain constants of a network profile",
+		ValidArgs: network.Names(),
+		Long: fmt.Sprintf(`Print the chain id, EVM chain id, denoms, address prefix, default ports
+and precompile addresses of a network profile, by default the one selected
+by --%s, the network key of app.toml or $%s.
+
+The profile gives the defaults of init, app.toml and wallet-config, and a node
+refuses to start when its chain id, EVM chain id or precompiles differ from it.
+A localnet runs under any chain id and EVM chain id.`, network.FlagNetwork, network.EnvNetwork),
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			profile, err := networkProfile(cmd)
+			if err != nil {
+				return err
+			}
+			if len(args) == 1 {
+				if profile, err = network.Get(args[0]); err != nil {
+					return err
+				}
+			}
+
+			out, err := json.MarshalIndent(profile, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+			return nil
+		},
+	}
+}
@@ -18,6 +18,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth/types"
 	"github.com/spf13/cobra"
 
+	evmkeyring "github.com/cosmos/evm/crypto/keyring"
+	evmeip712 "github.com/cosmos/evm/ethereum/eip712"
+
 	"mirrorvault/app"
 )
 
@@ -27,6 +30,8 @@ func NewRootCmd() *cobra.Command {
 		autoCliOpts        autocli.AppOptions
 		moduleBasicManager module.BasicManager
 		clientCtx          client.Context
+		legacyAmino        *codec.LegacyAmino
+		interfaceRegistry  codectypes.InterfaceRegistry
 	)
 
 	if err := depinject.Inject(
@@ -42,10 +47,21 @@ func NewRootCmd() *cobra.Command {
 		&autoCliOpts,
 		&moduleBasicManager,
 		&clientCtx,
+		&legacyAmino,
+		&interfaceRegistry,
 	); err != nil {
 		panic(err)
 	}
 
+	// Since the EVM modules don't support dependency injection, we need to
+	// manually register the modules on the client side.
+	// This needs to be removed after EVM supports App Wiring.
+	evmModules := app.RegisterEVM(clientCtx.InterfaceRegistry)
+	for name, mod := range evmModules {
+		moduleBasicManager[name] = module.CoreAppModuleBasicAdaptor(name, mod)
+		autoCliOpts.Modules[name] = mod
+	}
+
 	rootCmd := &cobra.Command{
 		Use:           app.Name + "d",
 		Short:         "mirrorvault node",
@@ -66,17 +82,42 @@ func NewRootCmd() *cobra.Command {
 				return err
 			}
 
+			clientCtx, err = applyEIP712SignMode(cmd, clientCtx)
+			if err != nil {
+				return err
+			}
+
 			if err := client.SetCmdClientContextHandler(clientCtx, cmd); err != nil {
 				return err
 			}
 
-			customAppTemplate, customAppConfig := initAppConfig()
+			// the profile of the flag or environment gives the defaults of a
+			// new app.toml
+			profile, err := networkProfile(cmd)
+			if err != nil {
+				return err
+			}
+
+			customAppTemplate, customAppConfig := initAppConfig(profile)
 			customCMTConfig := initCometBFTConfig()
 
-			return server.InterceptConfigsPreRunHandler(cmd, customAppTemplate, customAppConfig, customCMTConfig)
+			if err := server.InterceptConfigsPreRunHandler(cmd, customAppTemplate, customAppConfig, customCMTConfig); err != nil {
+				return err
+			}
+
+			// the flag now falls back to the network of app.toml
+			if profile, err = networkProfile(cmd); err != nil {
+				return err
+			}
+
+			// EIP-712 signing decodes sign docs with the app codecs
+			evmeip712.SetEncodingConfig(legacyAmino, interfaceRegistry, profile.EVMChainID)
+
+			return nil
 		},
 	}
 
+	addNetworkFlag(rootCmd)
 	initRootCmd(rootCmd, clientCtx.TxConfig, moduleBasicManager)
 
 	if err := autoCliOpts.EnhanceRootCommand(rootCmd); err != nil {
@@ -101,6 +142,7 @@ func ProvideClientContext(
 		WithInput(os.Stdin).
 		WithAccountRetriever(types.AccountRetriever{}).
 		WithHomeDir(app.DefaultNodeHome).
+		WithKeyringOptions(evmkeyring.Option()).
 		WithViper(app.Name) // env variable prefix
 
 	// Read the config again to overwrite the default values with the values from the config file
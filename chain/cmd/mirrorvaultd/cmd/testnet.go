@@ -5,8 +5,11 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
+	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/log"
 	"cosmossdk.io/math"
 	storetypes "cosmossdk.io/store/types"
@@ -23,23 +26,36 @@ import (
 	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
 	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+	evmserverconfig "github.com/cosmos/evm/server/config"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"mirrorvault/app"
+	"mirrorvault/faucet"
+	"mirrorvault/impersonate"
+	"mirrorvault/network"
 )
 
 const valVotingPower int64 = 900000000000000
 
-var flagAccountsToFund = "accounts-to-fund"
+const (
+	flagAccountsToFund = "accounts-to-fund"
+	flagFundAmount     = "fund-amount"
+)
 
 type valArgs struct {
 	newValAddr         bytes.HexBytes
 	newOperatorAddress string
 	newValPubKey       crypto.PubKey
-	accountsToFund     []string
-	upgradeToTrigger   string
-	homeDir            string
+	accountsToFund     []sdk.AccAddress
+	// fundCoins are in ExtendedDenom, so they may fund a fractional balance.
+	fundCoins        sdk.Coins
+	impersonated     []common.Address
+	upgradeToTrigger string
+	homeDir          string
 }
 
 func NewInPlaceTestnetCmd() *cobra.Command {
@@ -48,17 +64,37 @@ func NewInPlaceTestnetCmd() *cobra.Command {
 	cmd.Long = `The test command modifies both application and consensus stores within a local mainnet node and starts the node,
 with the aim of facilitating testing procedures. This command replaces existing validator data with updated information,
 thereby removing the old validator set and introducing a new set suitable for local testing purposes. By altering the state extracted from the mainnet node,
-it enables developers to configure their local environments to reflect mainnet conditions more accurately.`
+it enables developers to configure their local environments to reflect mainnet conditions more accurately.
+
+The node then runs as a localnet, with its EVM chain id, so its Ethereum txs can't be replayed on the copied network, and
+without a fee market base fee. The funded accounts, 0x or bech32 addresses, get the fund amount in both the bank and the
+precisebank fractional balance. The Ethereum txs of impersonated addresses run without their keys: send them with
+impersonate_sendTransaction. app.toml keeps these settings, so the node restarts with "start", which serves JSON-RPC.`
 
-	cmd.Example = fmt.Sprintf(`%sd in-place-testnet testing-1 cosmosvaloper1w7f3xx7e75p4l7qdym5msqem9rd4dyc4mq79dm --home $HOME/.%sd/validator1 --validator-privkey=6dq+/KHNvyiw2TToCgOpUpQKIzrLs69Rb8Az39xvmxPHNoPxY1Cil8FY+4DhT9YwD6s0tFABMlLcpaylzKKBOg== --accounts-to-fund="cosmos1f7twgcq4ypzg7y24wuywy06xmdet8pc4473tnq,cosmos1qvuhm5m644660nd8377d6l7yz9e9hhm9evmx3x"`, "mirrorvault", "mirrorvault")
+	cmd.Example = fmt.Sprintf(`%sd in-place-testnet testing-1 cosmosvaloper1w7f3xx7e75p4l7qdym5msqem9rd4dyc4mq79dm --home $HOME/.%sd/validator1 --accounts-to-fund="cosmos1f7twgcq4ypzg7y24wuywy06xmdet8pc4473tnq,0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266" --impersonate=0x70997970C51812dc3A010C7d01b50e20d17dc79C`, "mirrorvault", "mirrorvault")
 
 	cmd.Flags().String(flagAccountsToFund, "", "Comma-separated list of account addresses that will be funded for testing purposes")
+	cmd.Flags().String(flagFundAmount, "1000000000"+app.BaseDenom, fmt.Sprintf("Amount each funded account gets, in %s or %s", app.BaseDenom, app.ExtendedDenom))
+	cmd.Flags().String(impersonate.FlagImpersonate, "", "Comma-separated list of 0x addresses whose Ethereum txs run without their keys")
 	return cmd
 }
 
 // newTestnetApp starts by running the normal newApp method. From there, the app interface returned is modified in order
 // for a testnet to be created from the provided app.
 func newTestnetApp(logger log.Logger, db dbm.DB, traceStore io.Writer, appOpts servertypes.AppOptions) servertypes.Application {
+	// Get command args
+	args, err := getCommandArgs(appOpts)
+	if err != nil {
+		panic(err)
+	}
+
+	// The app options are the viper of the server context
+	v, ok := appOpts.(*viper.Viper)
+	if !ok {
+		panic("app options are not of type *viper.Viper")
+	}
+	handleErr(writeTestnetConfig(v, args))
+
 	// Create an app and type cast to an App
 	newApp := newApp(logger, db, traceStore, appOpts)
 	testApp, ok := newApp.(*app.App)
@@ -66,13 +102,42 @@ func newTestnetApp(logger log.Logger, db dbm.DB, traceStore io.Writer, appOpts s
 		panic("app created from newApp is not of type App")
 	}
 
-	// Get command args
-	args, err := getCommandArgs(appOpts)
+	return initAppForTestnet(testApp, args)
+}
+
+// writeTestnetConfig makes the node a localnet in app.toml and v: it takes
+// the localnet EVM chain id and minimum gas prices, serves JSON-RPC, and the
+// impersonate namespace if it impersonates addresses.
+func writeTestnetConfig(v *viper.Viper, args valArgs) error {
+	profile := network.MustGet(network.Localnet)
+	appConfig, err := evmserverconfig.GetConfig(v)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	appConfig.MinGasPrices = profile.MinGasPrices
+	appConfig.EVM.EVMChainID = profile.EVMChainID
+	appConfig.JSONRPC.Enable = true
 
-	return initAppForTestnet(testApp, args)
+	impersonated := make([]string, len(args.impersonated))
+	for i, addr := range args.impersonated {
+		impersonated[i] = addr.Hex()
+	}
+	if len(impersonated) > 0 && !slices.Contains(appConfig.JSONRPC.API, impersonate.Namespace) {
+		appConfig.JSONRPC.API = append(appConfig.JSONRPC.API, impersonate.Namespace)
+	}
+
+	appConfigFile := filepath.Join(args.homeDir, "config", "app.toml")
+	writeAppConfig(appConfigFile, appConfig, network.Localnet, strings.Join(impersonated, ","))
+
+	// flags would override the file
+	v.Set(network.FlagNetwork, network.Localnet)
+	v.Set(impersonate.FlagImpersonate, strings.Join(impersonated, ","))
+	v.SetConfigFile(appConfigFile)
+	if err := v.MergeInConfig(); err != nil {
+		return fmt.Errorf("failed to reload %s: %w", appConfigFile, err)
+	}
+
+	return nil
 }
 
 func initAppForTestnet(app *app.App, args valArgs) *app.App {
@@ -163,20 +228,32 @@ func initAppForTestnet(app *app.App, args valArgs) *app.App {
 
 	// BANK
 	//
-	bondDenom, err := app.StakingKeeper.BondDenom(ctx)
-	handleErr(err)
 
-	defaultCoins := sdk.NewCoins(sdk.NewInt64Coin(bondDenom, 1000000000))
+	// precisebank needs the EVM coin info, which the vm module sets on its
+	// first block
+	vm, ok := app.ModuleManager.Modules[evmtypes.ModuleName].(appmodule.HasPreBlocker)
+	if !ok {
+		handleErr(errors.New("vm module has no pre blocker"))
+	}
+	_, err = vm.PreBlock(ctx)
+	handleErr(err)
 
-	// Fund local accounts
-	for _, accountStr := range args.accountsToFund {
-		handleErr(app.BankKeeper.MintCoins(ctx, minttypes.ModuleName, defaultCoins))
+	// Fund local accounts through precisebank, which keeps the amount under
+	// a bond denom unit as their fractional balance
+	for _, account := range args.accountsToFund {
+		handleErr(app.PreciseBankKeeper.MintCoins(ctx, minttypes.ModuleName, args.fundCoins))
+		handleErr(app.PreciseBankKeeper.SendCoinsFromModuleToAccount(ctx, minttypes.ModuleName, account, args.fundCoins))
+	}
 
-		account, err := app.AuthKeeper.AddressCodec().StringToBytes(accountStr)
-		handleErr(err)
+	// FEEMARKET
+	//
 
-		handleErr(app.BankKeeper.SendCoinsFromModuleToAccount(ctx, minttypes.ModuleName, account, defaultCoins))
-	}
+	// Clear the base fee, so wallets send txs without a fee
+	feemarketParams := app.FeeMarketKeeper.GetParams(ctx)
+	feemarketParams.NoBaseFee = true
+	feemarketParams.BaseFee = math.LegacyZeroDec()
+	feemarketParams.MinGasPrice = math.LegacyZeroDec()
+	handleErr(app.FeeMarketKeeper.SetParams(ctx, feemarketParams))
 
 	return app
 }
@@ -208,7 +285,28 @@ func getCommandArgs(appOpts servertypes.AppOptions) (valArgs, error) {
 
 	// parsing  and set accounts to fund
 	accountsString := cast.ToString(appOpts.Get(flagAccountsToFund))
-	args.accountsToFund = append(args.accountsToFund, strings.Split(accountsString, ",")...)
+	for _, accountStr := range strings.Split(accountsString, ",") {
+		if strings.TrimSpace(accountStr) == "" {
+			continue
+		}
+		account, err := faucet.ParseAddress(accountStr)
+		if err != nil {
+			return args, err
+		}
+		args.accountsToFund = append(args.accountsToFund, account)
+	}
+	fundAmount, err := parseExtendedAmount(cast.ToString(appOpts.Get(flagFundAmount)))
+	if err != nil {
+		return args, fmt.Errorf("invalid fund amount: %w", err)
+	}
+	args.fundCoins = sdk.NewCoins(sdk.NewCoin(app.ExtendedDenom, fundAmount))
+
+	// impersonated addresses
+	impersonated, err := impersonate.Addresses(appOpts)
+	if err != nil {
+		return args, err
+	}
+	args.impersonated = impersonated
 
 	// home dir
 	homeDir := cast.ToString(appOpts.Get(flags.FlagHome))
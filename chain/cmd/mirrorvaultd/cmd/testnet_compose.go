@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"net"
+	"path/filepath"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+
+	srvflags "github.com/cosmos/evm/server/flags"
+)
+
+const (
+	// composeImage is the image of the nodes, which must have mirrorvaultd
+	// as entrypoint.
+	composeImage = "${MIRRORVAULTD_IMAGE:-mirrorvault:local}"
+	// composeHome is the home of a node in its container.
+	composeHome = "/mirrorvault"
+	// composeNetworkName is the network of the containers.
+	composeNetworkName = "mirrorvault"
+	// prometheusPort is the host port of the Prometheus container.
+	prometheusPort = 9091
+)
+
+type composeFile struct {
+	Services map[string]composeService `yaml:"services"`
+	Networks map[string]composeNetwork `yaml:"networks"`
+}
+
+type composeService struct {
+	Image         string                           `yaml:"image"`
+	ContainerName string                           `yaml:"container_name"`
+	Command       []string                         `yaml:"command,omitempty"`
+	Volumes       []string                         `yaml:"volumes"`
+	Ports         []string                         `yaml:"ports"`
+	Networks      map[string]composeServiceNetwork `yaml:"networks"`
+}
+
+type composeServiceNetwork struct {
+	IPv4Address string `yaml:"ipv4_address"`
+}
+
+type composeNetwork struct {
+	Driver string `yaml:"driver"`
+	IPAM   struct {
+		Config []composeIPAMConfig `yaml:"config"`
+	} `yaml:"ipam"`
+}
+
+type composeIPAMConfig struct {
+	Subnet string `yaml:"subnet"`
+}
+
+// writeDockerCompose writes the docker-compose.yml running each node in a
+// container at its address, with its node directory as home and its ports
+// published, and Prometheus if the nodes have telemetry.
+func writeDockerCompose(args initArgs, nodes []testnetNode) error {
+	// the nodes are in the /24 of the starting address
+	start := net.ParseIP(args.startingIPAddress).To4()
+	subnet := &net.IPNet{IP: start.Mask(net.CIDRMask(24, 32)), Mask: net.CIDRMask(24, 32)}
+	bridge := composeNetwork{Driver: "bridge"}
+	bridge.IPAM.Config = []composeIPAMConfig{{Subnet: subnet.String()}}
+
+	compose := composeFile{
+		Services: make(map[string]composeService, len(nodes)+1),
+		Networks: map[string]composeNetwork{composeNetworkName: bridge},
+	}
+	for _, node := range nodes {
+		command := []string{"start", "--home", composeHome}
+		if args.prometheus {
+			command = append(command, "--"+srvflags.JSONRPCEnableMetrics)
+		}
+
+		var ports []string
+		for _, port := range []int{node.ports.P2P, node.ports.RPC, node.ports.GRPC, node.ports.API, node.ports.JSONRPC, node.ports.JSONRPCWS} {
+			ports = append(ports, strconv.Itoa(port)+":"+strconv.Itoa(port))
+		}
+
+		compose.Services[node.name] = composeService{
+			Image:         composeImage,
+			ContainerName: node.name,
+			Command:       command,
+			Volumes:       []string{"./" + node.name + ":" + composeHome},
+			Ports:         ports,
+			Networks:      map[string]composeServiceNetwork{composeNetworkName: {IPv4Address: node.host}},
+		}
+	}
+
+	// Prometheus takes the address after the nodes
+	if args.prometheus {
+		last := net.ParseIP(nodes[len(nodes)-1].host).To4()
+		ip := net.IPv4(last[0], last[1], last[2], last[3]+1)
+		compose.Services["prometheus"] = composeService{
+			Image:         "prom/prometheus",
+			ContainerName: "prometheus",
+			Volumes:       []string{"./prometheus.yml:/etc/prometheus/prometheus.yml:ro"},
+			Ports:         []string{strconv.Itoa(prometheusPort) + ":9090"},
+			Networks:      map[string]composeServiceNetwork{composeNetworkName: {IPv4Address: ip.String()}},
+		}
+	}
+
+	bz, err := yaml.Marshal(compose)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(args.outputDir, "docker-compose.yml"), args.outputDir, bz)
+}
+
+type prometheusConfig struct {
+	Global struct {
+		ScrapeInterval string `yaml:"scrape_interval"`
+	} `yaml:"global"`
+	ScrapeConfigs []prometheusScrapeConfig `yaml:"scrape_configs"`
+}
+
+type prometheusScrapeConfig struct {
+	JobName       string                   `yaml:"job_name"`
+	MetricsPath   string                   `yaml:"metrics_path"`
+	Params        map[string][]string      `yaml:"params,omitempty"`
+	StaticConfigs []prometheusStaticConfig `yaml:"static_configs"`
+}
+
+type prometheusStaticConfig struct {
+	Targets []string          `yaml:"targets"`
+	Labels  map[string]string `yaml:"labels"`
+}
+
+// writePrometheusConfig writes the prometheus.yml scraping the metrics of
+// CometBFT, the SDK telemetry, the geth metrics of the EVM and the JSON-RPC
+// metrics of each node, labeled with the node name.
+func writePrometheusConfig(args initArgs, nodes []testnetNode) error {
+	jobs := []struct {
+		name, path string
+		params     map[string][]string
+		port       func(testnetNode) int
+	}{
+		{"cometbft", "/metrics", nil, func(n testnetNode) int { return n.ports.Prometheus }},
+		{"cosmos-sdk", "/metrics", map[string][]string{"format": {"prometheus"}}, func(n testnetNode) int { return n.ports.API }},
+		{"evm", "/metrics", nil, func(n testnetNode) int { return n.ports.GethMetrics }},
+		{"json-rpc", "/debug/metrics/prometheus", nil, func(n testnetNode) int { return n.ports.JSONRPCMetrics }},
+	}
+
+	var config prometheusConfig
+	config.Global.ScrapeInterval = "15s"
+	for _, job := range jobs {
+		scrape := prometheusScrapeConfig{JobName: job.name, MetricsPath: job.path, Params: job.params}
+		for _, node := range nodes {
+			scrape.StaticConfigs = append(scrape.StaticConfigs, prometheusStaticConfig{
+				Targets: []string{net.JoinHostPort(node.host, strconv.Itoa(job.port(node)))},
+				Labels:  map[string]string{"node": node.name},
+			})
+		}
+		config.ScrapeConfigs = append(config.ScrapeConfigs, scrape)
+	}
+
+	bz, err := yaml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	return writeFile(filepath.Join(args.outputDir, "prometheus.yml"), args.outputDir, bz)
+}
@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"bufio"
+	"encoding/binary"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
+	"net"
 	"os"
 	"path/filepath"
 	"strconv"
@@ -22,7 +24,6 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/tx"
-	"github.com/cosmos/cosmos-sdk/crypto/hd"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	"github.com/cosmos/cosmos-sdk/server"
@@ -37,19 +38,31 @@ import (
 	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
 
 	runtime "github.com/cosmos/cosmos-sdk/runtime"
+
+	evmhd "github.com/cosmos/evm/crypto/hd"
+	evmserverconfig "github.com/cosmos/evm/server/config"
+
+	"mirrorvault/app"
+	"mirrorvault/network"
 )
 
 var (
 	flagNodeDirPrefix         = "node-dir-prefix"
-	flagPorts                 = "list-ports"
 	flagNumValidators         = "v"
 	flagOutputDir             = "output-dir"
 	flagValidatorsStakeAmount = "validators-stake-amount"
 	flagStartingIPAddress     = "starting-ip-address"
+	flagPortStride            = "port-stride"
+	flagDockerCompose         = "docker-compose"
+	flagPrometheus            = "prometheus"
 )
 
 const nodeDirPerm = 0o755
 
+// composeStartingIPAddress is the address of the first node of a docker
+// compose network, unless --starting-ip-address sets one.
+const composeStartingIPAddress = "192.168.10.2"
+
 type initArgs struct {
 	algo                   string
 	chainID                string
@@ -60,24 +73,58 @@ type initArgs struct {
 	outputDir              string
 	startingIPAddress      string
 	validatorsStakesAmount map[int]sdk.Coin
-	ports                  map[int]string
+	portStride             int
+	dockerCompose          bool
+	prometheus             bool
+}
+
+// testnetNode is a node of the testnet.
+type testnetNode struct {
+	name string
+	// host is the address the other nodes reach the node at.
+	host  string
+	ports network.Ports
+}
+
+// NewTestnetCmd returns the command initializing multi-validator testnets.
+func NewTestnetCmd(mbm module.BasicManager, genBalIterator banktypes.GenesisBalancesIterator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        "testnet",
+		Short:                      "Initialize multi-validator testnets",
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	cmd.AddCommand(testnetInitFilesCmd(mbm, genBalIterator))
+
+	return cmd
 }
 
-// NewTestnetMultiNodeCmd returns a cmd to initialize all files for tendermint testnet and application
-func NewTestnetMultiNodeCmd(mbm module.BasicManager, genBalIterator banktypes.GenesisBalancesIterator) *cobra.Command {
+// testnetInitFilesCmd returns a cmd to initialize all files for tendermint testnet and application
+func testnetInitFilesCmd(mbm module.BasicManager, genBalIterator banktypes.GenesisBalancesIterator) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "multi-node",
+		Use:   "init-files",
 		Short: "Initialize config directories & files for a multi-validator testnet running locally via separate processes (e.g. Docker Compose or similar)",
-		Long: `multi-node will setup "v" number of directories and populate each with
+		Long: `init-files will setup "v" number of directories and populate each with
 necessary files (private validator, genesis, config, etc.) for running "v" validator nodes.
 
 Booting up a network with these validator folders is intended to be used with Docker Compose,
 or a similar setup where each node has a manually configurable IP address.
 
+Each node listens on the default ports shifted by its index times --port-stride: CometBFT P2P,
+RPC and Prometheus, gRPC, REST, and the EVM JSON-RPC, WebSocket and metrics. The nodes run
+as a localnet, whose EVM chain id they take, and the validator operator keys are
+eth_secp256k1, as MetaMask derives them from the key_seed.json mnemonic.
+
+--docker-compose writes a docker-compose.yml running each node at its own address from
+--starting-ip-address, ` + composeStartingIPAddress + ` by default, with its ports published. Its image,
+$MIRRORVAULTD_IMAGE or mirrorvault:local, must have mirrorvaultd as entrypoint.
+--prometheus enables the node telemetry and writes a prometheus.yml scraping CometBFT, the
+SDK and the EVM, which docker compose then runs on port 9091.
+
 Note, strict routability for addresses is turned off in the config file.
 
 Example:
-	mirrorvaultd multi-node --v 4 --output-dir ./.testnets --validators-stake-amount 1000000,200000,300000,400000 --list-ports 47222,50434,52851,44210
+	mirrorvaultd testnet init-files --v 4 --output-dir ./.testnets --validators-stake-amount 1000000,200000,300000,400000 --docker-compose --prometheus
 	`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			clientCtx, err := client.GetClientQueryContext(cmd)
@@ -97,8 +144,16 @@ Example:
 			args.startingIPAddress, _ = cmd.Flags().GetString(flagStartingIPAddress)
 			args.numValidators, _ = cmd.Flags().GetInt(flagNumValidators)
 			args.algo, _ = cmd.Flags().GetString(flags.FlagKeyType)
+			args.portStride, _ = cmd.Flags().GetInt(flagPortStride)
+			args.dockerCompose, _ = cmd.Flags().GetBool(flagDockerCompose)
+			args.prometheus, _ = cmd.Flags().GetBool(flagPrometheus)
+			if err := network.DefaultPorts().CheckStride(args.portStride, args.numValidators); err != nil {
+				return fmt.Errorf("invalid --%s: %w", flagPortStride, err)
+			}
+			if args.dockerCompose && !cmd.Flags().Changed(flagStartingIPAddress) {
+				args.startingIPAddress = composeStartingIPAddress
+			}
 
-			args.ports = map[int]string{}
 			args.validatorsStakesAmount = make(map[int]sdk.Coin)
 			top := 0
 			// If the flag string is invalid, the amount will default to 100000000.
@@ -108,32 +163,20 @@ Example:
 					if !ok {
 						continue
 					}
-					args.validatorsStakesAmount[top] = sdk.NewCoin(sdk.DefaultBondDenom, a)
+					args.validatorsStakesAmount[top] = sdk.NewCoin(app.BaseDenom, a)
 					top += 1
 				}
 
 			}
-			top = 0
-			if s, err := cmd.Flags().GetString(flagPorts); err == nil {
-				if s == "" {
-					for i := 0; i < args.numValidators; i++ {
-						args.ports[top] = strconv.Itoa(26657 - 3*i)
-						top += 1
-					}
-				} else {
-					for _, port := range strings.Split(s, ",") {
-						args.ports[top] = port
-						top += 1
-					}
-				}
-			}
 
 			return initTestnetFiles(clientCtx, cmd, config, mbm, genBalIterator, args)
 		},
 	}
 
 	addTestnetFlagsToCmd(cmd)
-	cmd.Flags().String(flagPorts, "", "Ports of nodes (default 26657,26654,26651,26648.. )")
+	cmd.Flags().Int(flagPortStride, 10, "Shift of the ports of each node from the previous one")
+	cmd.Flags().Bool(flagDockerCompose, false, "Write a docker-compose.yml running the nodes")
+	cmd.Flags().Bool(flagPrometheus, false, "Enable telemetry and write a prometheus.yml scraping the nodes")
 	cmd.Flags().String(flagNodeDirPrefix, "validator", "Prefix the directory name for each node with (node results in node0, node1, ...)")
 	cmd.Flags().String(flagValidatorsStakeAmount, "100000000,100000000,100000000,100000000", "Amount of stake for each validator")
 	cmd.Flags().String(flagStartingIPAddress, "localhost", "Starting IP address (192.168.0.1 results in persistent peers list ID0@192.168.0.1:46656, ID1@192.168.0.2:46656, ...)")
@@ -146,8 +189,8 @@ func addTestnetFlagsToCmd(cmd *cobra.Command) {
 	cmd.Flags().Int(flagNumValidators, 4, "Number of validators to initialize the testnet with")
 	cmd.Flags().StringP(flagOutputDir, "o", "./.testnets", "Directory to store initialization data for the testnet")
 	cmd.Flags().String(flags.FlagChainID, "", "genesis file chain-id, if left blank will be randomly created")
-	cmd.Flags().String(server.FlagMinGasPrices, fmt.Sprintf("0.0001%s", sdk.DefaultBondDenom), "Minimum gas prices to accept for transactions; All fees in a tx must meet this minimum (e.g. 0.01photino,0.001stake)")
-	cmd.Flags().String(flags.FlagKeyType, string(hd.Secp256k1Type), "Key signing algorithm to generate keys for")
+	cmd.Flags().String(server.FlagMinGasPrices, network.MustGet(network.Localnet).MinGasPrices, fmt.Sprintf("Minimum gas prices to accept for transactions; All fees in a tx must meet this minimum (e.g. 0.01%s)", app.BaseDenom))
+	cmd.Flags().String(flags.FlagKeyType, string(evmhd.EthSecp256k1Type), "Key signing algorithm to generate keys for")
 
 	// support old flags name for backwards compatibility
 	cmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
@@ -173,14 +216,26 @@ func initTestnetFiles(
 	}
 	nodeIDs := make([]string, args.numValidators)
 	valPubKeys := make([]cryptotypes.PubKey, args.numValidators)
+	nodes, err := testnetNodes(args)
+	if err != nil {
+		return err
+	}
 
-	appConfig := srvconfig.DefaultConfig()
+	// the nodes run as a localnet, the servers of a compose node listen on
+	// its address
+	_, defaultAppConfig := initAppConfig(network.MustGet(network.Localnet))
+	appConfig := defaultAppConfig.(EVMAppConfig)
 	appConfig.MinGasPrices = args.minGasPrices
-	appConfig.API.Enable = false
-	appConfig.BaseConfig.MinGasPrices = "0.0001" + sdk.DefaultBondDenom
 	appConfig.Telemetry.EnableHostnameLabel = false
-	appConfig.Telemetry.Enabled = false
+	appConfig.Telemetry.Enabled = args.prometheus
 	appConfig.Telemetry.PrometheusRetentionTime = 0
+	if args.prometheus {
+		appConfig.Telemetry.PrometheusRetentionTime = 60
+	}
+	listenHost := "127.0.0.1"
+	if args.dockerCompose {
+		listenHost = "0.0.0.0"
+	}
 
 	var (
 		genAccounts     []authtypes.GenesisAccount
@@ -192,13 +247,12 @@ func initTestnetFiles(
 
 	inBuf := bufio.NewReader(cmd.InOrStdin())
 	for i := 0; i < args.numValidators; i++ {
-		nodeDirName := fmt.Sprintf("%s%d", args.nodeDirPrefix, i)
+		nodeDirName := nodes[i].name
 		nodeDir := filepath.Join(args.outputDir, nodeDirName)
 		gentxsDir := filepath.Join(args.outputDir, nodeDirName, "config", "gentx")
 
 		nodeConfig.SetRoot(nodeDir)
 		nodeConfig.Moniker = nodeDirName
-		nodeConfig.RPC.ListenAddress = "tcp://0.0.0.0:" + args.ports[i]
 
 		var err error
 		if err := os.MkdirAll(filepath.Join(nodeDir, "config"), nodeDirPerm); err != nil {
@@ -212,7 +266,7 @@ func initTestnetFiles(
 			return err
 		}
 
-		memo := fmt.Sprintf("%s@%s:"+strconv.Itoa(26656-3*i), nodeIDs[i], args.startingIPAddress)
+		memo := fmt.Sprintf("%s@%s", nodeIDs[i], net.JoinHostPort(nodes[i].host, strconv.Itoa(nodes[i].ports.P2P)))
 
 		if persistentPeers == "" {
 			persistentPeers = memo
@@ -222,7 +276,7 @@ func initTestnetFiles(
 
 		genFiles = append(genFiles, nodeConfig.GenesisFile())
 
-		kb, err := keyring.New(sdk.KeyringServiceName(), args.keyringBackend, nodeDir, inBuf, clientCtx.Codec)
+		kb, err := keyring.New(sdk.KeyringServiceName(), args.keyringBackend, nodeDir, inBuf, clientCtx.Codec, evmhd.EthSecp256k1Option())
 		if err != nil {
 			return err
 		}
@@ -253,11 +307,7 @@ func initTestnetFiles(
 		}
 
 		accTokens := sdk.TokensFromConsensusPower(1000, sdk.DefaultPowerReduction)
-		accStakingTokens := sdk.TokensFromConsensusPower(500, sdk.DefaultPowerReduction)
-		coins := sdk.Coins{
-			sdk.NewCoin("testtoken", accTokens),
-			sdk.NewCoin(sdk.DefaultBondDenom, accStakingTokens),
-		}
+		coins := sdk.NewCoins(sdk.NewCoin(app.BaseDenom, accTokens))
 
 		genBalances = append(genBalances, banktypes.Balance{Address: addr.String(), Coins: coins.Sort()})
 		genAccounts = append(genAccounts, authtypes.NewBaseAccount(addr, nil, 0, 0))
@@ -265,7 +315,7 @@ func initTestnetFiles(
 		var valTokens sdk.Coin
 		valTokens, ok := args.validatorsStakesAmount[i]
 		if !ok {
-			valTokens = sdk.NewCoin(sdk.DefaultBondDenom, sdk.TokensFromConsensusPower(100, sdk.DefaultPowerReduction))
+			valTokens = sdk.NewCoin(app.BaseDenom, sdk.TokensFromConsensusPower(100, sdk.DefaultPowerReduction))
 		}
 		createValMsg, err := stakingtypes.NewMsgCreateValidator(
 			sdk.ValAddress(addr).String(),
@@ -307,9 +357,8 @@ func initTestnetFiles(
 			return err
 		}
 
-		appConfig.GRPC.Address = args.startingIPAddress + ":" + strconv.Itoa(9090-2*i)
-		appConfig.API.Address = "tcp://localhost:" + strconv.Itoa(1317-i)
-		srvconfig.WriteConfigFile(filepath.Join(nodeDir, "config", "app.toml"), appConfig)
+		srvconfig.SetConfigTemplate(appConfigTemplate())
+		srvconfig.WriteConfigFile(filepath.Join(nodeDir, "config", "app.toml"), nodeAppConfig(appConfig, listenHost, nodes[i].ports))
 	}
 
 	if err := initGenFiles(clientCtx, mbm, args.chainID, genAccounts, genBalances, genFiles, args.numValidators); err != nil {
@@ -318,7 +367,7 @@ func initTestnetFiles(
 	// copy gentx file
 	for i := 0; i < args.numValidators; i++ {
 		for _, file := range gentxsFiles {
-			nodeDirName := fmt.Sprintf("%s%d", args.nodeDirPrefix, i)
+			nodeDirName := nodes[i].name
 			nodeDir := filepath.Join(args.outputDir, nodeDirName)
 			gentxsDir := filepath.Join(nodeDir, "config", "gentx")
 
@@ -332,20 +381,77 @@ func initTestnetFiles(
 			}
 		}
 	}
-	err := collectGenFiles(
+	err = collectGenFiles(
 		clientCtx, nodeConfig, nodeIDs, valPubKeys,
 		genBalIterator,
 		clientCtx.TxConfig.SigningContext().ValidatorAddressCodec(),
-		persistentPeers, args,
+		persistentPeers, args, nodes, listenHost,
 	)
 	if err != nil {
 		return err
 	}
 
+	if args.dockerCompose {
+		if err := writeDockerCompose(args, nodes); err != nil {
+			return err
+		}
+	}
+	if args.prometheus {
+		if err := writePrometheusConfig(args, nodes); err != nil {
+			return err
+		}
+	}
+
 	cmd.PrintErrf("Successfully initialized %d node directories\n", args.numValidators)
 	return nil
 }
 
+// testnetNodes returns the nodes of the testnet. Nodes are at consecutive
+// addresses from an IP starting address, and all at a host name otherwise.
+func testnetNodes(args initArgs) ([]testnetNode, error) {
+	start := net.ParseIP(args.startingIPAddress).To4()
+	if args.dockerCompose && start == nil {
+		return nil, fmt.Errorf("docker compose needs an IPv4 starting address, got %q", args.startingIPAddress)
+	}
+
+	nodes := make([]testnetNode, args.numValidators)
+	for i := range nodes {
+		nodes[i] = testnetNode{
+			name:  fmt.Sprintf("%s%d", args.nodeDirPrefix, i),
+			host:  args.startingIPAddress,
+			ports: network.DefaultPorts().Offset(i * args.portStride),
+		}
+		if start != nil {
+			ip := binary.BigEndian.Uint32(start) + uint32(i) //#nosec G115 -- i is a node index
+			nodes[i].host = net.IPv4(byte(ip>>24), byte(ip>>16), byte(ip>>8), byte(ip)).String()
+		}
+	}
+
+	return nodes, nil
+}
+
+// nodeAppConfig returns the app config of a node whose servers listen on
+// host at ports.
+func nodeAppConfig(appConfig EVMAppConfig, host string, ports network.Ports) EVMAppConfig {
+	appConfig.API.Address = "tcp://" + host
+	appConfig.GRPC.Address = host
+	appConfig.JSONRPC.Address = host
+	appConfig.JSONRPC.WsAddress = host
+	appConfig.JSONRPC.MetricsAddress = host
+	appConfig.EVM.GethMetricsAddress = host
+
+	cfg := evmserverconfig.Config{
+		Config:  appConfig.Config,
+		EVM:     appConfig.EVM,
+		JSONRPC: appConfig.JSONRPC,
+		TLS:     appConfig.TLS,
+	}
+	ports.ApplyApp(&cfg)
+	appConfig.Config, appConfig.EVM, appConfig.JSONRPC, appConfig.TLS = cfg.Config, cfg.EVM, cfg.JSONRPC, cfg.TLS
+
+	return appConfig
+}
+
 func writeFile(file, dir string, contents []byte) error {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return fmt.Errorf("could not create directory %q: %w", dir, err)
@@ -412,18 +518,17 @@ func collectGenFiles(
 	nodeIDs []string, valPubKeys []cryptotypes.PubKey,
 	genBalIterator banktypes.GenesisBalancesIterator,
 	valAddrCodec runtime.ValidatorAddressCodec, persistentPeers string,
-	args initArgs,
+	args initArgs, nodes []testnetNode, listenHost string,
 ) error {
 	chainID := args.chainID
 	numValidators := args.numValidators
 	outputDir := args.outputDir
-	nodeDirPrefix := args.nodeDirPrefix
 
 	var appState json.RawMessage
 	genTime := tmtime.Now()
 
 	for i := 0; i < numValidators; i++ {
-		nodeDirName := fmt.Sprintf("%s%d", nodeDirPrefix, i)
+		nodeDirName := nodes[i].name
 		nodeDir := filepath.Join(outputDir, nodeDirName)
 		gentxsDir := filepath.Join(nodeDir, "config", "gentx")
 		nodeConfig.Moniker = nodeDirName
@@ -446,11 +551,11 @@ func collectGenFiles(
 
 		nodeConfig.P2P.PersistentPeers = persistentPeers
 		nodeConfig.P2P.AllowDuplicateIP = true
-		nodeConfig.P2P.ListenAddress = "tcp://0.0.0.0:" + strconv.Itoa(26656-3*i)
-		nodeConfig.RPC.ListenAddress = "tcp://127.0.0.1:" + args.ports[i]
-		nodeConfig.BaseConfig.ProxyApp = "tcp://127.0.0.1:" + strconv.Itoa(26658-3*i)
-		nodeConfig.Instrumentation.PrometheusListenAddr = ":" + strconv.Itoa(26660+i)
+		nodeConfig.P2P.ListenAddress = "tcp://0.0.0.0"
+		nodeConfig.RPC.ListenAddress = "tcp://" + listenHost
+		nodeConfig.BaseConfig.ProxyApp = "tcp://127.0.0.1"
 		nodeConfig.Instrumentation.Prometheus = true
+		nodes[i].ports.ApplyComet(nodeConfig)
 		cmtconfig.WriteConfigFile(filepath.Join(nodeConfig.RootDir, "config", "config.toml"), nodeConfig)
 		if appState == nil {
 			// set the canonical application state (they should not differ)
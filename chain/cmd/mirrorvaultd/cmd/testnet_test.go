@@ -0,0 +1,71 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestTestnetInitFiles(t *testing.T) {
+	dir := t.TempDir()
+	out, err := runCLI(t, "testnet", "init-files", "--v", "2", "-o", dir, "--chain-id", "mirror-vault-tn", "--docker-compose", "--prometheus")
+	require.NoError(t, err, out)
+
+	for i, node := range []string{"validator0", "validator1"} {
+		home := filepath.Join(dir, node)
+		out, err := runCLI(t, "genesis", "validate", "--home", home)
+		require.NoError(t, err, out)
+
+		// the servers of each node listen on its own ports, on all
+		// interfaces of its container
+		v := viper.New()
+		v.SetConfigFile(filepath.Join(home, "config", "app.toml"))
+		require.NoError(t, v.ReadInConfig())
+		offset := 10 * i
+		require.Equal(t, "localnet", v.GetString("network"))
+		require.Equal(t, "0umvlt", v.GetString("minimum-gas-prices"))
+		require.Equal(t, 7777, v.GetInt("evm.evm-chain-id"))
+		require.True(t, v.GetBool("telemetry.enabled"))
+		require.True(t, v.GetBool("api.enable"))
+		require.Equal(t, "tcp://0.0.0.0:"+strconv.Itoa(1317+offset), v.GetString("api.address"))
+		require.Equal(t, "0.0.0.0:"+strconv.Itoa(9090+offset), v.GetString("grpc.address"))
+		require.Equal(t, "0.0.0.0:"+strconv.Itoa(8545+offset), v.GetString("json-rpc.address"))
+		require.Equal(t, "0.0.0.0:"+strconv.Itoa(8546+offset), v.GetString("json-rpc.ws-address"))
+
+		v = viper.New()
+		v.SetConfigFile(filepath.Join(home, "config", "config.toml"))
+		require.NoError(t, v.ReadInConfig())
+		require.Equal(t, "tcp://0.0.0.0:"+strconv.Itoa(26657+offset), v.GetString("rpc.laddr"))
+		require.Equal(t, ":"+strconv.Itoa(26660+offset), v.GetString("instrumentation.prometheus_listen_addr"))
+	}
+
+	genesis, err := os.ReadFile(filepath.Join(dir, "validator0", "config", "genesis.json"))
+	require.NoError(t, err)
+	require.Contains(t, string(genesis), "/cosmos.evm.crypto.v1.ethsecp256k1.PubKey")
+
+	var compose struct {
+		Services map[string]struct {
+			Ports    []string
+			Networks map[string]struct {
+				IPv4Address string `yaml:"ipv4_address"`
+			}
+		}
+	}
+	bz, err := os.ReadFile(filepath.Join(dir, "docker-compose.yml"))
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(bz, &compose))
+	require.Len(t, compose.Services, 3)
+	require.Contains(t, compose.Services["validator1"].Ports, "8555:8555")
+	require.Equal(t, "192.168.10.3", compose.Services["validator1"].Networks["mirrorvault"].IPv4Address)
+	require.Equal(t, "192.168.10.4", compose.Services["prometheus"].Networks["mirrorvault"].IPv4Address)
+
+	bz, err = os.ReadFile(filepath.Join(dir, "prometheus.yml"))
+	require.NoError(t, err)
+	require.Contains(t, string(bz), "192.168.10.3:26670")
+	require.Contains(t, string(bz), "192.168.10.2:8100")
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	"mirrorvault/app"
+	"mirrorvault/walletconfig"
+)
+
+const (
+	flagRPCURL     = "rpc-url"
+	flagRESTURL    = "rest-url"
+	flagJSONRPCURL = "json-rpc-url"
+)
+
+// walletConfigCmd returns the command printing the wallet onboarding payloads.
+func walletConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:       fmt.Sprintf("wallet-config [%s]", strings.Join(walletconfig.Kinds, "|")),
+		Short:     "Print the Keplr, MetaMask or chain-registry config of the node",
+		ValidArgs: walletconfig.Kinds,
+		Long: `Print the JSON a wallet needs to add the chain, derived from the node's
+config.toml, app.toml and client.toml:
+
+  keplr           argument of window.keplr.experimentalSuggestChain
+  metamask        argument of the wallet_addEthereumChain RPC method
+  chain-registry  cosmos/chain-registry chain.json entry
+
+Listen addresses are turned into localhost URLs; pass --rpc-url, --rest-url
+and --json-rpc-url to publish other endpoints. The API server serves the same
+JSON at ` + walletconfig.Route + `.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			serverCtx := server.GetServerContextFromCmd(cmd)
+
+			chainID := client.GetClientContextFromCmd(cmd).ChainID
+			if chainID == "" {
+				appGenesis, err := genutiltypes.AppGenesisFromFile(serverCtx.Config.GenesisFile())
+				if err != nil {
+					return fmt.Errorf("chain id not set in client.toml and genesis not readable: %w", err)
+				}
+				chainID = appGenesis.ChainID
+			}
+
+			cfg, err := app.WalletConfig(serverCtx.Viper, chainID)
+			if err != nil {
+				return err
+			}
+
+			for flag, url := range map[string]*string{
+				flagRPCURL:     &cfg.RPC,
+				flagRESTURL:    &cfg.REST,
+				flagJSONRPCURL: &cfg.JSONRPC,
+			} {
+				if v, _ := cmd.Flags().GetString(flag); v != "" {
+					*url = v
+				}
+			}
+
+			payload, err := cfg.Payload(args[0])
+			if err != nil {
+				return err
+			}
+
+			out, err := json.MarshalIndent(payload, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), string(out))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagRPCURL, "", "Public CometBFT RPC URL (default from config.toml)")
+	cmd.Flags().String(flagRESTURL, "", "Public REST URL (default from app.toml)")
+	cmd.Flags().String(flagJSONRPCURL, "", "Public EVM JSON-RPC URL (default from app.toml)")
+
+	return cmd
+}
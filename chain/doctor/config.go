@@ -0,0 +1,84 @@
+package doctor
+
+import (
+	"context"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"mirrorvault/app"
+	"mirrorvault/network"
+)
+
+// checkConfig checks the chain ids and the minimum gas prices of the
+// configs agree with the genesis and the network profile.
+func (d *doctor) checkConfig(context.Context) []Finding {
+	profile := d.opts.Profile
+	appConfig := d.opts.AppConfig
+
+	var findings []Finding
+	if d.chainID != "" && d.opts.ClientChainID != "" && d.opts.ClientChainID != d.chainID {
+		findings = append(findings, Finding{
+			Check:   "chain-id",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("client.toml sends txs to chain %s, the genesis is of chain %s: they fail signature verification", d.opts.ClientChainID, d.chainID),
+			Hint:    fmt.Sprintf("%s config set client chain-id %s", app.Name+"d", d.chainID),
+		})
+	}
+
+	if err := profile.CheckEVMChainID(appConfig.EVM.EVMChainID); err != nil {
+		findings = append(findings, Finding{
+			Check:   "evm-chain-id",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("app.toml evm-chain-id is %d, the %s network runs %d: the node refuses to start", appConfig.EVM.EVMChainID, profile.Name, profile.EVMChainID),
+			Hint:    fmt.Sprintf("set evm-chain-id to %d in app.toml, or select the network of the node with its network key", profile.EVMChainID),
+			fix: func() (string, error) {
+				appConfig.EVM.EVMChainID = profile.EVMChainID
+				d.configChanged = true
+				return fmt.Sprintf("set evm-chain-id to %d in app.toml", profile.EVMChainID), nil
+			},
+		})
+	} else {
+		findings = append(findings, Finding{
+			Check:   "evm-chain-id",
+			Status:  StatusOK,
+			Message: fmt.Sprintf("the %s network runs EVM chain id %d", profile.Name, appConfig.EVM.EVMChainID),
+		})
+	}
+
+	return append(findings, d.checkMinGasPrices())
+}
+
+// checkMinGasPrices checks the minimum gas prices are set in a denom fees
+// are paid in.
+func (d *doctor) checkMinGasPrices() Finding {
+	profile := d.opts.Profile
+	appConfig := d.opts.AppConfig
+
+	fixable := func(msg string) Finding {
+		return Finding{
+			Check:   "min-gas-prices",
+			Status:  StatusFail,
+			Message: msg,
+			Hint:    fmt.Sprintf("set minimum-gas-prices to %q in app.toml", profile.MinGasPrices),
+			fix: func() (string, error) {
+				appConfig.MinGasPrices = profile.MinGasPrices
+				d.configChanged = true
+				return fmt.Sprintf("set minimum-gas-prices to %q in app.toml", profile.MinGasPrices), nil
+			},
+		}
+	}
+
+	if appConfig.MinGasPrices == "" {
+		return fixable("app.toml has no minimum-gas-prices: the node refuses to start")
+	}
+	prices, err := sdk.ParseDecCoins(appConfig.MinGasPrices)
+	if err != nil {
+		return fixable(fmt.Sprintf("app.toml minimum-gas-prices %q: %s", appConfig.MinGasPrices, err))
+	}
+	if prices.AmountOf(network.BaseDenom).IsZero() && prices.AmountOf(network.ExtendedDenom).IsZero() && !prices.IsZero() {
+		return fixable(fmt.Sprintf("app.toml minimum-gas-prices %s has no %s price: the node accepts no tx paying fees in %s", prices, network.BaseDenom, network.BaseDenom))
+	}
+
+	return Finding{Check: "min-gas-prices", Status: StatusOK, Message: fmt.Sprintf("minimum gas prices are %q", appConfig.MinGasPrices)}
+}
@@ -0,0 +1,141 @@
+// Package doctor diagnoses the home directory of a node and its host: the
+// files CometBFT needs, the genesis against the modules of the binary, the
+// chain ids and denoms of the configs, the ports and the keys of the
+// keyring. It tells how to fix each problem, and fixes the safe ones.
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	evmserverconfig "github.com/cosmos/evm/server/config"
+
+	"mirrorvault/network"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	StatusOK    Status = "ok"
+	StatusWarn  Status = "warn"
+	StatusFail  Status = "fail"
+	StatusFixed Status = "fixed"
+)
+
+// Finding is the outcome of a check.
+type Finding struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	// Hint tells how to fix the problem.
+	Hint string `json:"hint,omitempty"`
+	// Fixable is set when Options.Fix fixes the problem.
+	Fixable bool `json:"fixable,omitempty"`
+
+	// fix fixes the problem and returns what it did.
+	fix func() (string, error)
+}
+
+// Options are the inputs of Run.
+type Options struct {
+	// Config is the CometBFT config of the node, rooted at its home.
+	Config *cmtcfg.Config
+	// AppConfig is the app config of the node.
+	AppConfig *evmserverconfig.Config
+	// Profile is the network profile the node runs as.
+	Profile network.Profile
+	// ClientChainID is the chain id of client.toml, if any.
+	ClientChainID string
+	// Keyring holds the keys checked, none are when it is nil.
+	Keyring      keyring.Keyring
+	Codec        codec.Codec
+	TxConfig     client.TxConfig
+	BasicManager module.BasicManager
+	// HTTPClient queries the processes holding the ports of the node.
+	HTTPClient *http.Client
+
+	// Fix fixes the safe problems.
+	Fix bool
+	// WriteConfig writes Config and AppConfig to the home after fixes
+	// changed them.
+	WriteConfig func() error
+}
+
+// doctor runs the checks, which share what earlier ones found.
+type doctor struct {
+	opts Options
+	// chainID is the chain id of the genesis.
+	chainID string
+	// configChanged is set when fixes changed the configs.
+	configChanged bool
+}
+
+// Run runs the checks and returns their findings. With opts.Fix the
+// fixable problems are fixed and their findings have StatusFixed.
+func Run(ctx context.Context, opts Options) ([]Finding, error) {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	d := &doctor{opts: opts}
+
+	var findings []Finding
+	for _, check := range []func(context.Context) []Finding{
+		d.checkFiles,
+		d.checkGenesis,
+		d.checkConfig,
+		d.checkPorts,
+		d.checkKeyring,
+		d.checkHost,
+	} {
+		findings = append(findings, check(ctx)...)
+	}
+	for i := range findings {
+		findings[i].Fixable = findings[i].fix != nil && findings[i].Status != StatusOK
+	}
+	if !opts.Fix {
+		return findings, nil
+	}
+
+	for i := range findings {
+		f := &findings[i]
+		if !f.Fixable {
+			continue
+		}
+		msg, err := f.fix()
+		if err != nil {
+			return findings, fmt.Errorf("failed to fix %s: %w", f.Check, err)
+		}
+		f.Status, f.Message, f.Hint, f.Fixable = StatusFixed, msg, "", false
+	}
+	if d.configChanged {
+		if err := opts.WriteConfig(); err != nil {
+			return findings, err
+		}
+	}
+
+	return findings, nil
+}
+
+// Failed returns the number of findings with StatusFail, and how many of
+// them are fixable.
+func Failed(findings []Finding) (failed, fixable int) {
+	for _, f := range findings {
+		if f.Status != StatusFail {
+			continue
+		}
+		failed++
+		if f.Fixable {
+			fixable++
+		}
+	}
+	return failed, fixable
+}
@@ -0,0 +1,238 @@
+package doctor_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	cmtstore "github.com/cometbft/cometbft/proto/tendermint/store"
+	"github.com/cometbft/cometbft/store"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/depinject"
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+
+	evmhd "github.com/cosmos/evm/crypto/hd"
+	evmserverconfig "github.com/cosmos/evm/server/config"
+
+	"mirrorvault/app"
+	"mirrorvault/doctor"
+	"mirrorvault/localnet"
+	"mirrorvault/network"
+	"mirrorvault/pairs"
+)
+
+const spec = `default_denom: umvlt
+accounts:
+- name: alice
+  coins:
+  - 1000000000umvlt
+  mnemonic: test test test test test test test test test test test junk
+validators:
+- name: alice
+  bonded: 200000000umvlt
+genesis:
+  chain_id: mirror-vault-test
+`
+
+// newOptions initializes a localnet home and returns the options checking
+// it.
+func newOptions(t *testing.T) (doctor.Options, keyring.Keyring) {
+	t.Helper()
+
+	var (
+		cdc               codec.Codec
+		txConfig          client.TxConfig
+		interfaceRegistry codectypes.InterfaceRegistry
+		basicManager      module.BasicManager
+	)
+	require.NoError(t, depinject.Inject(
+		depinject.Configs(app.AppConfig(), depinject.Supply(log.NewNopLogger())),
+		&cdc,
+		&txConfig,
+		&interfaceRegistry,
+		&basicManager,
+	))
+	for name, mod := range app.RegisterEVM(interfaceRegistry) {
+		basicManager[name] = module.CoreAppModuleBasicAdaptor(name, mod)
+	}
+
+	s, err := localnet.ParseSpec([]byte(spec))
+	require.NoError(t, err)
+	cfg := cmtcfg.DefaultConfig()
+	cfg.SetRoot(t.TempDir())
+	// ports the test host doesn't use
+	cfg.P2P.ListenAddress = "tcp://127.0.0.1:0"
+	cfg.RPC.ListenAddress = "tcp://127.0.0.1:0"
+	kr := keyring.NewInMemory(cdc, evmhd.EthSecp256k1Option())
+	_, err = localnet.Init(context.Background(), localnet.Options{
+		Spec:         s,
+		Config:       cfg,
+		Keyring:      kr,
+		Codec:        cdc,
+		TxConfig:     txConfig,
+		BasicManager: basicManager,
+	})
+	require.NoError(t, err)
+
+	profile := network.MustGet(network.Localnet)
+	appConfig := evmserverconfig.DefaultConfig()
+	appConfig.MinGasPrices = profile.MinGasPrices
+	appConfig.EVM.EVMChainID = profile.EVMChainID
+	appConfig.GRPC.Address = "127.0.0.1:0"
+	appConfig.JSONRPC.Address = "127.0.0.1:0"
+	appConfig.JSONRPC.WsAddress = "127.0.0.1:0"
+
+	return doctor.Options{
+		Config:       cfg,
+		AppConfig:    appConfig,
+		Profile:      profile,
+		Keyring:      kr,
+		Codec:        cdc,
+		TxConfig:     txConfig,
+		BasicManager: basicManager,
+	}, kr
+}
+
+// findings returns the findings of check.
+func findings(t *testing.T, all []doctor.Finding, check string) []doctor.Finding {
+	t.Helper()
+
+	var found []doctor.Finding
+	for _, f := range all {
+		if f.Check == check {
+			found = append(found, f)
+		}
+	}
+	require.NotEmpty(t, found, check)
+	return found
+}
+
+func TestRun(t *testing.T) {
+	opts, _ := newOptions(t)
+
+	all, err := doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	for _, check := range []string{"files", "genesis", "gentx", "evm-chain-id", "min-gas-prices", "ports", "keyring"} {
+		require.Equal(t, doctor.StatusOK, findings(t, all, check)[0].Status, check)
+	}
+	failed, _ := doctor.Failed(all)
+	require.Zero(t, failed)
+}
+
+func TestRunGenTxChainID(t *testing.T) {
+	opts, _ := newOptions(t)
+
+	// the chain id changes after the gentx is signed
+	genFile := opts.Config.GenesisFile()
+	appGenesis, err := genutiltypes.AppGenesisFromFile(genFile)
+	require.NoError(t, err)
+	appGenesis.ChainID = "mirror-vault-other"
+	require.NoError(t, appGenesis.SaveAs(genFile))
+
+	all, err := doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	gentx := findings(t, all, "gentx")[0]
+	require.Equal(t, doctor.StatusFail, gentx.Status)
+	require.Contains(t, gentx.Message, "gentx of alice isn't signed for chain id mirror-vault-other")
+	require.False(t, gentx.Fixable)
+}
+
+func TestRunEVMChainID(t *testing.T) {
+	opts, _ := newOptions(t)
+
+	// a localnet runs under any EVM chain id
+	opts.AppConfig.EVM.EVMChainID = 31337
+	all, err := doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.Equal(t, doctor.StatusOK, findings(t, all, "evm-chain-id")[0].Status)
+
+	opts.Profile = network.MustGet(network.Testnet)
+	all, err = doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	evmChainID := findings(t, all, "evm-chain-id")[0]
+	require.Equal(t, doctor.StatusFail, evmChainID.Status)
+	require.True(t, evmChainID.Fixable)
+}
+
+func TestRunFix(t *testing.T) {
+	opts, kr := newOptions(t)
+
+	// the validator state is lost
+	require.NoError(t, os.Remove(opts.Config.PrivValidatorStateFile()))
+	// another chain serves its RPC on the port of the node
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":-1,"result":{"node_info":{"network":"evmbridge_9000-1","version":"0.37.4","moniker":"evmbridge-node"}}}`))
+	}))
+	defer other.Close()
+	opts.Config.RPC.ListenAddress = "tcp://" + other.Listener.Addr().String()
+	// a key isn't an eth_secp256k1 one
+	_, err := kr.NewAccount("legacy", "test test test test test test test test test test test junk", "", pairs.HDPath(0), hd.Secp256k1)
+	require.NoError(t, err)
+	opts.AppConfig.MinGasPrices = ""
+
+	all, err := doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.Equal(t, doctor.StatusFail, findings(t, all, "files")[0].Status)
+	ports := findings(t, all, "ports")[0]
+	require.Equal(t, doctor.StatusFail, ports.Status)
+	require.Contains(t, ports.Message, "chain evmbridge_9000-1 (moniker evmbridge-node)")
+	require.Equal(t, doctor.StatusWarn, findings(t, all, "keyring")[0].Status)
+	failed, fixable := doctor.Failed(all)
+	require.Equal(t, 3, failed)
+	require.Equal(t, 3, fixable)
+
+	written := 0
+	opts.Fix = true
+	opts.WriteConfig = func() error {
+		written++
+		return nil
+	}
+	all, err = doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	failed, _ = doctor.Failed(all)
+	require.Zero(t, failed)
+	require.Equal(t, 1, written)
+
+	_, err = os.Stat(opts.Config.PrivValidatorStateFile())
+	require.NoError(t, err)
+	require.Equal(t, opts.Profile.MinGasPrices, opts.AppConfig.MinGasPrices)
+	require.NotEqual(t, "tcp://"+other.Listener.Addr().String(), opts.Config.RPC.ListenAddress)
+	// keys are not rewritten
+	require.Equal(t, doctor.StatusWarn, findings(t, all, "keyring")[0].Status)
+}
+
+func TestRunValidatorStateWithBlocks(t *testing.T) {
+	opts, _ := newOptions(t)
+	require.NoError(t, os.Remove(opts.Config.PrivValidatorStateFile()))
+
+	// the node stored blocks, which the validator may have signed
+	db, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: opts.Config})
+	require.NoError(t, err)
+	store.SaveBlockStoreState(&cmtstore.BlockStoreState{Base: 1, Height: 10}, db)
+	require.NoError(t, db.Close())
+
+	// a localnet has nothing to lose
+	all, err := doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.True(t, findings(t, all, "files")[0].Fixable)
+
+	opts.Profile = network.MustGet(network.Testnet)
+	all, err = doctor.Run(context.Background(), opts)
+	require.NoError(t, err)
+	files := findings(t, all, "files")[0]
+	require.Equal(t, doctor.StatusFail, files.Status)
+	require.Contains(t, files.Message, "blocks up to height 10")
+	require.False(t, files.Fixable)
+}
@@ -0,0 +1,264 @@
+package doctor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/store"
+	"google.golang.org/protobuf/types/known/anypb"
+
+	txsigning "cosmossdk.io/x/tx/signing"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"mirrorvault/app"
+	"mirrorvault/network"
+)
+
+// checkFiles checks the home has the files CometBFT loads at start.
+func (d *doctor) checkFiles(context.Context) []Finding {
+	cfg := d.opts.Config
+	var findings []Finding
+	for _, file := range []string{cfg.GenesisFile(), cfg.NodeKeyFile(), cfg.PrivValidatorKeyFile()} {
+		if _, err := os.Stat(file); err != nil {
+			findings = append(findings, Finding{
+				Check:   "files",
+				Status:  StatusFail,
+				Message: fmt.Sprintf("%s is missing", file),
+				Hint:    fmt.Sprintf("initialize the home with %s init or %s localnet, or copy the file from a backup", app.Name+"d", app.Name+"d"),
+			})
+		}
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+
+	// the validator signs from height 0 when it has no state, which only a
+	// new validator or one moved to a new chain can
+	stateFile := cfg.PrivValidatorStateFile()
+	if _, err := os.Stat(stateFile); err != nil {
+		f := Finding{
+			Check:   "files",
+			Status:  StatusFail,
+			Message: fmt.Sprintf("%s is missing, CometBFT doesn't start without it", stateFile),
+			Hint:    "restore it from a backup, or create it with the height 0 state if the validator never signed blocks of this chain: otherwise it would double sign",
+		}
+		// a node without blocks hasn't signed any, and a localnet has no
+		// stake to lose to a double sign
+		height, err := blockStoreHeight(cfg)
+		if err != nil {
+			f.Message += fmt.Sprintf(", and its block store can't be read: %s", err)
+			return []Finding{f}
+		}
+		if height > 0 && d.opts.Profile.Name != network.Localnet {
+			f.Message += fmt.Sprintf(", and the node has blocks up to height %d", height)
+			return []Finding{f}
+		}
+
+		f.Hint = "create it with the height 0 state"
+		f.fix = func() (string, error) {
+			bz, err := cmtjson.MarshalIndent(privval.FilePVLastSignState{}, "", "  ")
+			if err != nil {
+				return "", err
+			}
+			if err := os.MkdirAll(filepath.Dir(stateFile), 0o700); err != nil {
+				return "", err
+			}
+			if err := os.WriteFile(stateFile, bz, 0o600); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("created %s at height 0", stateFile), nil
+		}
+		return []Finding{f}
+	}
+
+	return []Finding{{Check: "files", Status: StatusOK, Message: "genesis, node key and validator key and state are present"}}
+}
+
+// blockStoreHeight returns the height of the last block the node stored, 0
+// when it has no block store.
+func blockStoreHeight(cfg *cmtcfg.Config) (int64, error) {
+	if _, err := os.Stat(filepath.Join(cfg.DBDir(), "blockstore.db")); errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+
+	db, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: cfg})
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	return store.LoadBlockStoreState(db).Height, nil
+}
+
+// checkGenesis checks the genesis is valid for the modules of the binary,
+// its denoms and chain id, and that its gentxs are signed for its chain id.
+func (d *doctor) checkGenesis(ctx context.Context) []Finding {
+	genFile := d.opts.Config.GenesisFile()
+	if _, err := os.Stat(genFile); err != nil {
+		// reported by checkFiles
+		return nil
+	}
+
+	fail := func(format string, a ...any) Finding {
+		return Finding{Check: "genesis", Status: StatusFail, Message: fmt.Sprintf(format, a...)}
+	}
+
+	appGenesis, err := genutiltypes.AppGenesisFromFile(genFile)
+	if err != nil {
+		return []Finding{fail("%s", err)}
+	}
+	d.chainID = appGenesis.ChainID
+
+	var genState map[string]json.RawMessage
+	if err := json.Unmarshal(appGenesis.AppState, &genState); err != nil {
+		return []Finding{fail("app state of %s: %s", genFile, err)}
+	}
+
+	// the modules with a genesis are the ones of the default genesis
+	defaultGenState := d.opts.BasicManager.DefaultGenesis(d.opts.Codec)
+	var unknown, missing []string
+	for name := range genState {
+		if _, ok := defaultGenState[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	for name := range defaultGenState {
+		if _, ok := genState[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(unknown)
+	sort.Strings(missing)
+	if len(unknown) > 0 {
+		f := fail("genesis has the state of modules the binary doesn't run: %s", strings.Join(unknown, ", "))
+		f.Hint = fmt.Sprintf("the genesis is made for another chain, create it with %s", app.Name+"d")
+		return []Finding{f}
+	}
+	if len(missing) > 0 {
+		f := fail("genesis has no state for the modules %s, which would start without their params", strings.Join(missing, ", "))
+		f.Hint = fmt.Sprintf("copy their state from the genesis of %s init", app.Name+"d")
+		return []Finding{f}
+	}
+
+	if err := d.opts.BasicManager.ValidateGenesis(d.opts.Codec, d.opts.TxConfig, genState); err != nil {
+		return []Finding{fail("%s", err)}
+	}
+	if err := app.ValidateGenesisDenoms(d.opts.Codec, genState); err != nil {
+		return []Finding{fail("%s", err)}
+	}
+	if err := d.opts.Profile.CheckChainID(appGenesis.ChainID); err != nil {
+		f := fail("%s", err)
+		f.Hint = "select the network of the genesis with --network or the network key of app.toml"
+		return []Finding{f}
+	}
+
+	findings := []Finding{{
+		Check:   "genesis",
+		Status:  StatusOK,
+		Message: fmt.Sprintf("genesis of chain %s is valid for the modules and denoms of the binary", appGenesis.ChainID),
+	}}
+
+	return append(findings, d.checkGenTxs(ctx, appGenesis.ChainID, genState)...)
+}
+
+// checkGenTxs checks the gentxs of genState are signed for chainID: their
+// signature no longer verifies once the chain id of the genesis changes.
+func (d *doctor) checkGenTxs(ctx context.Context, chainID string, genState map[string]json.RawMessage) []Finding {
+	genTxs := genutiltypes.GetGenesisStateFromAppState(d.opts.Codec, genState).GenTxs
+	if len(genTxs) == 0 {
+		// an exported genesis has its validators in the staking state
+		return nil
+	}
+
+	var findings []Finding
+	for i, bz := range genTxs {
+		tx, err := d.opts.TxConfig.TxJSONDecoder()(bz)
+		if err != nil {
+			return []Finding{{Check: "gentx", Status: StatusFail, Message: fmt.Sprintf("gentx %d: %s", i, err)}}
+		}
+
+		name := fmt.Sprintf("gentx %d", i)
+		for _, msg := range tx.GetMsgs() {
+			if msg, ok := msg.(*stakingtypes.MsgCreateValidator); ok {
+				name = fmt.Sprintf("gentx of %s", msg.Description.Moniker)
+			}
+		}
+		if err := d.verifyGenTx(ctx, tx, chainID); err != nil {
+			findings = append(findings, Finding{
+				Check:   "gentx",
+				Status:  StatusFail,
+				Message: fmt.Sprintf("%s isn't signed for chain id %s: %s", name, chainID, err),
+				Hint:    "the chain id of the genesis changed after the gentx was signed: sign it again with genesis gentx and run genesis collect-gentxs",
+			})
+		}
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+
+	return []Finding{{Check: "gentx", Status: StatusOK, Message: fmt.Sprintf("%d gentxs are signed for chain id %s", len(genTxs), chainID)}}
+}
+
+// verifyGenTx verifies the signatures of tx as InitChain does: at account
+// number 0 and with the chain id of the genesis.
+func (d *doctor) verifyGenTx(ctx context.Context, tx sdk.Tx, chainID string) error {
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return fmt.Errorf("unexpected tx %T", tx)
+	}
+	adaptableTx, ok := tx.(authsigning.V2AdaptableTx)
+	if !ok {
+		return fmt.Errorf("unexpected tx %T", tx)
+	}
+
+	signers, err := sigTx.GetSigners()
+	if err != nil {
+		return err
+	}
+	pubKeys, err := sigTx.GetPubKeys()
+	if err != nil {
+		return err
+	}
+	sigs, err := sigTx.GetSignaturesV2()
+	if err != nil {
+		return err
+	}
+	if len(sigs) != len(signers) || len(pubKeys) != len(signers) {
+		return fmt.Errorf("%d signers, %d public keys and %d signatures", len(signers), len(pubKeys), len(sigs))
+	}
+
+	for i, sig := range sigs {
+		if pubKeys[i] == nil {
+			return errors.New("no public key")
+		}
+		anyPk, err := codectypes.NewAnyWithValue(pubKeys[i])
+		if err != nil {
+			return err
+		}
+		signerData := txsigning.SignerData{
+			Address:  sdk.AccAddress(signers[i]).String(),
+			ChainID:  chainID,
+			Sequence: sig.Sequence,
+			PubKey:   &anypb.Any{TypeUrl: anyPk.TypeUrl, Value: anyPk.Value},
+		}
+		if err := authsigning.VerifySignature(ctx, pubKeys[i], signerData, sig.Data, d.opts.TxConfig.SignModeHandler(), adaptableTx.GetSigningTxData()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
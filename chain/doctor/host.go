@@ -0,0 +1,114 @@
+package doctor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+
+	"mirrorvault/app"
+)
+
+// buildMemory is the memory go build of the binary needs, in bytes.
+const buildMemory = 4 << 30
+
+// checkKeyring checks the keys of the keyring are eth_secp256k1 keys, whose
+// address is the one wallets derive from the same mnemonic.
+func (d *doctor) checkKeyring(context.Context) []Finding {
+	kr := d.opts.Keyring
+	if kr == nil {
+		return nil
+	}
+	if kr.Backend() == keyring.BackendFile {
+		return []Finding{{
+			Check:   "keyring",
+			Status:  StatusWarn,
+			Message: "the file keyring asks for its passphrase, its keys are not checked",
+			Hint:    "pass --keyring-backend with the backend of the keys to check",
+		}}
+	}
+
+	records, err := kr.List()
+	if err != nil {
+		return []Finding{{Check: "keyring", Status: StatusWarn, Message: fmt.Sprintf("%s keyring: %s", kr.Backend(), err)}}
+	}
+
+	var findings []Finding
+	for _, record := range records {
+		// multisig and offline keys have no algorithm of their own
+		if record.GetLocal() == nil && record.GetLedger() == nil {
+			continue
+		}
+		pubKey, err := record.GetPubKey()
+		if err != nil {
+			findings = append(findings, Finding{Check: "keyring", Status: StatusWarn, Message: fmt.Sprintf("key %s: %s", record.Name, err)})
+			continue
+		}
+		if _, ok := pubKey.(*ethsecp256k1.PubKey); ok {
+			continue
+		}
+		findings = append(findings, Finding{
+			Check:   "keyring",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("key %s is a %s key: its address isn't the one MetaMask derives from its mnemonic", record.Name, pubKey.Type()),
+			Hint:    fmt.Sprintf("recover its mnemonic under a new name with %s keys add <name> --recover, whose keys are eth_secp256k1", app.Name+"d"),
+		})
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+
+	return []Finding{{Check: "keyring", Status: StatusOK, Message: fmt.Sprintf("%d keys of the %s keyring are eth_secp256k1 keys", len(records), kr.Backend())}}
+}
+
+// checkHost checks the host has the memory to build the binary, the go
+// build of which gets killed for lack of memory on small WSL2 machines.
+func (d *doctor) checkHost(context.Context) []Finding {
+	if runtime.GOOS != "linux" {
+		return nil
+	}
+	total, err := memTotal()
+	if err != nil {
+		return nil
+	}
+	if total < buildMemory {
+		return []Finding{{
+			Check:   "memory",
+			Status:  StatusWarn,
+			Message: fmt.Sprintf("the host has %d MiB of memory, building %s takes about %d MiB", total>>20, app.Name+"d", buildMemory>>20),
+			Hint:    "build with tools/chain-build-safe.sh, which limits the go build memory, or give WSL2 more memory in .wslconfig",
+		}}
+	}
+
+	return []Finding{{Check: "memory", Status: StatusOK, Message: fmt.Sprintf("the host has %d MiB of memory", total>>20)}}
+}
+
+// memTotal returns the memory of the host from /proc/meminfo, in bytes.
+func memTotal() (uint64, error) {
+	f, err := os.Open("/proc/meminfo")
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "MemTotal:" {
+			kb, err := strconv.ParseUint(fields[1], 10, 64)
+			return kb << 10, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	return 0, fmt.Errorf("no MemTotal in /proc/meminfo")
+}
@@ -0,0 +1,266 @@
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"mirrorvault/network"
+)
+
+// probeTimeout bounds the queries to the process holding a port.
+const probeTimeout = 2 * time.Second
+
+// server is a server of the node.
+type server struct {
+	name, addr string
+}
+
+// holder describes the node holding a port.
+type holder struct {
+	// ChainID is the Cosmos chain id of the node, EVMChainID the EIP-155
+	// one, whichever its server tells.
+	ChainID    string
+	EVMChainID uint64
+	// Description is what the node tells about itself.
+	Description string
+}
+
+// checkPorts checks the ports of the servers of the node are free. The
+// process holding one is asked who it is, as a CometBFT RPC, REST or
+// JSON-RPC server.
+func (d *doctor) checkPorts(ctx context.Context) []Finding {
+	cfg, appConfig := d.opts.Config, d.opts.AppConfig
+
+	servers := []server{
+		{"CometBFT P2P", cfg.P2P.ListenAddress},
+		{"CometBFT RPC", cfg.RPC.ListenAddress},
+	}
+	if appConfig.API.Enable {
+		servers = append(servers, server{"REST", appConfig.API.Address})
+	}
+	if appConfig.GRPC.Enable {
+		servers = append(servers, server{"gRPC", appConfig.GRPC.Address})
+	}
+	if appConfig.JSONRPC.Enable {
+		servers = append(servers, server{"JSON-RPC", appConfig.JSONRPC.Address}, server{"JSON-RPC WebSocket", appConfig.JSONRPC.WsAddress})
+	}
+
+	var (
+		findings []Finding
+		moved    string
+	)
+	// moving the ports fixes all the conflicts at once
+	movePorts := func() (string, error) {
+		if moved != "" {
+			return moved, nil
+		}
+		offset, err := d.freePortOffset()
+		if err != nil {
+			return "", err
+		}
+		ports := d.opts.Profile.Ports.Offset(offset)
+		ports.ApplyComet(cfg)
+		ports.ApplyApp(appConfig)
+		d.configChanged = true
+		moved = fmt.Sprintf("moved the ports by %d: CometBFT RPC %d, REST %d, gRPC %d, JSON-RPC %d", offset, ports.RPC, ports.API, ports.GRPC, ports.JSONRPC)
+		return moved, nil
+	}
+
+	for _, srv := range servers {
+		host, port, err := splitAddress(srv.addr)
+		if err != nil {
+			findings = append(findings, Finding{Check: "ports", Status: StatusFail, Message: fmt.Sprintf("%s address %q: %s", srv.name, srv.addr, err)})
+			continue
+		}
+		if listenable(host, port) {
+			continue
+		}
+
+		h, ok := d.probe(ctx, port)
+		switch {
+		case ok && ((h.ChainID != "" && h.ChainID == d.chainID) || h.EVMChainID == appConfig.EVM.EVMChainID):
+			findings = append(findings, Finding{
+				Check:   "ports",
+				Status:  StatusWarn,
+				Message: fmt.Sprintf("%s port %s is held by a node of this chain, %s", srv.name, port, h.Description),
+				Hint:    "stop it before starting this home, unless it is this node",
+			})
+		default:
+			f := Finding{
+				Check:   "ports",
+				Status:  StatusFail,
+				Message: fmt.Sprintf("%s port %s is held by another process", srv.name, port),
+				Hint:    "stop the process or move the ports of the node in config.toml and app.toml",
+			}
+			if ok {
+				f.Message = fmt.Sprintf("%s port %s is held by another chain, %s", srv.name, port, h.Description)
+			}
+			if d.opts.Profile.Name == network.Localnet {
+				f.Hint = "stop the process or move the ports of the node, as localnet --port-offset does"
+				f.fix = movePorts
+			}
+			findings = append(findings, f)
+		}
+	}
+	if len(findings) > 0 {
+		return findings
+	}
+
+	names := make([]string, len(servers))
+	for i, srv := range servers {
+		_, port, _ := splitAddress(srv.addr)
+		names[i] = srv.name + " " + port
+	}
+	return []Finding{{Check: "ports", Status: StatusOK, Message: "ports are free: " + strings.Join(names, ", ")}}
+}
+
+// freePortOffset returns the smallest multiple of 100 shifting all the
+// ports of the profile to free ones.
+func (d *doctor) freePortOffset() (int, error) {
+	for offset := 100; offset <= 10000; offset += 100 {
+		p := d.opts.Profile.Ports.Offset(offset)
+		free := true
+		for _, port := range []int{p.P2P, p.RPC, p.ABCI, p.Pprof, p.Prometheus, p.GRPC, p.API, p.JSONRPC, p.JSONRPCWS, p.JSONRPCMetrics, p.GethMetrics} {
+			if !listenable("127.0.0.1", strconv.Itoa(port)) {
+				free = false
+				break
+			}
+		}
+		if free {
+			return offset, nil
+		}
+	}
+	return 0, errors.New("no free ports")
+}
+
+// probe asks the process holding port on the local host who it is.
+func (d *doctor) probe(ctx context.Context, port string) (holder, bool) {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	base := "http://" + net.JoinHostPort("127.0.0.1", port)
+
+	// CometBFT RPC
+	var status struct {
+		Result struct {
+			NodeInfo struct {
+				Network string `json:"network"`
+				Version string `json:"version"`
+				Moniker string `json:"moniker"`
+			} `json:"node_info"`
+		} `json:"result"`
+	}
+	if d.getJSON(ctx, base+"/status", &status) == nil && status.Result.NodeInfo.Network != "" {
+		info := status.Result.NodeInfo
+		return holder{
+			ChainID:     info.Network,
+			Description: fmt.Sprintf("CometBFT %s RPC of chain %s (moniker %s)", info.Version, info.Network, info.Moniker),
+		}, true
+	}
+
+	// REST
+	var nodeInfo struct {
+		DefaultNodeInfo struct {
+			Network string `json:"network"`
+			Moniker string `json:"moniker"`
+		} `json:"default_node_info"`
+		ApplicationVersion struct {
+			AppName string `json:"app_name"`
+			Version string `json:"version"`
+		} `json:"application_version"`
+	}
+	if d.getJSON(ctx, base+"/cosmos/base/tendermint/v1beta1/node_info", &nodeInfo) == nil && nodeInfo.DefaultNodeInfo.Network != "" {
+		return holder{
+			ChainID: nodeInfo.DefaultNodeInfo.Network,
+			Description: fmt.Sprintf("%s %s REST of chain %s (moniker %s)", nodeInfo.ApplicationVersion.AppName, nodeInfo.ApplicationVersion.Version,
+				nodeInfo.DefaultNodeInfo.Network, nodeInfo.DefaultNodeInfo.Moniker),
+		}, true
+	}
+
+	// JSON-RPC
+	var chainID hexutil.Big
+	if d.callJSONRPC(ctx, base, "eth_chainId", &chainID) == nil {
+		var version string
+		_ = d.callJSONRPC(ctx, base, "web3_clientVersion", &version)
+		id := (*big.Int)(&chainID)
+		return holder{
+			EVMChainID:  id.Uint64(),
+			Description: strings.TrimSpace(fmt.Sprintf("JSON-RPC of EVM chain %s %s", id, version)),
+		}, true
+	}
+
+	return holder{}, false
+}
+
+// getJSON decodes the JSON answer of a GET of target.
+func (d *doctor) getJSON(ctx context.Context, target string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	return d.do(req, v)
+}
+
+// callJSONRPC decodes the result of a JSON-RPC call without params.
+func (d *doctor) callJSONRPC(ctx context.Context, target, method string, result any) error {
+	body := fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":%q,"params":[]}`, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewBufferString(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var rsp struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err := d.do(req, &rsp); err != nil {
+		return err
+	}
+	if len(rsp.Result) == 0 {
+		return fmt.Errorf("%s: no result", method)
+	}
+	return json.Unmarshal(rsp.Result, result)
+}
+
+func (d *doctor) do(req *http.Request, v any) error {
+	rsp, err := d.opts.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: %s", req.URL, rsp.Status)
+	}
+	return json.NewDecoder(rsp.Body).Decode(v)
+}
+
+// splitAddress returns the host and port of a host:port or
+// scheme://host:port address.
+func splitAddress(addr string) (string, string, error) {
+	if u, err := url.Parse(addr); err == nil && u.Host != "" {
+		addr = u.Host
+	}
+	return net.SplitHostPort(addr)
+}
+
+// listenable tells whether a server can listen on host:port.
+func listenable(host, port string) bool {
+	l, err := net.Listen("tcp", net.JoinHostPort(host, port))
+	if err != nil {
+		return false
+	}
+	_ = l.Close()
+	return true
+}
@@ -0,0 +1,113 @@
+package eip712
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	evmeip712 "github.com/cosmos/evm/ethereum/eip712"
+)
+
+// SignMode is the value of the --sign-mode flag that signs Cosmos txs with an
+// EIP-712 typed-data signature. Such txs carry SIGN_MODE_LEGACY_AMINO_JSON on
+// chain; the eth_secp256k1 public key recognises the EIP-712 form when it
+// verifies the signature.
+const SignMode = "eip712"
+
+// TypedData returns the EIP-712 typed data for a legacy amino JSON sign doc,
+// i.e. the object a wallet is asked to sign with eth_signTypedData_v4. The
+// domain carries the EVM chain ID set with SetEncodingConfig.
+//
+// Any message registered with the legacy amino codec is supported, which
+// covers the bank and staking messages.
+func TypedData(signDoc []byte) (apitypes.TypedData, error) {
+	return evmeip712.GetEIP712TypedDataForMsg(signDoc)
+}
+
+// Hash returns the EIP-712 digest of a legacy amino JSON sign doc.
+func Hash(signDoc []byte) ([]byte, error) {
+	typedData, err := TypedData(signDoc)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, _, err := apitypes.TypedDataAndHash(typedData)
+	if err != nil {
+		return nil, fmt.Errorf("could not hash EIP-712 typed data: %w", err)
+	}
+
+	return hash, nil
+}
+
+var _ keyring.Keyring = Keyring{}
+
+// Keyring wraps a keyring so that SIGN_MODE_LEGACY_AMINO_JSON sign docs are
+// signed as EIP-712 typed data. All other sign modes are passed through.
+type Keyring struct {
+	keyring.Keyring
+}
+
+// NewKeyring returns kr wrapped for EIP-712 signing.
+func NewKeyring(kr keyring.Keyring) Keyring {
+	return Keyring{Keyring: kr}
+}
+
+// Sign implements keyring.Keyring.
+func (k Keyring) Sign(uid string, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	if signMode != signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+		return k.Keyring.Sign(uid, msg, signMode)
+	}
+
+	record, err := k.Key(uid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bz, err := typedDataBytes(record, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return k.Keyring.Sign(uid, bz, signMode)
+}
+
+// SignByAddress implements keyring.Keyring.
+func (k Keyring) SignByAddress(address sdk.Address, msg []byte, signMode signing.SignMode) ([]byte, cryptotypes.PubKey, error) {
+	if signMode != signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+		return k.Keyring.SignByAddress(address, msg, signMode)
+	}
+
+	record, err := k.KeyByAddress(address)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bz, err := typedDataBytes(record, msg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return k.Keyring.SignByAddress(address, bz, signMode)
+}
+
+// typedDataBytes returns the "\x19\x01" || domainSeparator || hashStruct
+// preimage of the sign doc. The eth_secp256k1 key hashes it with Keccak256
+// before signing, which yields the EIP-712 digest.
+func typedDataBytes(record *keyring.Record, signDoc []byte) ([]byte, error) {
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := pubKey.(*ethsecp256k1.PubKey); !ok {
+		return nil, fmt.Errorf("EIP-712 signing requires an %s key, %s is %s", ethsecp256k1.KeyType, record.Name, pubKey.Type())
+	}
+
+	return evmeip712.GetEIP712BytesForMsg(signDoc)
+}
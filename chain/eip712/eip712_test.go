@@ -0,0 +1,278 @@
+package eip712_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/hex"
+	"math/big"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/depinject"
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	"github.com/cosmos/evm/crypto/hd"
+	evmkeyring "github.com/cosmos/evm/crypto/keyring"
+	evmeip712 "github.com/cosmos/evm/ethereum/eip712"
+
+	"mirrorvault/app"
+	"mirrorvault/eip712"
+	"mirrorvault/network"
+)
+
+const chainID = "mirror-vault-localnet"
+
+type fixture struct {
+	txConfig          client.TxConfig
+	codec             codec.Codec
+	legacyAmino       *codec.LegacyAmino
+	interfaceRegistry codectypes.InterfaceRegistry
+}
+
+func newFixture(t *testing.T) fixture {
+	t.Helper()
+
+	var f fixture
+	require.NoError(t, depinject.Inject(
+		depinject.Configs(app.AppConfig(), depinject.Supply(log.NewNopLogger())),
+		&f.txConfig,
+		&f.codec,
+		&f.legacyAmino,
+		&f.interfaceRegistry,
+	))
+	evmeip712.SetEncodingConfig(f.legacyAmino, f.interfaceRegistry, network.MustGet(network.Localnet).EVMChainID)
+
+	return f
+}
+
+// signDoc builds a tx carrying msg for the given signer and returns its
+// SIGN_MODE_LEGACY_AMINO_JSON sign bytes.
+func (f fixture) signDoc(t *testing.T, pubKey *ethsecp256k1.PubKey, msg sdk.Msg) []byte {
+	t.Helper()
+
+	txBuilder := f.txConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(msg))
+	txBuilder.SetGasLimit(200000)
+	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("umvlt", 2000)))
+	txBuilder.SetMemo("eip712")
+	require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   pubKey,
+		Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON},
+		Sequence: 3,
+	}))
+
+	signBytes, err := authsigning.GetSignBytesAdapter(
+		context.Background(),
+		f.txConfig.SignModeHandler(),
+		signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+		authsigning.SignerData{
+			Address:       sdk.AccAddress(pubKey.Address()).String(),
+			ChainID:       chainID,
+			AccountNumber: 7,
+			Sequence:      3,
+			PubKey:        pubKey,
+		},
+		txBuilder.GetTx(),
+	)
+	require.NoError(t, err)
+
+	return signBytes
+}
+
+// metaMaskSign signs the EIP-712 digest of signDoc with a raw secp256k1 key,
+// which is what eth_signTypedData_v4 does.
+func metaMaskSign(t *testing.T, key *ecdsa.PrivateKey, signDoc []byte) []byte {
+	t.Helper()
+
+	hash, err := eip712.Hash(signDoc)
+	require.NoError(t, err)
+
+	sig, err := crypto.Sign(hash, key)
+	require.NoError(t, err)
+
+	return sig
+}
+
+func TestMetaMaskSignedCosmosTx(t *testing.T) {
+	f := newFixture(t)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pubKey := &ethsecp256k1.PubKey{Key: crypto.CompressPubkey(&key.PublicKey)}
+	from := sdk.AccAddress(pubKey.Address())
+	to := sdk.AccAddress(crypto.Keccak256([]byte("bob"))[:20])
+
+	msgs := map[string]sdk.Msg{
+		"bank send": banktypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("umvlt", 12345))),
+		"staking delegate": stakingtypes.NewMsgDelegate(
+			from.String(),
+			sdk.ValAddress(from).String(),
+			sdk.NewCoin("umvlt", math.NewInt(1000000)),
+		),
+	}
+
+	for name, msg := range msgs {
+		t.Run(name, func(t *testing.T) {
+			signDoc := f.signDoc(t, pubKey, msg)
+
+			typedData, err := eip712.TypedData(signDoc)
+			require.NoError(t, err)
+			require.Equal(t, int64(network.MustGet(network.Localnet).EVMChainID), (*big.Int)(typedData.Domain.ChainId).Int64())
+
+			sig := metaMaskSign(t, key, signDoc)
+			require.True(t, pubKey.VerifySignature(signDoc, sig))
+
+			// the signature must not verify for a different sign doc
+			require.False(t, pubKey.VerifySignature(f.signDoc(t, pubKey, banktypes.NewMsgSend(from, to, sdk.NewCoins(sdk.NewInt64Coin("umvlt", 1)))), sig))
+		})
+	}
+}
+
+func TestKeyringSignsTypedData(t *testing.T) {
+	f := newFixture(t)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pubKey := &ethsecp256k1.PubKey{Key: crypto.CompressPubkey(&key.PublicKey)}
+
+	kr := keyring.NewInMemory(f.codec, evmkeyring.Option())
+	require.NoError(t, kr.ImportPrivKeyHex("alice", hex.EncodeToString(crypto.FromECDSA(key)), string(hd.EthSecp256k1Type)))
+
+	from := sdk.AccAddress(pubKey.Address())
+	signDoc := f.signDoc(t, pubKey, banktypes.NewMsgSend(from, from, sdk.NewCoins(sdk.NewInt64Coin("umvlt", 1))))
+
+	sig, _, err := eip712.NewKeyring(kr).Sign("alice", signDoc, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	require.NoError(t, err)
+	require.Equal(t, metaMaskSign(t, key, signDoc), sig)
+	require.True(t, pubKey.VerifySignature(signDoc, sig))
+}
+
+func TestWrongDomainIsRejected(t *testing.T) {
+	f := newFixture(t)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pubKey := &ethsecp256k1.PubKey{Key: crypto.CompressPubkey(&key.PublicKey)}
+	from := sdk.AccAddress(pubKey.Address())
+	signDoc := f.signDoc(t, pubKey, banktypes.NewMsgSend(from, from, sdk.NewCoins(sdk.NewInt64Coin("umvlt", 1))))
+
+	// sign for another EVM chain id, then verify against ours
+	evmeip712.SetEncodingConfig(f.legacyAmino, f.interfaceRegistry, 1)
+	sig := metaMaskSign(t, key, signDoc)
+	evmeip712.SetEncodingConfig(f.legacyAmino, f.interfaceRegistry, network.MustGet(network.Localnet).EVMChainID)
+
+	require.False(t, pubKey.VerifySignature(signDoc, sig))
+}
+
+// TestDeliverMetaMaskSignedTx delivers Cosmos txs signed as MetaMask signs
+// EIP-712 typed data to an in-memory app, through its ante handler. The EVM
+// global config allows a single app per process, so the other tests don't
+// run one.
+func TestDeliverMetaMaskSignedTx(t *testing.T) {
+	a, err := app.NewInMemory(log.NewNopLogger(), simtestutil.AppOptionsMap{})
+	require.NoError(t, err)
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	pubKey := &ethsecp256k1.PubKey{Key: crypto.CompressPubkey(&key.PublicKey)}
+	sender := sdk.AccAddress(pubKey.Address())
+	to := sdk.AccAddress(crypto.Keccak256([]byte("bob"))[:20])
+
+	// fund the key from the validator operator
+	ctx := a.NewUncachedContext(false, cmtproto.Header{Height: 1})
+	vals, err := a.StakingKeeper.GetAllValidators(ctx)
+	require.NoError(t, err)
+	valAddr, err := sdk.ValAddressFromBech32(vals[0].OperatorAddress)
+	require.NoError(t, err)
+	require.NoError(t, a.BankKeeper.SendCoins(ctx, sdk.AccAddress(valAddr), sender, sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 1_000_000))))
+	accNum := a.AuthKeeper.GetAccount(ctx, sender).GetAccountNumber()
+	baseFee := a.FeeMarketKeeper.GetBaseFee(ctx)
+
+	// newTx returns a tx sending amount to bob, with the MetaMask signature
+	// of the typed data of a tx sending signed instead
+	const gasLimit = 200_000
+	newTx := func(sequence uint64, amount, signed int64) []byte {
+		build := func(amount int64) client.TxBuilder {
+			txBuilder := a.TxConfig().NewTxBuilder()
+			require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(sender, to, sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, amount)))))
+			txBuilder.SetGasLimit(gasLimit)
+			txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewCoin(app.BaseDenom, baseFee.MulInt64(2*gasLimit).Ceil().TruncateInt())))
+			require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+				PubKey:   pubKey,
+				Data:     &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON},
+				Sequence: sequence,
+			}))
+			return txBuilder
+		}
+
+		signDoc, err := authsigning.GetSignBytesAdapter(
+			context.Background(),
+			a.TxConfig().SignModeHandler(),
+			signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+			authsigning.SignerData{
+				Address:       sender.String(),
+				ChainID:       app.InMemoryChainID,
+				AccountNumber: accNum,
+				Sequence:      sequence,
+				PubKey:        pubKey,
+			},
+			build(signed).GetTx(),
+		)
+		require.NoError(t, err)
+
+		txBuilder := build(amount)
+		require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+			PubKey: pubKey,
+			Data: &signing.SingleSignatureData{
+				SignMode:  signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+				Signature: metaMaskSign(t, key, signDoc),
+			},
+			Sequence: sequence,
+		}))
+		txBytes, err := a.TxConfig().TxEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+		return txBytes
+	}
+
+	res, err := a.FinalizeBlock(&abci.RequestFinalizeBlock{
+		Height: 2,
+		Time:   time.Now(),
+		Txs: [][]byte{
+			newTx(0, 12345, 12345),
+			// the typed data signed sends less than the tx
+			newTx(1, 54321, 1),
+		},
+	})
+	require.NoError(t, err)
+	_, err = a.Commit()
+	require.NoError(t, err)
+
+	delivered, tampered := res.TxResults[0], res.TxResults[1]
+	require.Zero(t, delivered.Code, delivered.Log)
+	require.Equal(t, errortypes.ErrUnauthorized.ABCICode(), tampered.Code, tampered.Log)
+
+	ctx = a.NewUncachedContext(false, cmtproto.Header{Height: 2})
+	require.Equal(t, int64(12345), a.BankKeeper.GetBalance(ctx, to, app.BaseDenom).Amount.Int64())
+	sequence, err := a.AuthKeeper.GetSequence(ctx, sender)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), sequence)
+}
@@ -0,0 +1,198 @@
+package ethkeystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	evmhd "github.com/cosmos/evm/crypto/hd"
+)
+
+// Supported key derivation functions.
+const (
+	KDFScrypt = "scrypt"
+	KDFPBKDF2 = "pbkdf2"
+)
+
+// PBKDF2Iterations is the iteration count of PBKDF2 keystores, the one geth
+// and web3 use.
+const PBKDF2Iterations = 262144
+
+// Options are the encryption parameters of an exported keystore.
+type Options struct {
+	// KDF is KDFScrypt or KDFPBKDF2.
+	KDF string
+	// Light selects the light scrypt parameters, which are quick to decrypt
+	// but weaker. It is ignored for PBKDF2.
+	Light bool
+}
+
+// keyJSON is the V3 keystore document.
+type keyJSON struct {
+	Address string `json:"address"`
+	Crypto  any    `json:"crypto"`
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+}
+
+// cryptoJSON is keystore.CryptoJSON, whose cipher params type is unexported.
+type cryptoJSON struct {
+	Cipher       string           `json:"cipher"`
+	CipherText   string           `json:"ciphertext"`
+	CipherParams cipherParamsJSON `json:"cipherparams"`
+	KDF          string           `json:"kdf"`
+	KDFParams    map[string]any   `json:"kdfparams"`
+	MAC          string           `json:"mac"`
+}
+
+type cipherParamsJSON struct {
+	IV string `json:"iv"`
+}
+
+// Decrypt decrypts a V3 keystore with password. Keystores that carry an
+// address must match the decrypted key.
+func Decrypt(keyjson []byte, password string) (*ethsecp256k1.PrivKey, error) {
+	key, err := keystore.DecryptKey(keyjson, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+	}
+
+	var doc keyJSON
+	if err := json.Unmarshal(keyjson, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Address != "" && !strings.EqualFold(strings.TrimPrefix(doc.Address, "0x"), hex.EncodeToString(key.Address.Bytes())) {
+		return nil, fmt.Errorf("keystore address %s does not match its key %s", doc.Address, key.Address)
+	}
+
+	return &ethsecp256k1.PrivKey{Key: ethcrypto.FromECDSA(key.PrivateKey)}, nil
+}
+
+// Encrypt encrypts an eth_secp256k1 private key into a V3 keystore.
+func Encrypt(privKey *ethsecp256k1.PrivKey, password string, opts Options) ([]byte, error) {
+	ecdsaKey, err := privKey.ToECDSA()
+	if err != nil {
+		return nil, err
+	}
+	address := ethcrypto.PubkeyToAddress(ecdsaKey.PublicKey)
+
+	var cryptoDoc any
+	switch opts.KDF {
+	case KDFScrypt, "":
+		n, p := keystore.StandardScryptN, keystore.StandardScryptP
+		if opts.Light {
+			n, p = keystore.LightScryptN, keystore.LightScryptP
+		}
+		cryptoDoc, err = keystore.EncryptDataV3(privKey.Key, []byte(password), n, p)
+	case KDFPBKDF2:
+		cryptoDoc, err = encryptPBKDF2(privKey.Key, []byte(password))
+	default:
+		return nil, fmt.Errorf("unsupported KDF %q, expected %s or %s", opts.KDF, KDFScrypt, KDFPBKDF2)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(keyJSON{
+		Address: hex.EncodeToString(address.Bytes()),
+		Crypto:  cryptoDoc,
+		ID:      uuid.NewString(),
+		Version: 3,
+	})
+}
+
+// encryptPBKDF2 is keystore.EncryptDataV3 with PBKDF2-HMAC-SHA256 as the KDF,
+// which geth decrypts but does not write.
+func encryptPBKDF2(data, password []byte) (cryptoJSON, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return cryptoJSON{}, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return cryptoJSON{}, err
+	}
+
+	derivedKey, err := pbkdf2.Key(sha256.New, string(password), salt, PBKDF2Iterations, 32)
+	if err != nil {
+		return cryptoJSON{}, err
+	}
+
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return cryptoJSON{}, err
+	}
+	cipherText := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, data)
+
+	return cryptoJSON{
+		Cipher:       "aes-128-ctr",
+		CipherText:   hex.EncodeToString(cipherText),
+		CipherParams: cipherParamsJSON{IV: hex.EncodeToString(iv)},
+		KDF:          KDFPBKDF2,
+		KDFParams: map[string]any{
+			"c":     PBKDF2Iterations,
+			"dklen": 32,
+			"prf":   "hmac-sha256",
+			"salt":  hex.EncodeToString(salt),
+		},
+		MAC: hex.EncodeToString(ethcrypto.Keccak256(derivedKey[16:32], cipherText)),
+	}, nil
+}
+
+// Import decrypts a V3 keystore and stores its key in kr under name as an
+// eth_secp256k1 key.
+func Import(kr keyring.Keyring, name string, keyjson []byte, password string) (*keyring.Record, error) {
+	privKey, err := Decrypt(keyjson, password)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := kr.ImportPrivKeyHex(name, hex.EncodeToString(privKey.Key), string(evmhd.EthSecp256k1Type)); err != nil {
+		return nil, err
+	}
+
+	return kr.Key(name)
+}
+
+// Export encrypts the key stored in kr under name into a V3 keystore. Only
+// eth_secp256k1 keys can be exported: the keystore address is the Keccak256
+// address of the key, which only matches the Cosmos address for those keys.
+func Export(kr keyring.Keyring, name, password string, opts Options) ([]byte, error) {
+	record, err := kr.Key(name)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := pubKey.(*ethsecp256k1.PubKey); !ok {
+		return nil, fmt.Errorf("%s is a %s key, only %s keys can be exported to an Ethereum keystore", name, pubKey.Type(), ethsecp256k1.KeyType)
+	}
+
+	local := record.GetLocal()
+	if local == nil {
+		return nil, fmt.Errorf("%s is a %s key, only local keys can be exported", name, record.GetType())
+	}
+	privKey, ok := local.PrivKey.GetCachedValue().(*ethsecp256k1.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("unexpected private key type %T", local.PrivKey.GetCachedValue())
+	}
+
+	return Encrypt(privKey, password, opts)
+}
@@ -0,0 +1,144 @@
+package ethkeystore_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"testing"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	cryptocodec "github.com/cosmos/cosmos-sdk/crypto/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	evmcryptocodec "github.com/cosmos/evm/crypto/codec"
+	evmhd "github.com/cosmos/evm/crypto/hd"
+
+	"mirrorvault/ethkeystore"
+)
+
+// vector is a geth keystore test vector, see
+// accounts/keystore/testdata/v3_test_vector.json in go-ethereum.
+type vector struct {
+	JSON     json.RawMessage `json:"json"`
+	Password string          `json:"password"`
+	Priv     string          `json:"priv"`
+}
+
+func loadVectors(t *testing.T) map[string]vector {
+	t.Helper()
+
+	bz, err := os.ReadFile("testdata/v3_test_vector.json")
+	require.NoError(t, err)
+
+	var vectors map[string]vector
+	require.NoError(t, json.Unmarshal(bz, &vectors))
+	require.Len(t, vectors, 2)
+
+	return vectors
+}
+
+func newKeyring(t *testing.T) keyring.Keyring {
+	t.Helper()
+
+	registry := codectypes.NewInterfaceRegistry()
+	cryptocodec.RegisterInterfaces(registry)
+	evmcryptocodec.RegisterInterfaces(registry)
+
+	return keyring.NewInMemory(codec.NewProtoCodec(registry), evmhd.EthSecp256k1Option())
+}
+
+func TestDecryptGethVectors(t *testing.T) {
+	for name, v := range loadVectors(t) {
+		t.Run(name, func(t *testing.T) {
+			privKey, err := ethkeystore.Decrypt(v.JSON, v.Password)
+			require.NoError(t, err)
+			require.Equal(t, v.Priv, hex.EncodeToString(privKey.Key))
+
+			_, err = ethkeystore.Decrypt(v.JSON, "wrong")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestImportExportRoundTrip(t *testing.T) {
+	for name, v := range loadVectors(t) {
+		t.Run(name, func(t *testing.T) {
+			kr := newKeyring(t)
+
+			record, err := ethkeystore.Import(kr, "geth", v.JSON, v.Password)
+			require.NoError(t, err)
+
+			// the Cosmos address is the Ethereum address of the key
+			privBz, err := hex.DecodeString(v.Priv)
+			require.NoError(t, err)
+			ecdsaKey, err := ethcrypto.ToECDSA(privBz)
+			require.NoError(t, err)
+			ethAddr := ethcrypto.PubkeyToAddress(ecdsaKey.PublicKey)
+
+			addr, err := record.GetAddress()
+			require.NoError(t, err)
+			require.Equal(t, ethAddr.Bytes(), addr.Bytes())
+
+			for _, opts := range []ethkeystore.Options{
+				{KDF: ethkeystore.KDFScrypt, Light: true},
+				{KDF: ethkeystore.KDFPBKDF2},
+			} {
+				keyjson, err := ethkeystore.Export(kr, "geth", "exported", opts)
+				require.NoError(t, err)
+
+				var doc struct {
+					Address string `json:"address"`
+					Crypto  struct {
+						KDF string `json:"kdf"`
+					} `json:"crypto"`
+					Version int `json:"version"`
+				}
+				require.NoError(t, json.Unmarshal(keyjson, &doc))
+				require.Equal(t, opts.KDF, doc.Crypto.KDF)
+				require.Equal(t, 3, doc.Version)
+				require.Equal(t, hex.EncodeToString(ethAddr.Bytes()), doc.Address)
+
+				privKey, err := ethkeystore.Decrypt(keyjson, "exported")
+				require.NoError(t, err)
+				require.Equal(t, v.Priv, hex.EncodeToString(privKey.Key))
+
+				// and back into another keyring under the same address
+				other := newKeyring(t)
+				reimported, err := ethkeystore.Import(other, "geth", keyjson, "exported")
+				require.NoError(t, err)
+				reimportedAddr, err := reimported.GetAddress()
+				require.NoError(t, err)
+				require.Equal(t, addr, reimportedAddr)
+			}
+		})
+	}
+}
+
+func TestExportRefusesNonEthKeys(t *testing.T) {
+	kr := newKeyring(t)
+
+	_, _, err := kr.NewMnemonic("cosmos", keyring.English, sdk.FullFundraiserPath, keyring.DefaultBIP39Passphrase, hd.Secp256k1)
+	require.NoError(t, err)
+
+	_, err = ethkeystore.Export(kr, "cosmos", "password", ethkeystore.Options{KDF: ethkeystore.KDFScrypt, Light: true})
+	require.ErrorContains(t, err, "only eth_secp256k1 keys")
+}
+
+func TestDecryptRejectsAddressMismatch(t *testing.T) {
+	v := loadVectors(t)["wikipage_test_vector_pbkdf2"]
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(v.JSON, &doc))
+	doc["address"] = "0000000000000000000000000000000000000001"
+	keyjson, err := json.Marshal(doc)
+	require.NoError(t, err)
+
+	_, err = ethkeystore.Decrypt(keyjson, v.Password)
+	require.ErrorContains(t, err, "does not match")
+}
@@ -0,0 +1,171 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ErrFaucetAccount is returned for requests funding the faucet account
+// itself.
+var ErrFaucetAccount = errors.New("cannot fund the faucet account")
+
+// ErrTxFailed is returned for funding txs executed with a non-zero code.
+var ErrTxFailed = errors.New("funding tx failed")
+
+// Sender sends coins from the faucet account.
+type Sender interface {
+	// Address is the faucet account.
+	Address() sdk.AccAddress
+	// Send broadcasts a bank send of coins to addr and returns its tx hash.
+	Send(ctx context.Context, to sdk.AccAddress, coins sdk.Coins) (string, error)
+	// WaitForTx waits for the tx to be included in a block. It returns
+	// ErrTxFailed if the tx was included but failed.
+	WaitForTx(ctx context.Context, hash string) error
+	// Balances returns both balance views of addr.
+	Balances(ctx context.Context, addr sdk.AccAddress) (Balances, error)
+}
+
+// Balances are the two views of one account balance: the Cosmos bank
+// balances and the EVM balance of the native coin, with 18 decimals.
+type Balances struct {
+	Cosmos sdk.Coins `json:"cosmos"`
+	EVM    string    `json:"evm"`
+}
+
+// Response is the result of a funding request.
+type Response struct {
+	Address    string    `json:"address"`
+	EthAddress string    `json:"eth_address"`
+	Amount     sdk.Coins `json:"amount"`
+	TxHash     string    `json:"tx_hash"`
+	// Balances are queried once the tx is included. They are missing if it
+	// wasn't included in time.
+	Balances *Balances `json:"balances,omitempty"`
+}
+
+// Faucet funds accounts within its limits.
+type Faucet struct {
+	sender  Sender
+	amount  sdk.Coins
+	limiter *limiter
+	// inclusionTimeout bounds the wait for the funding tx before balances
+	// are queried.
+	inclusionTimeout time.Duration
+}
+
+// New returns a faucet sending amount per request.
+func New(sender Sender, amount sdk.Coins, limits Limits) (*Faucet, error) {
+	if !amount.IsValid() || amount.IsZero() {
+		return nil, fmt.Errorf("invalid faucet amount %q", amount)
+	}
+	if (limits.PerAddress > 0 || limits.PerIP > 0) && limits.Window <= 0 {
+		return nil, errors.New("rate limits need a positive window")
+	}
+
+	return &Faucet{
+		sender:           sender,
+		amount:           amount,
+		limiter:          newLimiter(limits, time.Now),
+		inclusionTimeout: 30 * time.Second,
+	}, nil
+}
+
+// ParseAddress parses either form of an account address: 0x hex, as shown
+// by MetaMask, or bech32, as shown by Keplr.
+func ParseAddress(addr string) (sdk.AccAddress, error) {
+	addr = strings.TrimSpace(addr)
+	if strings.HasPrefix(addr, "0x") || strings.HasPrefix(addr, "0X") {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid hex address %q", addr)
+		}
+		return common.HexToAddress(addr).Bytes(), nil
+	}
+
+	accAddr, err := sdk.AccAddressFromBech32(addr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %q: %w", addr, err)
+	}
+
+	return accAddr, nil
+}
+
+// Request funds addr, in either form, on behalf of the client at ip.
+func (f *Faucet) Request(ctx context.Context, addr, ip string) (Response, error) {
+	accAddr, err := ParseAddress(addr)
+	if err != nil {
+		return Response{}, err
+	}
+	if accAddr.Equals(f.sender.Address()) {
+		return Response{}, ErrFaucetAccount
+	}
+
+	release, err := f.limiter.reserve(accAddr.String(), ip, f.amount)
+	if err != nil {
+		return Response{}, err
+	}
+
+	hash, err := f.sender.Send(ctx, accAddr, f.amount)
+	if err != nil {
+		release()
+		return Response{}, err
+	}
+
+	res := Response{
+		Address:    accAddr.String(),
+		EthAddress: common.BytesToAddress(accAddr).Hex(),
+		Amount:     f.amount,
+		TxHash:     hash,
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, f.inclusionTimeout)
+	defer cancel()
+	if err := f.sender.WaitForTx(waitCtx, hash); err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			// still pending, the client can follow the tx hash
+			return res, nil
+		}
+		if errors.Is(err, ErrTxFailed) {
+			// nothing was sent
+			release()
+		}
+		return Response{}, err
+	}
+
+	if balances, err := f.sender.Balances(ctx, accAddr); err == nil {
+		res.Balances = &balances
+	}
+
+	return res, nil
+}
+
+// Info describes the faucet.
+type Info struct {
+	Address    string    `json:"address"`
+	EthAddress string    `json:"eth_address"`
+	Amount     sdk.Coins `json:"amount"`
+	PerAddress int       `json:"per_address"`
+	PerIP      int       `json:"per_ip"`
+	Window     string    `json:"window"`
+	DailyCap   sdk.Coins `json:"daily_cap"`
+}
+
+// Info returns the faucet account and limits.
+func (f *Faucet) Info() Info {
+	limits := f.limiter.limits
+	return Info{
+		Address:    f.sender.Address().String(),
+		EthAddress: common.BytesToAddress(f.sender.Address()).Hex(),
+		Amount:     f.amount,
+		PerAddress: limits.PerAddress,
+		PerIP:      limits.PerIP,
+		Window:     limits.Window.String(),
+		DailyCap:   limits.DailyCap,
+	}
+}
@@ -0,0 +1,215 @@
+package faucet_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	_ "mirrorvault/app" // sets the bech32 prefixes
+	"mirrorvault/faucet"
+)
+
+const (
+	faucetAddr = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	aliceAddr  = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+	bobAddr    = "0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC"
+)
+
+// fakeSender records the sends and credits the recipients.
+type fakeSender struct {
+	balances map[string]sdk.Coins
+	fail     error
+	failTx   error
+}
+
+func newFakeSender() *fakeSender {
+	return &fakeSender{balances: make(map[string]sdk.Coins)}
+}
+
+func (s *fakeSender) Address() sdk.AccAddress {
+	return common.HexToAddress(faucetAddr).Bytes()
+}
+
+func (s *fakeSender) Send(_ context.Context, to sdk.AccAddress, coins sdk.Coins) (string, error) {
+	if s.fail != nil {
+		return "", s.fail
+	}
+	s.balances[to.String()] = s.balances[to.String()].Add(coins...)
+	return "HASH", nil
+}
+
+func (s *fakeSender) WaitForTx(context.Context, string) error {
+	return s.failTx
+}
+
+func (s *fakeSender) Balances(_ context.Context, addr sdk.AccAddress) (faucet.Balances, error) {
+	coins := s.balances[addr.String()]
+	// 1umvlt is 10^12 wei
+	return faucet.Balances{Cosmos: coins, EVM: coins.AmountOf("umvlt").MulRaw(1e12).String()}, nil
+}
+
+func newFaucet(t *testing.T, sender faucet.Sender, limits faucet.Limits) *faucet.Faucet {
+	t.Helper()
+	f, err := faucet.New(sender, sdk.NewCoins(sdk.NewInt64Coin("umvlt", 10)), limits)
+	require.NoError(t, err)
+	return f
+}
+
+func TestParseAddress(t *testing.T) {
+	hexAddr, err := faucet.ParseAddress(aliceAddr)
+	require.NoError(t, err)
+
+	bech32Addr, err := faucet.ParseAddress(hexAddr.String())
+	require.NoError(t, err)
+	require.Equal(t, hexAddr, bech32Addr)
+	require.True(t, strings.HasPrefix(hexAddr.String(), "mirror1"))
+
+	for _, addr := range []string{"", "0x1234", "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu", "alice"} {
+		_, err := faucet.ParseAddress(addr)
+		require.Error(t, err, addr)
+	}
+}
+
+func TestRequest(t *testing.T) {
+	sender := newFakeSender()
+	f := newFaucet(t, sender, faucet.Limits{})
+
+	res, err := f.Request(context.Background(), aliceAddr, "1.2.3.4")
+	require.NoError(t, err)
+	require.Equal(t, aliceAddr, res.EthAddress)
+	require.Equal(t, "HASH", res.TxHash)
+	require.Equal(t, "10umvlt", res.Balances.Cosmos.String())
+	require.Equal(t, "10000000000000", res.Balances.EVM)
+
+	// the bech32 form funds the same account
+	res, err = f.Request(context.Background(), res.Address, "1.2.3.4")
+	require.NoError(t, err)
+	require.Equal(t, "20umvlt", res.Balances.Cosmos.String())
+
+	_, err = f.Request(context.Background(), faucetAddr, "1.2.3.4")
+	require.ErrorIs(t, err, faucet.ErrFaucetAccount)
+}
+
+func TestLimits(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("per address", func(t *testing.T) {
+		f := newFaucet(t, newFakeSender(), faucet.Limits{PerAddress: 1, Window: time.Hour})
+		_, err := f.Request(ctx, aliceAddr, "1.2.3.4")
+		require.NoError(t, err)
+
+		_, err = f.Request(ctx, aliceAddr, "5.6.7.8")
+		var limitErr *faucet.LimitError
+		require.ErrorAs(t, err, &limitErr)
+		require.InDelta(t, time.Hour, limitErr.RetryAfter, float64(time.Minute))
+
+		_, err = f.Request(ctx, bobAddr, "1.2.3.4")
+		require.NoError(t, err)
+	})
+
+	t.Run("per IP", func(t *testing.T) {
+		f := newFaucet(t, newFakeSender(), faucet.Limits{PerIP: 1, Window: time.Hour})
+		_, err := f.Request(ctx, aliceAddr, "1.2.3.4")
+		require.NoError(t, err)
+
+		_, err = f.Request(ctx, bobAddr, "1.2.3.4")
+		require.ErrorAs(t, err, new(*faucet.LimitError))
+
+		_, err = f.Request(ctx, bobAddr, "5.6.7.8")
+		require.NoError(t, err)
+	})
+
+	t.Run("daily cap", func(t *testing.T) {
+		f := newFaucet(t, newFakeSender(), faucet.Limits{DailyCap: sdk.NewCoins(sdk.NewInt64Coin("umvlt", 15))})
+		_, err := f.Request(ctx, aliceAddr, "1.2.3.4")
+		require.NoError(t, err)
+
+		_, err = f.Request(ctx, bobAddr, "5.6.7.8")
+		require.ErrorContains(t, err, "daily cap")
+	})
+
+	t.Run("failed sends are not counted", func(t *testing.T) {
+		sender := newFakeSender()
+		f := newFaucet(t, sender, faucet.Limits{PerAddress: 1, Window: time.Hour})
+
+		sender.fail = errors.New("node down")
+		_, err := f.Request(ctx, aliceAddr, "1.2.3.4")
+		require.ErrorContains(t, err, "node down")
+
+		sender.fail = nil
+		sender.failTx = fmt.Errorf("%w: out of gas", faucet.ErrTxFailed)
+		_, err = f.Request(ctx, aliceAddr, "1.2.3.4")
+		require.ErrorIs(t, err, faucet.ErrTxFailed)
+
+		sender.failTx = nil
+		_, err = f.Request(ctx, aliceAddr, "1.2.3.4")
+		require.NoError(t, err)
+	})
+
+	_, err := faucet.New(newFakeSender(), sdk.NewCoins(sdk.NewInt64Coin("umvlt", 10)), faucet.Limits{PerIP: 1})
+	require.Error(t, err)
+}
+
+func TestHandler(t *testing.T) {
+	f := newFaucet(t, newFakeSender(), faucet.Limits{PerAddress: 1, Window: time.Hour})
+	srv := httptest.NewServer(f.Handler())
+	defer srv.Close()
+
+	post := func(body string) (*http.Response, map[string]any) {
+		resp, err := http.Post(srv.URL, "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, "*", resp.Header.Get("Access-Control-Allow-Origin"))
+
+		var out map[string]any
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+		return resp, out
+	}
+
+	resp, out := post(`{"address": "` + aliceAddr + `"}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "HASH", out["tx_hash"])
+	require.Equal(t, "10000000000000", out["balances"].(map[string]any)["evm"])
+
+	resp, out = post(`{"address": "` + aliceAddr + `"}`)
+	require.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	require.NotEmpty(t, resp.Header.Get("Retry-After"))
+	require.Contains(t, out["error"], "address limit")
+
+	resp, _ = post(`{"address": "0xnope"}`)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// JSON-RPC
+	_, out = post(`{"jsonrpc": "2.0", "id": 7, "method": "faucet_request", "params": ["` + bobAddr + `"]}`)
+	require.Equal(t, float64(7), out["id"])
+	require.Nil(t, out["error"])
+	require.Equal(t, bobAddr, out["result"].(map[string]any)["eth_address"])
+
+	_, out = post(`{"jsonrpc": "2.0", "id": 8, "method": "faucet_request", "params": ["` + bobAddr + `"]}`)
+	require.Equal(t, float64(-32005), out["error"].(map[string]any)["code"])
+
+	_, out = post(`{"jsonrpc": "2.0", "id": 9, "method": "faucet_request", "params": []}`)
+	require.Equal(t, float64(-32602), out["error"].(map[string]any)["code"])
+
+	_, out = post(`{"jsonrpc": "2.0", "id": 10, "method": "eth_chainId", "params": []}`)
+	require.Equal(t, float64(-32601), out["error"].(map[string]any)["code"])
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	var info faucet.Info
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&info))
+	require.Equal(t, faucetAddr, info.EthAddress)
+	require.Equal(t, "10umvlt", info.Amount.String())
+}
@@ -0,0 +1,126 @@
+package faucet
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Limits bound what the faucet gives away. Zero values disable a limit.
+type Limits struct {
+	// PerAddress and PerIP are the number of requests allowed per Window for
+	// a recipient and for a client IP.
+	PerAddress int
+	PerIP      int
+	Window     time.Duration
+	// DailyCap is the most the faucet sends per UTC day, all recipients
+	// included.
+	DailyCap sdk.Coins
+}
+
+// LimitError is returned for requests over a limit.
+type LimitError struct {
+	Reason     string
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", e.Reason, e.RetryAfter.Round(time.Second))
+}
+
+// limiter tracks the requests of the last window and the coins sent today.
+type limiter struct {
+	limits Limits
+	now    func() time.Time
+
+	mu        sync.Mutex
+	addresses map[string][]time.Time
+	ips       map[string][]time.Time
+	day       time.Time
+	sent      sdk.Coins
+}
+
+func newLimiter(limits Limits, now func() time.Time) *limiter {
+	return &limiter{
+		limits:    limits,
+		now:       now,
+		addresses: make(map[string][]time.Time),
+		ips:       make(map[string][]time.Time),
+	}
+}
+
+// reserve records a request of coins to addr from ip, if the limits allow it.
+// The returned release undoes the reservation, for requests that failed.
+func (l *limiter) reserve(addr, ip string, coins sdk.Coins) (release func(), err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if day := now.UTC().Truncate(24 * time.Hour); !day.Equal(l.day) {
+		l.day = day
+		l.sent = sdk.NewCoins()
+	}
+
+	if !l.limits.DailyCap.IsZero() && l.sent.Add(coins...).IsAnyGT(l.limits.DailyCap) {
+		return nil, &LimitError{Reason: "daily cap reached", RetryAfter: l.day.Add(24 * time.Hour).Sub(now)}
+	}
+	if err := l.check(l.addresses, addr, l.limits.PerAddress, now, "address"); err != nil {
+		return nil, err
+	}
+	if err := l.check(l.ips, ip, l.limits.PerIP, now, "IP"); err != nil {
+		return nil, err
+	}
+
+	l.addresses[addr] = append(l.addresses[addr], now)
+	l.ips[ip] = append(l.ips[ip], now)
+	l.sent = l.sent.Add(coins...)
+	day := l.day
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		l.addresses[addr] = remove(l.addresses[addr], now)
+		l.ips[ip] = remove(l.ips[ip], now)
+		if l.day.Equal(day) {
+			l.sent = l.sent.Sub(coins...)
+		}
+	}, nil
+}
+
+// check prunes the requests of key older than the window and errors if key
+// already made limit requests in it.
+func (l *limiter) check(requests map[string][]time.Time, key string, limit int, now time.Time, kind string) error {
+	if limit <= 0 {
+		return nil
+	}
+
+	var recent []time.Time
+	for _, t := range requests[key] {
+		if now.Sub(t) < l.limits.Window {
+			recent = append(recent, t)
+		}
+	}
+	requests[key] = recent
+
+	if len(recent) >= limit {
+		return &LimitError{
+			Reason:     fmt.Sprintf("%s limit of %d requests per %s reached", kind, limit, l.limits.Window),
+			RetryAfter: recent[0].Add(l.limits.Window).Sub(now),
+		}
+	}
+
+	return nil
+}
+
+func remove(times []time.Time, t time.Time) []time.Time {
+	for i := range times {
+		if times[i].Equal(t) {
+			return append(times[:i], times[i+1:]...)
+		}
+	}
+
+	return times
+}
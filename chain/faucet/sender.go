@@ -0,0 +1,139 @@
+package faucet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	authtx "github.com/cosmos/cosmos-sdk/x/auth/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// TxSender signs bank sends with a keyring account and broadcasts them to a
+// node, in process.
+type TxSender struct {
+	clientCtx client.Context
+	txf       tx.Factory
+
+	// mu serializes sends, whose sequence is tracked locally so that
+	// several sends fit in one block.
+	mu       sync.Mutex
+	sequence uint64
+	synced   bool
+}
+
+// NewTxSender returns a sender signing with the from account of clientCtx
+// and the gas and fee settings of txf.
+func NewTxSender(clientCtx client.Context, txf tx.Factory) (*TxSender, error) {
+	if clientCtx.FromName == "" || clientCtx.FromAddress.Empty() {
+		return nil, errors.New("no faucet account, set --from")
+	}
+
+	return &TxSender{clientCtx: clientCtx, txf: txf}, nil
+}
+
+// Address implements Sender.
+func (s *TxSender) Address() sdk.AccAddress {
+	return s.clientCtx.FromAddress
+}
+
+// Send implements Sender.
+func (s *TxSender) Send(ctx context.Context, to sdk.AccAddress, coins sdk.Coins) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.synced {
+		accNum, seq, err := s.clientCtx.AccountRetriever.GetAccountNumberSequence(s.clientCtx, s.Address())
+		if err != nil {
+			return "", fmt.Errorf("failed to query the faucet account: %w", err)
+		}
+		s.txf = s.txf.WithAccountNumber(accNum)
+		s.sequence = seq
+		s.synced = true
+	}
+
+	txf := s.txf.WithSequence(s.sequence)
+	msg := banktypes.NewMsgSend(s.Address(), to, coins)
+	if txf.SimulateAndExecute() {
+		_, gas, err := tx.CalculateGas(s.clientCtx, txf, msg)
+		if err != nil {
+			s.synced = false
+			return "", err
+		}
+		txf = txf.WithGas(gas)
+	}
+
+	txBuilder, err := txf.BuildUnsignedTx(msg)
+	if err != nil {
+		return "", err
+	}
+	if err := tx.Sign(ctx, txf, s.clientCtx.FromName, txBuilder, true); err != nil {
+		return "", err
+	}
+	txBytes, err := s.clientCtx.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return "", err
+	}
+
+	res, err := s.clientCtx.BroadcastTx(txBytes)
+	if err != nil {
+		s.synced = false
+		return "", err
+	}
+	if res.Code != 0 {
+		if res.Code == sdkerrors.ErrWrongSequence.ABCICode() {
+			s.synced = false
+		}
+		return "", fmt.Errorf("%w: %s", ErrTxFailed, res.RawLog)
+	}
+
+	s.sequence++
+
+	return res.TxHash, nil
+}
+
+// WaitForTx implements Sender.
+func (s *TxSender) WaitForTx(ctx context.Context, hash string) error {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		if res, err := authtx.QueryTx(s.clientCtx, hash); err == nil {
+			if res.Code != 0 {
+				return fmt.Errorf("%w: %s", ErrTxFailed, res.RawLog)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Balances implements Sender.
+func (s *TxSender) Balances(ctx context.Context, addr sdk.AccAddress) (Balances, error) {
+	bankRes, err := banktypes.NewQueryClient(s.clientCtx).AllBalances(ctx, &banktypes.QueryAllBalancesRequest{Address: addr.String()})
+	if err != nil {
+		return Balances{}, err
+	}
+
+	evmRes, err := evmtypes.NewQueryClient(s.clientCtx).Balance(ctx, &evmtypes.QueryBalanceRequest{Address: common.BytesToAddress(addr).Hex()})
+	if err != nil {
+		return Balances{}, err
+	}
+
+	return Balances{Cosmos: bankRes.Balances, EVM: evmRes.Balance}, nil
+}
@@ -0,0 +1,167 @@
+package faucet
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+)
+
+// RPCMethod is the JSON-RPC method funding the address in its first param,
+// for scripts that already speak JSON-RPC, like Hardhat's
+// network.provider.send.
+const RPCMethod = "faucet_request"
+
+// JSON-RPC error codes.
+const (
+	rpcCodeInvalidRequest = -32600
+	rpcCodeMethodNotFound = -32601
+	rpcCodeInvalidParams  = -32602
+	rpcCodeServerError    = -32000
+	// rpcCodeLimitExceeded is the code EIP-1474 reserves for rate limits.
+	rpcCodeLimitExceeded = -32005
+)
+
+// Handler returns the HTTP handler of the faucet. GET returns the faucet
+// info. POST takes either {"address": "0x…"|"mirror1…"} or a JSON-RPC call of
+// RPCMethod.
+func (f *Faucet) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		// dapps and wallets call the faucet from their own origin
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+
+		switch req.Method {
+		case http.MethodOptions:
+			w.WriteHeader(http.StatusNoContent)
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, f.Info())
+		case http.MethodPost:
+			f.serveRequest(w, req)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", req.Method))
+		}
+	})
+}
+
+// request is the body of a POST, either a plain request or a JSON-RPC call.
+type request struct {
+	Address string `json:"address"`
+
+	JSONRPC string            `json:"jsonrpc"`
+	Method  string            `json:"method"`
+	Params  []json.RawMessage `json:"params"`
+	ID      json.RawMessage   `json:"id"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (f *Faucet) serveRequest(w http.ResponseWriter, req *http.Request) {
+	var body request
+	if err := json.NewDecoder(http.MaxBytesReader(w, req.Body, 1<<16)).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err))
+		return
+	}
+
+	ip := clientIP(req)
+	if body.JSONRPC != "" {
+		f.serveRPC(w, req, body, ip)
+		return
+	}
+
+	res, err := f.Request(req.Context(), body.Address, ip)
+	if err != nil {
+		var limitErr *LimitError
+		switch {
+		case errors.As(err, &limitErr):
+			w.Header().Set("Retry-After", retryAfter(limitErr))
+			writeError(w, http.StatusTooManyRequests, err)
+		case isBadAddress(body.Address), errors.Is(err, ErrFaucetAccount):
+			writeError(w, http.StatusBadRequest, err)
+		default:
+			writeError(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, res)
+}
+
+func (f *Faucet) serveRPC(w http.ResponseWriter, req *http.Request, body request, ip string) {
+	resp := rpcResponse{JSONRPC: "2.0", ID: body.ID}
+	if resp.ID == nil {
+		resp.ID = json.RawMessage("null")
+	}
+
+	var addr string
+	switch {
+	case body.JSONRPC != "2.0":
+		resp.Error = &rpcError{Code: rpcCodeInvalidRequest, Message: "jsonrpc must be 2.0"}
+	case body.Method != RPCMethod:
+		resp.Error = &rpcError{Code: rpcCodeMethodNotFound, Message: fmt.Sprintf("method %s not found", body.Method)}
+	case len(body.Params) != 1 || json.Unmarshal(body.Params[0], &addr) != nil || isBadAddress(addr):
+		resp.Error = &rpcError{Code: rpcCodeInvalidParams, Message: "params must be [address], 0x or bech32"}
+	default:
+		res, err := f.Request(req.Context(), addr, ip)
+		var limitErr *LimitError
+		switch {
+		case errors.As(err, &limitErr):
+			resp.Error = &rpcError{Code: rpcCodeLimitExceeded, Message: err.Error()}
+		case errors.Is(err, ErrFaucetAccount):
+			resp.Error = &rpcError{Code: rpcCodeInvalidParams, Message: err.Error()}
+		case err != nil:
+			resp.Error = &rpcError{Code: rpcCodeServerError, Message: err.Error()}
+		default:
+			resp.Result = res
+		}
+	}
+
+	// JSON-RPC errors are carried in the body, with a 200 status
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// isBadAddress reports whether addr is not an account address, to tell
+// client errors from send failures.
+func isBadAddress(addr string) bool {
+	_, err := ParseAddress(addr)
+	return err != nil
+}
+
+// clientIP returns the IP of the client of req. X-Forwarded-For is ignored
+// since clients could set it to get around the IP limit.
+func clientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+func retryAfter(err *LimitError) string {
+	return strconv.Itoa(int(math.Ceil(err.RetryAfter.Seconds())))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,186 @@
+package gascost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+
+	evmserverconfig "github.com/cosmos/evm/server/config"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// Quote kinds.
+const (
+	KindEthereum = "ethereum"
+	KindCosmos   = "cosmos"
+)
+
+// QuoteRequest holds the tx to quote, either an unsigned Ethereum tx or an
+// encoded Cosmos tx.
+type QuoteRequest struct {
+	// Ethereum is an eth_estimateGas call object: from, to, gas, value,
+	// input, ...
+	Ethereum json.RawMessage `json:"ethereum,omitempty"`
+	// CosmosTx is an encoded Cosmos tx. Signatures can be left out.
+	CosmosTx []byte `json:"cosmos_tx,omitempty"`
+}
+
+// Quote is the gas and fee of a tx at the current gas prices. Prices are
+// per gas.
+type Quote struct {
+	Kind string `json:"kind"`
+	// Gas is the gas limit to set: the eth_estimateGas result for Ethereum
+	// txs and the simulated gas used for Cosmos txs.
+	Gas          uint64 `json:"gas"`
+	BaseFee      Amount `json:"base_fee"`
+	SuggestedTip Amount `json:"suggested_tip"`
+	MinGasPrice  Amount `json:"min_gas_price"`
+	// GasPrice is the base fee plus the tip, at least the min gas price.
+	GasPrice Amount `json:"gas_price"`
+	TotalFee Amount `json:"total_fee"`
+}
+
+// Amount is an amount of the EVM coin in both its units: the 6 decimals
+// bank denom and the 18 decimals denom of the EVM.
+type Amount struct {
+	Base     sdk.DecCoin `json:"base"`
+	Extended sdk.DecCoin `json:"extended"`
+}
+
+func newAmount(extended math.LegacyDec) Amount {
+	return Amount{
+		Base:     baseAmount(extended),
+		Extended: sdk.NewDecCoinFromDec(evmtypes.GetEVMCoinExtendedDenom(), extended),
+	}
+}
+
+// Quote simulates the tx of req and returns its fee. Ethereum txs are
+// estimated against the state of ctx, Cosmos txs against the check state,
+// as the simulate service does. The base fee and tip are those of the fee
+// market, like the JSON-RPC eth_gasPrice and eth_maxPriorityFeePerGas.
+func (e Engine) Quote(ctx sdk.Context, req QuoteRequest) (Quote, error) {
+	var (
+		quote Quote
+		err   error
+	)
+	switch {
+	case len(req.Ethereum) > 0 && len(req.CosmosTx) > 0:
+		return Quote{}, errors.New("set either ethereum or cosmos, not both")
+	case len(req.Ethereum) > 0:
+		quote.Kind = KindEthereum
+		quote.Gas, err = e.estimateEthereum(ctx, req.Ethereum)
+	case len(req.CosmosTx) > 0:
+		quote.Kind = KindCosmos
+		quote.Gas, err = e.simulateCosmos(ctx, req.CosmosTx)
+	default:
+		return Quote{}, errors.New("no tx, set ethereum or cosmos")
+	}
+	if err != nil {
+		return Quote{}, err
+	}
+
+	baseFee, minGasPrice := e.gasPrices(ctx)
+	params := e.FeeMarketKeeper.GetParams(ctx)
+	tip := math.LegacyZeroDec()
+	if params.BaseFeeChangeDenominator > 0 {
+		// the most the base fee can rise in one block
+		tip = baseFee.MulInt64(int64(params.ElasticityMultiplier) - 1).QuoInt64(int64(params.BaseFeeChangeDenominator))
+	}
+	gasPrice := math.LegacyMaxDec(baseFee.Add(tip), minGasPrice)
+
+	quote.BaseFee = newAmount(baseFee)
+	quote.SuggestedTip = newAmount(tip)
+	quote.MinGasPrice = newAmount(minGasPrice)
+	quote.GasPrice = newAmount(gasPrice)
+	quote.TotalFee = newAmount(gasPrice.MulInt(math.NewIntFromUint64(quote.Gas)).Ceil())
+
+	return quote, nil
+}
+
+// estimateEthereum runs eth_estimateGas on the call object args.
+func (e Engine) estimateEthereum(ctx sdk.Context, args json.RawMessage) (uint64, error) {
+	ctx, err := e.withProposer(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := e.EVMKeeper.EstimateGas(ctx, &evmtypes.EthCallRequest{
+		Args:            args,
+		GasCap:          evmserverconfig.DefaultGasCap,
+		ProposerAddress: ctx.BlockHeader().ProposerAddress,
+		ChainId:         evmtypes.GetEthChainConfig().ChainID.Int64(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	if res.Failed() {
+		return 0, fmt.Errorf("tx reverts: %s", res.VmError)
+	}
+
+	return res.Gas, nil
+}
+
+// simulateCosmos simulates the tx, adding empty signatures at the signer
+// sequences if it has none, like the CLI does for --gas auto.
+func (e Engine) simulateCosmos(ctx sdk.Context, txBytes []byte) (uint64, error) {
+	tx, err := e.TxConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cosmos tx: %w", err)
+	}
+
+	txBuilder, err := e.TxConfig.WrapTxBuilder(tx)
+	if err != nil {
+		return 0, err
+	}
+	sigTx, ok := tx.(authsigning.SigVerifiableTx)
+	if !ok {
+		return 0, errors.New("invalid cosmos tx: not signable")
+	}
+	if sigs, err := sigTx.GetSignaturesV2(); err != nil {
+		return 0, err
+	} else if len(sigs) == 0 {
+		if err := e.setSimSignatures(ctx, txBuilder, sigTx); err != nil {
+			return 0, err
+		}
+	}
+
+	txBytes, err = e.TxConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return 0, err
+	}
+	gasInfo, _, err := e.Simulate(txBytes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate: %w", err)
+	}
+
+	return gasInfo.GasUsed, nil
+}
+
+// setSimSignatures sets an empty signature per signer of tx, with the signer
+// key and sequence when the account is on chain.
+func (e Engine) setSimSignatures(ctx sdk.Context, txBuilder client.TxBuilder, tx authsigning.SigVerifiableTx) error {
+	signers, err := tx.GetSigners()
+	if err != nil {
+		return err
+	}
+
+	sigs := make([]signing.SignatureV2, len(signers))
+	for i, signer := range signers {
+		sigs[i] = signing.SignatureV2{
+			Data: &signing.SingleSignatureData{SignMode: signing.SignMode_SIGN_MODE_DIRECT},
+		}
+		if acc := e.AccountKeeper.GetAccount(ctx, signer); acc != nil {
+			sigs[i].PubKey = acc.GetPubKey()
+			sigs[i].Sequence = acc.GetSequence()
+		}
+	}
+
+	return txBuilder.SetSignatures(sigs...)
+}
@@ -0,0 +1,259 @@
+// Package gascost compares the cost of storing a payload through the EVM,
+// with SSTORE, and through a Cosmos module KVStore, the write behind the
+// vault MsgStoreSecret. x/vault is not part of the app yet, so the Cosmos
+// side is the gas-metered store write and tx bytes such a message pays.
+package gascost
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dbm "github.com/cosmos/cosmos-db"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+
+	"cosmossdk.io/math"
+	"cosmossdk.io/store/dbadapter"
+	"cosmossdk.io/store/gaskv"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authkeeper "github.com/cosmos/cosmos-sdk/x/auth/keeper"
+	stakingkeeper "github.com/cosmos/cosmos-sdk/x/staking/keeper"
+
+	feemarketkeeper "github.com/cosmos/evm/x/feemarket/keeper"
+	evmkeeper "github.com/cosmos/evm/x/vm/keeper"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// MaxSize is the largest payload estimated. Storing it in the EVM takes
+// about 23.2M gas, just under the 25M gas cap of the EVM calls of an
+// estimate (the JSON-RPC default): a slot takes 22100 gas, so 36 KiB would
+// not fit.
+const MaxSize = 32 * 1024
+
+// MaxSizes is the most payload sizes estimated at once. Each one runs an EVM
+// call, so a query can't ask for many.
+const MaxSizes = 16
+
+// DefaultSizes are the payload sizes compared when none are given.
+var DefaultSizes = []int{32, 1024, 4096}
+
+// sender is the account deploying and calling the storage contract. It only
+// exists in the discarded state of an estimate.
+var sender = common.BytesToAddress([]byte("mirrorvault/gascost"))
+
+// storageContract returns the init code of a contract storing its calldata,
+// one 32 bytes word per slot starting at slot 0. It is the storage pattern
+// of a Solidity bytes variable, without the length slot and ABI decoding.
+func storageContract() []byte {
+	// runtime: for (off = 0; off < calldatasize; off += 32) sstore(off >> 5, calldataload(off))
+	runtime, loop := program.New().Push(0).Jumpdest()
+	runtime.Op(vm.DUP1, vm.CALLDATALOAD).
+		Op(vm.DUP2).Push(5).Op(vm.SHR).
+		Op(vm.SSTORE).
+		Push(32).Op(vm.ADD).
+		Op(vm.DUP1, vm.CALLDATASIZE, vm.GT).
+		Push(loop).Op(vm.JUMPI, vm.STOP)
+
+	return program.New().ReturnViaCodeCopy(runtime.Bytes()).Bytes()
+}
+
+// Engine estimates storage costs and quotes tx fees against the state of a
+// context. The state is never modified.
+type Engine struct {
+	EVMKeeper       *evmkeeper.Keeper
+	FeeMarketKeeper feemarketkeeper.Keeper
+	AccountKeeper   authkeeper.AccountKeeper
+	StakingKeeper   *stakingkeeper.Keeper
+	// MinGasPrices are the node minimum gas prices, from app.toml.
+	MinGasPrices sdk.DecCoins
+
+	// TxConfig and Simulate decode and simulate the Cosmos txs quoted.
+	TxConfig client.TxConfig
+	Simulate func(txBytes []byte) (sdk.GasInfo, *sdk.Result, error)
+}
+
+// Report is the cost of storing each payload size through both paths.
+type Report struct {
+	// BaseFee and MinGasPrice are the gas prices the fees are computed at,
+	// in the base denom.
+	BaseFee     sdk.DecCoin `json:"base_fee"`
+	MinGasPrice sdk.DecCoin `json:"min_gas_price"`
+	Estimates   []Estimate  `json:"estimates"`
+}
+
+// Estimate is the cost of storing one payload through both paths.
+type Estimate struct {
+	Size   int  `json:"size"`
+	EVM    Cost `json:"evm"`
+	Cosmos Cost `json:"cosmos"`
+}
+
+// Cost is the cost of storing a payload through one path. GasUsed is the gas
+// spent over an empty tx of the same VM: carrying the payload in the tx and
+// writing it to state.
+type Cost struct {
+	GasUsed          uint64      `json:"gas_used"`
+	FeeAtBaseFee     sdk.DecCoin `json:"fee_at_base_fee"`
+	FeeAtMinGasPrice sdk.DecCoin `json:"fee_at_min_gas_price"`
+	BytesWritten     int         `json:"bytes_written"`
+}
+
+// ParseSizes parses a comma separated list of at most MaxSizes payload sizes
+// in bytes.
+func ParseSizes(s string) ([]int, error) {
+	var sizes []int
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		size, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size %q", field)
+		}
+		if size <= 0 || size > MaxSize {
+			return nil, fmt.Errorf("size %d out of range, must be in [1, %d]", size, MaxSize)
+		}
+		if len(sizes) == MaxSizes {
+			return nil, fmt.Errorf("too many sizes, at most %d", MaxSizes)
+		}
+		sizes = append(sizes, size)
+	}
+	if len(sizes) == 0 {
+		return nil, errors.New("no sizes")
+	}
+
+	return sizes, nil
+}
+
+// Estimate deploys the storage contract and stores a payload of each size
+// with it and with a KVStore write, in a cache of ctx.
+func (e Engine) Estimate(ctx sdk.Context, sizes []int) (Report, error) {
+	if len(sizes) > MaxSizes {
+		return Report{}, fmt.Errorf("too many sizes, at most %d", MaxSizes)
+	}
+
+	ctx, _ = ctx.CacheContext()
+	ctx = ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+
+	ctx, err := e.withProposer(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	baseFee, minGasPrice := e.gasPrices(ctx)
+	report := Report{BaseFee: baseAmount(baseFee), MinGasPrice: baseAmount(minGasPrice)}
+
+	if e.AccountKeeper.GetAccount(ctx, sender.Bytes()) == nil {
+		e.AccountKeeper.SetAccount(ctx, e.AccountKeeper.NewAccountWithAddress(ctx, sender.Bytes()))
+	}
+	nonce, err := e.AccountKeeper.GetSequence(ctx, sender.Bytes())
+	if err != nil {
+		return Report{}, err
+	}
+	if _, err := e.EVMKeeper.CallEVMWithData(ctx, sender, nil, storageContract(), true, nil); err != nil {
+		return Report{}, fmt.Errorf("failed to deploy the storage contract: %w", err)
+	}
+	contract := crypto.CreateAddress(sender, nonce)
+
+	txSizeCost := e.AccountKeeper.GetParams(ctx).TxSizeCostPerByte
+	for _, size := range sizes {
+		if size <= 0 || size > MaxSize {
+			return Report{}, fmt.Errorf("size %d out of range, must be in [1, %d]", size, MaxSize)
+		}
+		payload := bytes.Repeat([]byte{0xab}, size)
+
+		// each size starts from the freshly deployed contract, so that
+		// every SSTORE sets a new slot
+		sizeCtx, _ := ctx.CacheContext()
+		res, err := e.EVMKeeper.CallEVMWithData(sizeCtx, sender, &contract, payload, true, nil)
+		if err != nil {
+			return Report{}, fmt.Errorf("failed to store %d bytes in the EVM: %w", size, err)
+		}
+		evmCost := report.cost(res.GasUsed-params.TxGas, (size+31)/32*32)
+
+		// the KVStore charges gas the way ctx.KVStore does for a module
+		// store, and a secret is keyed by its owner
+		meter := storetypes.NewInfiniteGasMeter()
+		store := gaskv.NewStore(dbadapter.Store{DB: dbm.NewMemDB()}, meter, ctx.KVGasConfig())
+		key := append([]byte("secret/"), sender.Bytes()...)
+		store.Set(key, payload)
+		cosmosGas := uint64(size)*txSizeCost + meter.GasConsumed()
+		cosmosCost := report.cost(cosmosGas, len(key)+len(payload))
+
+		report.Estimates = append(report.Estimates, Estimate{Size: size, EVM: evmCost, Cosmos: cosmosCost})
+	}
+
+	return report, nil
+}
+
+// withProposer sets the block proposer of ctx, which the EVM needs for the
+// coinbase, to a bonded validator if ctx has none, as in queries.
+func (e Engine) withProposer(ctx sdk.Context) (sdk.Context, error) {
+	header := ctx.BlockHeader()
+	if len(header.ProposerAddress) > 0 {
+		return ctx, nil
+	}
+
+	validators, err := e.StakingKeeper.GetBondedValidatorsByPower(ctx)
+	if err != nil {
+		return ctx, err
+	}
+	if len(validators) == 0 {
+		return ctx, errors.New("no bonded validator")
+	}
+	consAddr, err := validators[0].GetConsAddr()
+	if err != nil {
+		return ctx, err
+	}
+	header.ProposerAddress = consAddr
+
+	return ctx.WithBlockHeader(header), nil
+}
+
+// gasPrices returns the base fee and the min gas price, the higher of the
+// fee market and node minimums, in the extended denom per gas. The fee
+// market keeps them in the base denom, like the node.
+func (e Engine) gasPrices(ctx sdk.Context) (baseFee, minGasPrice math.LegacyDec) {
+	baseFee = e.FeeMarketKeeper.GetBaseFee(ctx)
+	if baseFee.IsNil() {
+		baseFee = math.LegacyZeroDec()
+	}
+
+	minGasPrice = math.LegacyMaxDec(
+		e.FeeMarketKeeper.GetParams(ctx).MinGasPrice,
+		e.MinGasPrices.AmountOf(evmtypes.GetEVMCoinDenom()),
+	)
+
+	return baseFee.Mul(conversionFactor()), minGasPrice.Mul(conversionFactor())
+}
+
+// conversionFactor is the number of extended denom units in a base denom
+// unit, 10^12 for umvlt.
+func conversionFactor() math.LegacyDec {
+	return math.LegacyNewDecFromInt(evmtypes.GetEVMCoinDecimals().ConversionFactor())
+}
+
+// baseAmount converts an amount of the extended denom to the base denom.
+func baseAmount(extended math.LegacyDec) sdk.DecCoin {
+	return sdk.NewDecCoinFromDec(evmtypes.GetEVMCoinDenom(), extended.Quo(conversionFactor()))
+}
+
+func (r Report) cost(gas uint64, bytesWritten int) Cost {
+	gasDec := math.LegacyNewDecFromInt(math.NewIntFromUint64(gas))
+	return Cost{
+		GasUsed:          gas,
+		FeeAtBaseFee:     sdk.NewDecCoinFromDec(r.BaseFee.Denom, r.BaseFee.Amount.Mul(gasDec)),
+		FeeAtMinGasPrice: sdk.NewDecCoinFromDec(r.MinGasPrice.Denom, r.MinGasPrice.Amount.Mul(gasDec)),
+		BytesWritten:     bytesWritten,
+	}
+}
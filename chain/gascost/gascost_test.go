@@ -0,0 +1,263 @@
+package gascost_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server/api"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	evmserverconfig "github.com/cosmos/evm/server/config"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/app"
+	"mirrorvault/gascost"
+	"mirrorvault/gascost/types"
+)
+
+func TestParseSizes(t *testing.T) {
+	sizes, err := gascost.ParseSizes("32, 1024,4096,")
+	require.NoError(t, err)
+	require.Equal(t, []int{32, 1024, 4096}, sizes)
+
+	for _, s := range []string{"", "0", "-1", "1k", "1000000", strings.Repeat("32,", gascost.MaxSizes+1)} {
+		_, err := gascost.ParseSizes(s)
+		require.Error(t, err, s)
+	}
+
+	sizes, err = gascost.ParseSizes(strings.Repeat("32,", gascost.MaxSizes))
+	require.NoError(t, err)
+	require.Len(t, sizes, gascost.MaxSizes)
+}
+
+// TestEstimate runs the engine on an in-memory app. The EVM global config
+// allows a single app per process, so all cases share it.
+func TestEstimate(t *testing.T) {
+	a, err := app.NewInMemory(log.NewNopLogger(), simtestutil.AppOptionsMap{
+		"minimum-gas-prices": "0.5" + app.BaseDenom,
+	})
+	require.NoError(t, err)
+
+	engine, err := a.GasCostEngine()
+	require.NoError(t, err)
+	queryCtx := func() (sdk.Context, error) {
+		return a.CreateQueryContext(0, false)
+	}
+
+	ctx, err := queryCtx()
+	require.NoError(t, err)
+	report, err := engine.Estimate(ctx, []int{32, 1024})
+	require.NoError(t, err)
+	require.Len(t, report.Estimates, 2)
+	require.Equal(t, "0.500000000000000000"+app.BaseDenom, report.MinGasPrice.String())
+	// the fee market is in the base denom
+	require.True(t, report.BaseFee.Amount.Equal(a.FeeMarketKeeper.GetBaseFee(ctx)))
+
+	small, large := report.Estimates[0], report.Estimates[1]
+	require.Equal(t, 32, small.EVM.BytesWritten)
+	require.Equal(t, 1024, large.EVM.BytesWritten)
+	// a new slot costs 22100 gas (EIP-2929 cold SSTORE)
+	require.Greater(t, small.EVM.GasUsed, uint64(22100))
+	require.Greater(t, large.EVM.GasUsed, 32*uint64(22100))
+	// the KVStore charges per byte, far below 22100 per 32 bytes
+	require.Greater(t, large.Cosmos.GasUsed, small.Cosmos.GasUsed)
+	require.Less(t, large.Cosmos.GasUsed, large.EVM.GasUsed)
+	require.Equal(t, app.BaseDenom, large.EVM.FeeAtMinGasPrice.Denom)
+	require.True(t, large.EVM.FeeAtMinGasPrice.Amount.Equal(
+		report.MinGasPrice.Amount.MulInt64(int64(large.EVM.GasUsed))))
+
+	// estimates leave the state untouched, so they repeat
+	again, err := engine.Estimate(ctx, []int{32})
+	require.NoError(t, err)
+	require.Equal(t, small, again.Estimates[0])
+
+	t.Run("route", func(t *testing.T) {
+		rtr := mux.NewRouter()
+		gascost.RegisterRoutes(rtr, engine, queryCtx)
+
+		rec := httptest.NewRecorder()
+		rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, gascost.Route+"?sizes=32,1024", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+		require.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+		var got gascost.Report
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+		require.Equal(t, report.Estimates, got.Estimates)
+
+		rec = httptest.NewRecorder()
+		rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, gascost.Route+"?sizes=big", nil))
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		query := func(req *types.QueryEstimateStorageCostRequest) (*types.QueryEstimateStorageCostResponse, error) {
+			bz, err := req.Marshal()
+			require.NoError(t, err)
+			abciRes, err := a.Query(context.Background(), &abci.RequestQuery{
+				Path: "/mirrorvault.gascost.v1.Query/EstimateStorageCost",
+				Data: bz,
+			})
+			if err != nil {
+				return nil, err
+			}
+			if abciRes.Code != 0 {
+				return nil, errors.New(abciRes.Log)
+			}
+			var res types.QueryEstimateStorageCostResponse
+			require.NoError(t, res.Unmarshal(abciRes.Value))
+			return &res, nil
+		}
+
+		res, err := query(&types.QueryEstimateStorageCostRequest{Sizes: []uint32{32, 1024}})
+		require.NoError(t, err)
+		require.Len(t, res.Estimates, 2)
+		require.Equal(t, uint32(1024), res.Estimates[1].Size_)
+		require.Equal(t, large.EVM.GasUsed, res.Estimates[1].Evm.GasUsed)
+		require.Equal(t, large.Cosmos.GasUsed, res.Estimates[1].Cosmos.GasUsed)
+		require.Equal(t, uint64(large.EVM.BytesWritten), res.Estimates[1].Evm.BytesWritten)
+		require.Equal(t, report.MinGasPrice, res.MinGasPrice)
+
+		res, err = query(&types.QueryEstimateStorageCostRequest{})
+		require.NoError(t, err)
+		require.Len(t, res.Estimates, len(gascost.DefaultSizes))
+
+		_, err = query(&types.QueryEstimateStorageCostRequest{Sizes: make([]uint32, gascost.MaxSizes+1)})
+		require.ErrorContains(t, err, "too many sizes")
+		// the largest payload fits in the gas cap of the EVM call
+		res, err = query(&types.QueryEstimateStorageCostRequest{Sizes: []uint32{gascost.MaxSize}})
+		require.NoError(t, err)
+		require.Equal(t, uint64(gascost.MaxSize), res.Estimates[0].Evm.BytesWritten)
+		require.Less(t, res.Estimates[0].Evm.GasUsed, evmserverconfig.DefaultGasCap)
+		_, err = query(&types.QueryEstimateStorageCostRequest{Sizes: []uint32{gascost.MaxSize + 1}})
+		require.ErrorContains(t, err, "out of range")
+	})
+
+	vals, err := a.StakingKeeper.GetAllValidators(ctx)
+	require.NoError(t, err)
+	valAddr, err := sdk.ValAddressFromBech32(vals[0].OperatorAddress)
+	require.NoError(t, err)
+	operator := sdk.AccAddress(valAddr)
+	recipient := sdk.AccAddress(bytes.Repeat([]byte{1}, 20))
+
+	// quoteFee posts body to the QuoteFee route of a gRPC-gateway set up as
+	// the API server does, querying ctx
+	queryHelper := baseapp.NewQueryServerTestHelper(ctx, a.InterfaceRegistry())
+	types.RegisterQueryServer(queryHelper, gascost.NewQueryServer(engine))
+	apiSvr := api.New(client.Context{InterfaceRegistry: a.InterfaceRegistry()}, log.NewNopLogger(), nil)
+	require.NoError(t, types.RegisterQueryHandlerClient(context.Background(), apiSvr.GRPCGatewayRouter, types.NewQueryClient(queryHelper)))
+	quoteFee := func(body string) (*types.QueryQuoteFeeResponse, error) {
+		rec := httptest.NewRecorder()
+		apiSvr.GRPCGatewayRouter.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/mirrorvault/gas/v1/fee-quote", strings.NewReader(body)))
+		if rec.Code != http.StatusOK {
+			return nil, fmt.Errorf("%d: %s", rec.Code, rec.Body.String())
+		}
+		var res types.QueryQuoteFeeResponse
+		require.NoError(t, a.AppCodec().UnmarshalJSON(rec.Body.Bytes(), &res))
+		return &res, nil
+	}
+	requireResponse := func(t *testing.T, quote gascost.Quote, res *types.QueryQuoteFeeResponse) {
+		t.Helper()
+		require.Equal(t, quote.Kind, res.Kind)
+		require.Equal(t, quote.Gas, res.Gas)
+		for _, amounts := range [][2]any{
+			{quote.BaseFee, res.BaseFee}, {quote.SuggestedTip, res.SuggestedTip}, {quote.MinGasPrice, res.MinGasPrice},
+			{quote.GasPrice, res.GasPrice}, {quote.TotalFee, res.TotalFee},
+		} {
+			want, got := amounts[0].(gascost.Amount), amounts[1].(types.FeeAmount)
+			require.Equal(t, want.Base, got.Base)
+			require.Equal(t, want.Extended, got.Extended)
+		}
+	}
+
+	t.Run("quote ethereum", func(t *testing.T) {
+		args := fmt.Sprintf(`{"from":"%s","to":"%s","value":"0x1"}`,
+			common.BytesToAddress(operator), common.BytesToAddress(recipient))
+		quote, err := engine.Quote(ctx, gascost.QuoteRequest{Ethereum: json.RawMessage(args)})
+		require.NoError(t, err)
+		require.Equal(t, gascost.KindEthereum, quote.Kind)
+		require.Equal(t, uint64(21000), quote.Gas)
+		requireQuote(t, quote)
+		// the fee market is in umvlt, the EVM in amvlt
+		require.True(t, quote.BaseFee.Base.Amount.Equal(a.FeeMarketKeeper.GetBaseFee(ctx)))
+		require.Equal(t, a.EVMKeeper.GetBaseFee(ctx).String(), quote.BaseFee.Extended.Amount.TruncateInt().String())
+
+		res, err := quoteFee(`{"ethereum":` + args + `}`)
+		require.NoError(t, err)
+		requireResponse(t, quote, res)
+
+		_, err = quoteFee(`{"ethereum":{"from":"mirror1"}}`)
+		require.ErrorContains(t, err, "invalid from address")
+	})
+
+	t.Run("quote cosmos", func(t *testing.T) {
+		txBuilder := a.TxConfig().NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(operator, recipient, sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 1)))))
+		txBytes, err := a.TxConfig().TxEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+
+		quote, err := engine.Quote(ctx, gascost.QuoteRequest{CosmosTx: txBytes})
+		require.NoError(t, err)
+		require.Equal(t, gascost.KindCosmos, quote.Kind)
+		require.Greater(t, quote.Gas, uint64(0))
+		requireQuote(t, quote)
+
+		// the JSON of a --generate-only tx, and the encoded tx
+		txJSON, err := a.TxConfig().TxJSONEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+		res, err := quoteFee(`{"cosmos":{"tx":` + string(txJSON) + `}}`)
+		require.NoError(t, err)
+		requireResponse(t, quote, res)
+		body, err := json.Marshal(map[string]any{"cosmos": map[string]any{"tx_bytes": txBytes}})
+		require.NoError(t, err)
+		res, err = quoteFee(string(body))
+		require.NoError(t, err)
+		requireResponse(t, quote, res)
+
+		// more than the balance fails the simulation
+		txBuilder = a.TxConfig().NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(recipient, operator, sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 1)))))
+		txBytes, err = a.TxConfig().TxEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+		_, err = engine.Quote(ctx, gascost.QuoteRequest{CosmosTx: txBytes})
+		require.Error(t, err)
+
+		_, err = quoteFee(`{}`)
+		require.ErrorContains(t, err, "no tx")
+	})
+}
+
+// requireQuote checks the prices of quote add up and match in both units.
+func requireQuote(t *testing.T, quote gascost.Quote) {
+	t.Helper()
+
+	factor := evmtypes.GetEVMCoinDecimals().ConversionFactor()
+	for _, amount := range []gascost.Amount{quote.BaseFee, quote.SuggestedTip, quote.MinGasPrice, quote.GasPrice, quote.TotalFee} {
+		require.Equal(t, app.BaseDenom, amount.Base.Denom)
+		require.Equal(t, evmtypes.GetEVMCoinExtendedDenom(), amount.Extended.Denom)
+		require.True(t, amount.Base.Amount.MulInt(factor).Equal(amount.Extended.Amount), amount)
+	}
+
+	require.True(t, quote.BaseFee.Extended.Amount.IsPositive())
+	require.True(t, quote.GasPrice.Extended.Amount.Equal(math.LegacyMaxDec(
+		quote.BaseFee.Extended.Amount.Add(quote.SuggestedTip.Extended.Amount), quote.MinGasPrice.Extended.Amount)))
+	require.True(t, quote.TotalFee.Extended.Amount.Equal(quote.GasPrice.Extended.Amount.MulInt64(int64(quote.Gas)).Ceil()))
+}
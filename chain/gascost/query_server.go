@@ -0,0 +1,170 @@
+package gascost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/gascost/types"
+)
+
+type queryServer struct {
+	engine Engine
+}
+
+var _ types.QueryServer = queryServer{}
+
+// NewQueryServer returns the gRPC query server estimating on engine.
+func NewQueryServer(engine Engine) types.QueryServer {
+	return queryServer{engine: engine}
+}
+
+// EstimateStorageCost implements types.QueryServer.
+func (s queryServer) EstimateStorageCost(ctx context.Context, req *types.QueryEstimateStorageCostRequest) (*types.QueryEstimateStorageCostResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	sizes := DefaultSizes
+	if len(req.Sizes) > 0 {
+		if len(req.Sizes) > MaxSizes {
+			return nil, status.Errorf(codes.InvalidArgument, "too many sizes, at most %d", MaxSizes)
+		}
+		sizes = make([]int, len(req.Sizes))
+		for i, size := range req.Sizes {
+			if size == 0 || size > MaxSize {
+				return nil, status.Errorf(codes.InvalidArgument, "size %d out of range, must be in [1, %d]", size, MaxSize)
+			}
+			sizes[i] = int(size)
+		}
+	}
+
+	report, err := s.engine.Estimate(sdk.UnwrapSDKContext(ctx), sizes)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	res := &types.QueryEstimateStorageCostResponse{
+		BaseFee:     report.BaseFee,
+		MinGasPrice: report.MinGasPrice,
+		Estimates:   make([]types.StorageEstimate, len(report.Estimates)),
+	}
+	for i, estimate := range report.Estimates {
+		res.Estimates[i] = types.StorageEstimate{
+			Size_:  uint32(estimate.Size),
+			Evm:    storageCost(estimate.EVM),
+			Cosmos: storageCost(estimate.Cosmos),
+		}
+	}
+
+	return res, nil
+}
+
+// QuoteFee implements types.QueryServer.
+func (s queryServer) QuoteFee(ctx context.Context, req *types.QueryQuoteFeeRequest) (*types.QueryQuoteFeeResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	var quoteReq QuoteRequest
+	if req.Ethereum != nil {
+		args, err := ethereumArgs(req.Ethereum)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		quoteReq.Ethereum = args
+	}
+	if req.Cosmos != nil {
+		quoteReq.CosmosTx = req.Cosmos.TxBytes
+		// the JSON of a --generate-only tx, which encodes like the tx
+		if len(quoteReq.CosmosTx) == 0 && req.Cosmos.Tx != nil {
+			bz, err := proto.Marshal(req.Cosmos.Tx)
+			if err != nil {
+				return nil, status.Error(codes.InvalidArgument, err.Error())
+			}
+			quoteReq.CosmosTx = bz
+		}
+		if len(quoteReq.CosmosTx) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "no cosmos tx, set tx_bytes or tx")
+		}
+	}
+
+	// the errors are those of the tx: invalid or failing
+	quote, err := s.engine.Quote(sdk.UnwrapSDKContext(ctx), quoteReq)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &types.QueryQuoteFeeResponse{
+		Kind:         quote.Kind,
+		Gas:          quote.Gas,
+		BaseFee:      feeAmount(quote.BaseFee),
+		SuggestedTip: feeAmount(quote.SuggestedTip),
+		MinGasPrice:  feeAmount(quote.MinGasPrice),
+		GasPrice:     feeAmount(quote.GasPrice),
+		TotalFee:     feeAmount(quote.TotalFee),
+	}, nil
+}
+
+// ethereumArgs returns the eth_estimateGas call object of call.
+func ethereumArgs(call *types.EthereumCall) (json.RawMessage, error) {
+	var args evmtypes.TransactionArgs
+	if call.From != "" {
+		if !common.IsHexAddress(call.From) {
+			return nil, fmt.Errorf("invalid from address %q", call.From)
+		}
+		from := common.HexToAddress(call.From)
+		args.From = &from
+	}
+	if call.To != "" {
+		if !common.IsHexAddress(call.To) {
+			return nil, fmt.Errorf("invalid to address %q", call.To)
+		}
+		to := common.HexToAddress(call.To)
+		args.To = &to
+	}
+	if call.Gas != 0 {
+		gas := hexutil.Uint64(call.Gas)
+		args.Gas = &gas
+	}
+	if call.Value != "" {
+		value, ok := new(big.Int).SetString(call.Value, 0)
+		if !ok || value.Sign() < 0 {
+			return nil, fmt.Errorf("invalid value %q", call.Value)
+		}
+		args.Value = (*hexutil.Big)(value)
+	}
+	if call.Input != "" {
+		input, err := hexutil.Decode(call.Input)
+		if err != nil {
+			return nil, fmt.Errorf("invalid input: %w", err)
+		}
+		args.Input = (*hexutil.Bytes)(&input)
+	}
+
+	return json.Marshal(args)
+}
+
+func feeAmount(amount Amount) types.FeeAmount {
+	return types.FeeAmount{Base: amount.Base, Extended: amount.Extended}
+}
+
+func storageCost(cost Cost) types.StorageCost {
+	return types.StorageCost{
+		GasUsed:          cost.GasUsed,
+		FeeAtBaseFee:     cost.FeeAtBaseFee,
+		FeeAtMinGasPrice: cost.FeeAtMinGasPrice,
+		BytesWritten:     uint64(cost.BytesWritten),
+	}
+}
@@ -0,0 +1,59 @@
+package gascost
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Route is the API server path that serves EstimateStorageCost. The sizes
+// query parameter is a comma separated list of at most MaxSizes payload sizes
+// in bytes.
+const Route = "/mirrorvault/gas/v1/storage-cost"
+
+// RegisterRoutes registers the storage cost endpoint on the API server
+// router. The fee quote is the QuoteFee gRPC-gateway route. queryCtx returns a context on the latest committed state.
+func RegisterRoutes(rtr *mux.Router, engine Engine, queryCtx func() (sdk.Context, error)) {
+	rtr.HandleFunc(Route, func(w http.ResponseWriter, req *http.Request) {
+		sizes := DefaultSizes
+		if s := req.URL.Query().Get("sizes"); s != "" {
+			var err error
+			if sizes, err = ParseSizes(s); err != nil {
+				writeError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		ctx, err := queryCtx()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		report, err := engine.Estimate(ctx, sizes)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		writeJSON(w, report)
+	}).Methods(http.MethodGet)
+
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	// the dashboard is usually served from another origin
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,2944 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: mirrorvault/gascost/v1/query.proto
+
+package types
+
+import (
+	context "context"
+	fmt "fmt"
+	types "github.com/cosmos/cosmos-sdk/types"
+	tx "github.com/cosmos/cosmos-sdk/types/tx"
+	_ "github.com/cosmos/gogoproto/gogoproto"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	io "io"
+	math "math"
+	math_bits "math/bits"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+// QueryEstimateStorageCostRequest is the request type of
+// Query/EstimateStorageCost.
+type QueryEstimateStorageCostRequest struct {
+	// sizes are the payload sizes in bytes, the default ones when empty.
+	Sizes []uint32 `protobuf:"varint,1,rep,packed,name=sizes,proto3" json:"sizes,omitempty"`
+}
+
+func (m *QueryEstimateStorageCostRequest) Reset()         { *m = QueryEstimateStorageCostRequest{} }
+func (m *QueryEstimateStorageCostRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryEstimateStorageCostRequest) ProtoMessage()    {}
+func (*QueryEstimateStorageCostRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{0}
+}
+func (m *QueryEstimateStorageCostRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryEstimateStorageCostRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryEstimateStorageCostRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryEstimateStorageCostRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryEstimateStorageCostRequest.Merge(m, src)
+}
+func (m *QueryEstimateStorageCostRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryEstimateStorageCostRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryEstimateStorageCostRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryEstimateStorageCostRequest proto.InternalMessageInfo
+
+func (m *QueryEstimateStorageCostRequest) GetSizes() []uint32 {
+	if m != nil {
+		return m.Sizes
+	}
+	return nil
+}
+
+// QueryEstimateStorageCostResponse is the response type of
+// Query/EstimateStorageCost.
+type QueryEstimateStorageCostResponse struct {
+	// base_fee and min_gas_price are the gas prices the fees are computed at,
+	// in the base denom.
+	BaseFee     types.DecCoin     `protobuf:"bytes,1,opt,name=base_fee,json=baseFee,proto3" json:"base_fee"`
+	MinGasPrice types.DecCoin     `protobuf:"bytes,2,opt,name=min_gas_price,json=minGasPrice,proto3" json:"min_gas_price"`
+	Estimates   []StorageEstimate `protobuf:"bytes,3,rep,name=estimates,proto3" json:"estimates"`
+}
+
+func (m *QueryEstimateStorageCostResponse) Reset()         { *m = QueryEstimateStorageCostResponse{} }
+func (m *QueryEstimateStorageCostResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryEstimateStorageCostResponse) ProtoMessage()    {}
+func (*QueryEstimateStorageCostResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{1}
+}
+func (m *QueryEstimateStorageCostResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryEstimateStorageCostResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryEstimateStorageCostResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryEstimateStorageCostResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryEstimateStorageCostResponse.Merge(m, src)
+}
+func (m *QueryEstimateStorageCostResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryEstimateStorageCostResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryEstimateStorageCostResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryEstimateStorageCostResponse proto.InternalMessageInfo
+
+func (m *QueryEstimateStorageCostResponse) GetBaseFee() types.DecCoin {
+	if m != nil {
+		return m.BaseFee
+	}
+	return types.DecCoin{}
+}
+
+func (m *QueryEstimateStorageCostResponse) GetMinGasPrice() types.DecCoin {
+	if m != nil {
+		return m.MinGasPrice
+	}
+	return types.DecCoin{}
+}
+
+func (m *QueryEstimateStorageCostResponse) GetEstimates() []StorageEstimate {
+	if m != nil {
+		return m.Estimates
+	}
+	return nil
+}
+
+// StorageEstimate is the cost of storing one payload through both paths.
+type StorageEstimate struct {
+	Size_  uint32      `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Evm    StorageCost `protobuf:"bytes,2,opt,name=evm,proto3" json:"evm"`
+	Cosmos StorageCost `protobuf:"bytes,3,opt,name=cosmos,proto3" json:"cosmos"`
+}
+
+func (m *StorageEstimate) Reset()         { *m = StorageEstimate{} }
+func (m *StorageEstimate) String() string { return proto.CompactTextString(m) }
+func (*StorageEstimate) ProtoMessage()    {}
+func (*StorageEstimate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{2}
+}
+func (m *StorageEstimate) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageEstimate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageEstimate.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageEstimate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageEstimate.Merge(m, src)
+}
+func (m *StorageEstimate) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageEstimate) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageEstimate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageEstimate proto.InternalMessageInfo
+
+func (m *StorageEstimate) GetSize_() uint32 {
+	if m != nil {
+		return m.Size_
+	}
+	return 0
+}
+
+func (m *StorageEstimate) GetEvm() StorageCost {
+	if m != nil {
+		return m.Evm
+	}
+	return StorageCost{}
+}
+
+func (m *StorageEstimate) GetCosmos() StorageCost {
+	if m != nil {
+		return m.Cosmos
+	}
+	return StorageCost{}
+}
+
+// StorageCost is the cost of storing a payload through one path.
+type StorageCost struct {
+	GasUsed          uint64        `protobuf:"varint,1,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	FeeAtBaseFee     types.DecCoin `protobuf:"bytes,2,opt,name=fee_at_base_fee,json=feeAtBaseFee,proto3" json:"fee_at_base_fee"`
+	FeeAtMinGasPrice types.DecCoin `protobuf:"bytes,3,opt,name=fee_at_min_gas_price,json=feeAtMinGasPrice,proto3" json:"fee_at_min_gas_price"`
+	BytesWritten     uint64        `protobuf:"varint,4,opt,name=bytes_written,json=bytesWritten,proto3" json:"bytes_written,omitempty"`
+}
+
+func (m *StorageCost) Reset()         { *m = StorageCost{} }
+func (m *StorageCost) String() string { return proto.CompactTextString(m) }
+func (*StorageCost) ProtoMessage()    {}
+func (*StorageCost) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{3}
+}
+func (m *StorageCost) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *StorageCost) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_StorageCost.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *StorageCost) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageCost.Merge(m, src)
+}
+func (m *StorageCost) XXX_Size() int {
+	return m.Size()
+}
+func (m *StorageCost) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageCost.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StorageCost proto.InternalMessageInfo
+
+func (m *StorageCost) GetGasUsed() uint64 {
+	if m != nil {
+		return m.GasUsed
+	}
+	return 0
+}
+
+func (m *StorageCost) GetFeeAtBaseFee() types.DecCoin {
+	if m != nil {
+		return m.FeeAtBaseFee
+	}
+	return types.DecCoin{}
+}
+
+func (m *StorageCost) GetFeeAtMinGasPrice() types.DecCoin {
+	if m != nil {
+		return m.FeeAtMinGasPrice
+	}
+	return types.DecCoin{}
+}
+
+func (m *StorageCost) GetBytesWritten() uint64 {
+	if m != nil {
+		return m.BytesWritten
+	}
+	return 0
+}
+
+// QueryQuoteFeeRequest is the request type of Query/QuoteFee. It holds the
+// tx to quote, either an unsigned Ethereum tx or a Cosmos tx.
+type QueryQuoteFeeRequest struct {
+	Ethereum *EthereumCall `protobuf:"bytes,1,opt,name=ethereum,proto3" json:"ethereum,omitempty"`
+	Cosmos   *CosmosTx     `protobuf:"bytes,2,opt,name=cosmos,proto3" json:"cosmos,omitempty"`
+}
+
+func (m *QueryQuoteFeeRequest) Reset()         { *m = QueryQuoteFeeRequest{} }
+func (m *QueryQuoteFeeRequest) String() string { return proto.CompactTextString(m) }
+func (*QueryQuoteFeeRequest) ProtoMessage()    {}
+func (*QueryQuoteFeeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{4}
+}
+func (m *QueryQuoteFeeRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryQuoteFeeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryQuoteFeeRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryQuoteFeeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryQuoteFeeRequest.Merge(m, src)
+}
+func (m *QueryQuoteFeeRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryQuoteFeeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryQuoteFeeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryQuoteFeeRequest proto.InternalMessageInfo
+
+func (m *QueryQuoteFeeRequest) GetEthereum() *EthereumCall {
+	if m != nil {
+		return m.Ethereum
+	}
+	return nil
+}
+
+func (m *QueryQuoteFeeRequest) GetCosmos() *CosmosTx {
+	if m != nil {
+		return m.Cosmos
+	}
+	return nil
+}
+
+// EthereumCall is an unsigned Ethereum tx, the eth_estimateGas call object it
+// is estimated with.
+type EthereumCall struct {
+	// from and to are hex addresses, to is empty for a contract creation.
+	From string `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To   string `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	// gas caps the estimate, the gas cap of the node when zero.
+	Gas uint64 `protobuf:"varint,3,opt,name=gas,proto3" json:"gas,omitempty"`
+	// value is the amount sent in wei, decimal or 0x prefixed hex.
+	Value string `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	// input is the 0x prefixed hex calldata.
+	Input string `protobuf:"bytes,5,opt,name=input,proto3" json:"input,omitempty"`
+}
+
+func (m *EthereumCall) Reset()         { *m = EthereumCall{} }
+func (m *EthereumCall) String() string { return proto.CompactTextString(m) }
+func (*EthereumCall) ProtoMessage()    {}
+func (*EthereumCall) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{5}
+}
+func (m *EthereumCall) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *EthereumCall) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_EthereumCall.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *EthereumCall) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EthereumCall.Merge(m, src)
+}
+func (m *EthereumCall) XXX_Size() int {
+	return m.Size()
+}
+func (m *EthereumCall) XXX_DiscardUnknown() {
+	xxx_messageInfo_EthereumCall.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EthereumCall proto.InternalMessageInfo
+
+func (m *EthereumCall) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *EthereumCall) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *EthereumCall) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *EthereumCall) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+func (m *EthereumCall) GetInput() string {
+	if m != nil {
+		return m.Input
+	}
+	return ""
+}
+
+// CosmosTx is a Cosmos tx, either encoded or the output of a tx command with
+// --generate-only. Signatures can be left out.
+type CosmosTx struct {
+	TxBytes []byte `protobuf:"bytes,1,opt,name=tx_bytes,json=txBytes,proto3" json:"tx_bytes,omitempty"`
+	Tx      *tx.Tx `protobuf:"bytes,2,opt,name=tx,proto3" json:"tx,omitempty"`
+}
+
+func (m *CosmosTx) Reset()         { *m = CosmosTx{} }
+func (m *CosmosTx) String() string { return proto.CompactTextString(m) }
+func (*CosmosTx) ProtoMessage()    {}
+func (*CosmosTx) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{6}
+}
+func (m *CosmosTx) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *CosmosTx) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_CosmosTx.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *CosmosTx) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CosmosTx.Merge(m, src)
+}
+func (m *CosmosTx) XXX_Size() int {
+	return m.Size()
+}
+func (m *CosmosTx) XXX_DiscardUnknown() {
+	xxx_messageInfo_CosmosTx.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CosmosTx proto.InternalMessageInfo
+
+func (m *CosmosTx) GetTxBytes() []byte {
+	if m != nil {
+		return m.TxBytes
+	}
+	return nil
+}
+
+func (m *CosmosTx) GetTx() *tx.Tx {
+	if m != nil {
+		return m.Tx
+	}
+	return nil
+}
+
+// QueryQuoteFeeResponse is the response type of Query/QuoteFee. Prices are
+// per gas.
+type QueryQuoteFeeResponse struct {
+	// kind is the kind of tx quoted, ethereum or cosmos.
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// gas is the gas limit to set: the eth_estimateGas result for Ethereum txs
+	// and the simulated gas used for Cosmos txs.
+	Gas          uint64    `protobuf:"varint,2,opt,name=gas,proto3" json:"gas,omitempty"`
+	BaseFee      FeeAmount `protobuf:"bytes,3,opt,name=base_fee,json=baseFee,proto3" json:"base_fee"`
+	SuggestedTip FeeAmount `protobuf:"bytes,4,opt,name=suggested_tip,json=suggestedTip,proto3" json:"suggested_tip"`
+	MinGasPrice  FeeAmount `protobuf:"bytes,5,opt,name=min_gas_price,json=minGasPrice,proto3" json:"min_gas_price"`
+	// gas_price is the base fee plus the tip, at least the min gas price.
+	GasPrice FeeAmount `protobuf:"bytes,6,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price"`
+	TotalFee FeeAmount `protobuf:"bytes,7,opt,name=total_fee,json=totalFee,proto3" json:"total_fee"`
+}
+
+func (m *QueryQuoteFeeResponse) Reset()         { *m = QueryQuoteFeeResponse{} }
+func (m *QueryQuoteFeeResponse) String() string { return proto.CompactTextString(m) }
+func (*QueryQuoteFeeResponse) ProtoMessage()    {}
+func (*QueryQuoteFeeResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{7}
+}
+func (m *QueryQuoteFeeResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *QueryQuoteFeeResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_QueryQuoteFeeResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *QueryQuoteFeeResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryQuoteFeeResponse.Merge(m, src)
+}
+func (m *QueryQuoteFeeResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *QueryQuoteFeeResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryQuoteFeeResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QueryQuoteFeeResponse proto.InternalMessageInfo
+
+func (m *QueryQuoteFeeResponse) GetKind() string {
+	if m != nil {
+		return m.Kind
+	}
+	return ""
+}
+
+func (m *QueryQuoteFeeResponse) GetGas() uint64 {
+	if m != nil {
+		return m.Gas
+	}
+	return 0
+}
+
+func (m *QueryQuoteFeeResponse) GetBaseFee() FeeAmount {
+	if m != nil {
+		return m.BaseFee
+	}
+	return FeeAmount{}
+}
+
+func (m *QueryQuoteFeeResponse) GetSuggestedTip() FeeAmount {
+	if m != nil {
+		return m.SuggestedTip
+	}
+	return FeeAmount{}
+}
+
+func (m *QueryQuoteFeeResponse) GetMinGasPrice() FeeAmount {
+	if m != nil {
+		return m.MinGasPrice
+	}
+	return FeeAmount{}
+}
+
+func (m *QueryQuoteFeeResponse) GetGasPrice() FeeAmount {
+	if m != nil {
+		return m.GasPrice
+	}
+	return FeeAmount{}
+}
+
+func (m *QueryQuoteFeeResponse) GetTotalFee() FeeAmount {
+	if m != nil {
+		return m.TotalFee
+	}
+	return FeeAmount{}
+}
+
+// FeeAmount is an amount of the EVM coin in both its units: the 6 decimals
+// bank denom and the 18 decimals denom of the EVM.
+type FeeAmount struct {
+	Base     types.DecCoin `protobuf:"bytes,1,opt,name=base,proto3" json:"base"`
+	Extended types.DecCoin `protobuf:"bytes,2,opt,name=extended,proto3" json:"extended"`
+}
+
+func (m *FeeAmount) Reset()         { *m = FeeAmount{} }
+func (m *FeeAmount) String() string { return proto.CompactTextString(m) }
+func (*FeeAmount) ProtoMessage()    {}
+func (*FeeAmount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e6255857406e6787, []int{8}
+}
+func (m *FeeAmount) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *FeeAmount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_FeeAmount.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *FeeAmount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FeeAmount.Merge(m, src)
+}
+func (m *FeeAmount) XXX_Size() int {
+	return m.Size()
+}
+func (m *FeeAmount) XXX_DiscardUnknown() {
+	xxx_messageInfo_FeeAmount.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FeeAmount proto.InternalMessageInfo
+
+func (m *FeeAmount) GetBase() types.DecCoin {
+	if m != nil {
+		return m.Base
+	}
+	return types.DecCoin{}
+}
+
+func (m *FeeAmount) GetExtended() types.DecCoin {
+	if m != nil {
+		return m.Extended
+	}
+	return types.DecCoin{}
+}
+
+func init() {
+	proto.RegisterType((*QueryEstimateStorageCostRequest)(nil), "mirrorvault.gascost.v1.QueryEstimateStorageCostRequest")
+	proto.RegisterType((*QueryEstimateStorageCostResponse)(nil), "mirrorvault.gascost.v1.QueryEstimateStorageCostResponse")
+	proto.RegisterType((*StorageEstimate)(nil), "mirrorvault.gascost.v1.StorageEstimate")
+	proto.RegisterType((*StorageCost)(nil), "mirrorvault.gascost.v1.StorageCost")
+	proto.RegisterType((*QueryQuoteFeeRequest)(nil), "mirrorvault.gascost.v1.QueryQuoteFeeRequest")
+	proto.RegisterType((*EthereumCall)(nil), "mirrorvault.gascost.v1.EthereumCall")
+	proto.RegisterType((*CosmosTx)(nil), "mirrorvault.gascost.v1.CosmosTx")
+	proto.RegisterType((*QueryQuoteFeeResponse)(nil), "mirrorvault.gascost.v1.QueryQuoteFeeResponse")
+	proto.RegisterType((*FeeAmount)(nil), "mirrorvault.gascost.v1.FeeAmount")
+}
+
+func init() {
+	proto.RegisterFile("mirrorvault/gascost/v1/query.proto", fileDescriptor_e6255857406e6787)
+}
+
+var fileDescriptor_e6255857406e6787 = []byte{
+	// 848 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0xcf, 0x6f, 0x1b, 0x45,
+	0x14, 0xce, 0xda, 0x4e, 0x62, 0xbf, 0xd8, 0xb4, 0x1a, 0x52, 0xe4, 0x5a, 0xc5, 0x35, 0x5b, 0x10,
+	0x11, 0xa2, 0xbb, 0x4a, 0x2a, 0xd1, 0x0a, 0x04, 0x22, 0x4e, 0x1b, 0x84, 0x5a, 0x24, 0xba, 0x04,
+	0x21, 0x71, 0xb1, 0x26, 0xf6, 0xf3, 0xb2, 0xc2, 0xbb, 0xb3, 0xd9, 0x79, 0x6b, 0x36, 0x1c, 0xe1,
+	0xc2, 0x91, 0x1f, 0x7f, 0x03, 0x88, 0x3f, 0xa5, 0xc7, 0x4a, 0x5c, 0x38, 0x21, 0x94, 0x70, 0xe4,
+	0xcc, 0x19, 0xcd, 0xec, 0x78, 0xe3, 0x5a, 0x71, 0xeb, 0xbd, 0xcd, 0xcc, 0x7b, 0xdf, 0x37, 0xef,
+	0x7d, 0xef, 0xcd, 0xdb, 0x05, 0x3b, 0x0c, 0x92, 0x44, 0x24, 0x53, 0x9e, 0x4e, 0xc8, 0xf5, 0xb9,
+	0x1c, 0x0a, 0x49, 0xee, 0x74, 0xd7, 0x3d, 0x49, 0x31, 0x39, 0x75, 0xe2, 0x44, 0x90, 0x60, 0xaf,
+	0xcc, 0xf9, 0x38, 0xc6, 0xc7, 0x99, 0xee, 0x76, 0xba, 0x43, 0x21, 0x43, 0x21, 0xdd, 0x63, 0x2e,
+	0xd1, 0x9d, 0xee, 0x1e, 0x23, 0xf1, 0x5d, 0x77, 0x28, 0x82, 0x28, 0xc7, 0x75, 0x3a, 0xc6, 0x4e,
+	0x59, 0x61, 0xa5, 0xcc, 0xd8, 0xb6, 0x7d, 0xe1, 0x0b, 0xbd, 0x74, 0xd5, 0xca, 0x9c, 0xde, 0xf0,
+	0x85, 0xf0, 0x27, 0xe8, 0xf2, 0x38, 0x70, 0x79, 0x14, 0x09, 0xe2, 0x14, 0x88, 0x48, 0xe6, 0x56,
+	0xfb, 0x2e, 0xdc, 0x7c, 0xac, 0xc2, 0x7a, 0x20, 0x29, 0x08, 0x39, 0xe1, 0x67, 0x24, 0x12, 0xee,
+	0xe3, 0x81, 0x90, 0xe4, 0xe1, 0x49, 0x8a, 0x92, 0xd8, 0x36, 0xac, 0xcb, 0xe0, 0x5b, 0x94, 0x6d,
+	0xab, 0x57, 0xdd, 0x69, 0x79, 0xf9, 0xc6, 0xfe, 0xcf, 0x82, 0xde, 0x72, 0xa4, 0x8c, 0x45, 0x24,
+	0x91, 0xbd, 0x0f, 0x75, 0x95, 0xc8, 0x60, 0x8c, 0xd8, 0xb6, 0x7a, 0xd6, 0xce, 0xd6, 0xde, 0x0d,
+	0x27, 0x4f, 0xc0, 0x51, 0xe7, 0x8e, 0x49, 0xc1, 0xb9, 0x8f, 0xc3, 0x03, 0x11, 0x44, 0xfd, 0xda,
+	0x93, 0xbf, 0x6e, 0xae, 0x79, 0x9b, 0xca, 0x76, 0x88, 0xc8, 0x0e, 0xa1, 0x15, 0x06, 0xd1, 0xc0,
+	0xe7, 0x72, 0x10, 0x27, 0xc1, 0x10, 0xdb, 0x95, 0x95, 0x39, 0xb6, 0xc2, 0x20, 0xfa, 0x88, 0xcb,
+	0x4f, 0x15, 0x8c, 0x3d, 0x84, 0x06, 0x9a, 0x28, 0x65, 0xbb, 0xda, 0xab, 0xee, 0x6c, 0xed, 0xbd,
+	0xe9, 0x5c, 0x5e, 0x00, 0xc7, 0xa4, 0x31, 0xcb, 0xca, 0xd0, 0x5d, 0xe0, 0xed, 0x5f, 0x2d, 0xb8,
+	0xb2, 0xe0, 0xc4, 0x18, 0xd4, 0x94, 0x2a, 0x3a, 0xc7, 0x96, 0xa7, 0xd7, 0xec, 0x3d, 0xa8, 0xe2,
+	0x34, 0x34, 0x21, 0xdf, 0x7a, 0xc1, 0x75, 0x4a, 0x35, 0x73, 0x95, 0x42, 0xb1, 0x7d, 0xd8, 0xc8,
+	0x73, 0x6c, 0x57, 0xcb, 0xe2, 0x0d, 0xd0, 0xfe, 0xd7, 0x82, 0xad, 0x39, 0x2b, 0xbb, 0x0e, 0x75,
+	0x25, 0x64, 0x2a, 0x71, 0xa4, 0xe3, 0xac, 0x79, 0x9b, 0x3e, 0x97, 0x9f, 0x4b, 0x1c, 0xb1, 0x8f,
+	0xe1, 0xca, 0x18, 0x71, 0xc0, 0x69, 0x50, 0x54, 0x6b, 0x75, 0xa5, 0x9b, 0x63, 0xc4, 0x7d, 0xea,
+	0x9b, 0x92, 0x79, 0xb0, 0x6d, 0xa8, 0x9e, 0xad, 0x5c, 0x75, 0x65, 0xbe, 0xab, 0x9a, 0xef, 0x93,
+	0xb9, 0xf2, 0xdd, 0x82, 0xd6, 0xf1, 0x29, 0xa1, 0x1c, 0x7c, 0x93, 0x04, 0x44, 0x18, 0xb5, 0x6b,
+	0x3a, 0xfc, 0xa6, 0x3e, 0xfc, 0x22, 0x3f, 0xb3, 0x7f, 0xb6, 0x60, 0x5b, 0xf7, 0xe3, 0xe3, 0x54,
+	0x90, 0x0a, 0x65, 0xd6, 0xbe, 0x1f, 0x42, 0x1d, 0xe9, 0x2b, 0x4c, 0x30, 0x0d, 0x4d, 0x0f, 0xbe,
+	0xbe, 0x4c, 0xcc, 0x07, 0xc6, 0xef, 0x80, 0x4f, 0x26, 0x5e, 0x81, 0x62, 0xf7, 0x8a, 0x62, 0xe4,
+	0xaa, 0xf4, 0x96, 0xe1, 0x0f, 0xb4, 0xd7, 0x51, 0x56, 0xd4, 0x20, 0x86, 0xe6, 0x3c, 0xa7, 0xea,
+	0x93, 0x71, 0x22, 0xf2, 0x38, 0x1a, 0x9e, 0x5e, 0xb3, 0x97, 0xa0, 0x42, 0x42, 0x33, 0x37, 0xbc,
+	0x0a, 0x09, 0x76, 0x15, 0xaa, 0x3e, 0xcf, 0xeb, 0x5e, 0xf3, 0xd4, 0x52, 0x3d, 0xc0, 0x29, 0x9f,
+	0xa4, 0xa8, 0xf3, 0x6e, 0x78, 0xf9, 0x46, 0x9d, 0x06, 0x51, 0x9c, 0x52, 0x7b, 0x3d, 0x3f, 0xd5,
+	0x1b, 0xfb, 0x11, 0xd4, 0x67, 0x51, 0xa8, 0x8a, 0x53, 0x36, 0xd0, 0x2a, 0xe9, 0x1b, 0x9b, 0xde,
+	0x26, 0x65, 0x7d, 0xb5, 0x65, 0x6f, 0x40, 0x85, 0x32, 0x93, 0xce, 0xb5, 0x59, 0x51, 0x28, 0x2b,
+	0x4a, 0x72, 0x94, 0x79, 0x15, 0xca, 0xec, 0xdf, 0xab, 0x70, 0x6d, 0x41, 0x54, 0xf3, 0xb2, 0x19,
+	0xd4, 0xbe, 0x0e, 0xa2, 0xd1, 0x2c, 0x13, 0xb5, 0x9e, 0x45, 0x5e, 0xb9, 0x88, 0xbc, 0x3f, 0xf7,
+	0xfe, 0xf3, 0x0e, 0x78, 0x6d, 0x99, 0x76, 0x87, 0x88, 0xfb, 0xa1, 0x48, 0x23, 0x5a, 0x1c, 0x02,
+	0x8f, 0xa0, 0x25, 0x53, 0xdf, 0x47, 0x49, 0x38, 0x1a, 0x50, 0x10, 0x6b, 0x15, 0x4a, 0x10, 0x35,
+	0x0b, 0xf4, 0x51, 0x10, 0xb3, 0x87, 0x8b, 0x23, 0x65, 0xbd, 0x1c, 0xdb, 0x33, 0x73, 0xe5, 0x3e,
+	0x34, 0x2e, 0x88, 0x36, 0xca, 0x11, 0xa9, 0xc7, 0x58, 0xb0, 0x90, 0x20, 0x3e, 0xd1, 0x2a, 0x6d,
+	0x96, 0x64, 0xd1, 0xc8, 0x43, 0x44, 0xfb, 0x7b, 0x0b, 0x1a, 0x85, 0x95, 0xbd, 0x03, 0x35, 0xa5,
+	0x5f, 0x89, 0xa1, 0xab, 0xfd, 0xd9, 0x07, 0x50, 0xc7, 0x8c, 0x30, 0x1a, 0xe1, 0xa8, 0xc4, 0x08,
+	0x28, 0x30, 0x7b, 0xbf, 0x55, 0x60, 0x5d, 0x37, 0x0c, 0xfb, 0xc1, 0x82, 0x97, 0x2f, 0xf9, 0x34,
+	0xb0, 0xbb, 0xcb, 0x52, 0x7b, 0xc1, 0x67, 0xa8, 0x73, 0xaf, 0x3c, 0xd0, 0xf4, 0xea, 0x4f, 0x16,
+	0xd4, 0x67, 0x0d, 0xcc, 0xde, 0x7e, 0x2e, 0xcd, 0xc2, 0xf0, 0xe8, 0xdc, 0x5e, 0xd1, 0x3b, 0xbf,
+	0xc9, 0xde, 0xf9, 0xee, 0x8f, 0x7f, 0x7e, 0xa9, 0xd8, 0xef, 0x5a, 0x6f, 0xd9, 0xaf, 0xba, 0x0b,
+	0x7f, 0x01, 0xea, 0x0f, 0x60, 0x8c, 0x78, 0xfb, 0x44, 0x81, 0xfa, 0x77, 0x9e, 0x9c, 0x75, 0xad,
+	0xa7, 0x67, 0x5d, 0xeb, 0xef, 0xb3, 0xae, 0xf5, 0xe3, 0x79, 0x77, 0xed, 0xe9, 0x79, 0x77, 0xed,
+	0xcf, 0xf3, 0xee, 0xda, 0x97, 0xd7, 0x2f, 0xfb, 0x7b, 0xa0, 0xd3, 0x18, 0xe5, 0xf1, 0x86, 0xfe,
+	0x66, 0xdf, 0xf9, 0x3f, 0x00, 0x00, 0xff, 0xff, 0xa7, 0xfb, 0x1b, 0xbd, 0x61, 0x08, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// QueryClient is the client API for Query service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type QueryClient interface {
+	// EstimateStorageCost compares the cost of storing payloads through the
+	// EVM and through a Cosmos module KVStore, on the queried state.
+	EstimateStorageCost(ctx context.Context, in *QueryEstimateStorageCostRequest, opts ...grpc.CallOption) (*QueryEstimateStorageCostResponse, error)
+	// QuoteFee simulates a tx and returns its gas and fee at the current gas
+	// prices.
+	QuoteFee(ctx context.Context, in *QueryQuoteFeeRequest, opts ...grpc.CallOption) (*QueryQuoteFeeResponse, error)
+}
+
+type queryClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewQueryClient(cc grpc1.ClientConn) QueryClient {
+	return &queryClient{cc}
+}
+
+func (c *queryClient) EstimateStorageCost(ctx context.Context, in *QueryEstimateStorageCostRequest, opts ...grpc.CallOption) (*QueryEstimateStorageCostResponse, error) {
+	out := new(QueryEstimateStorageCostResponse)
+	err := c.cc.Invoke(ctx, "/mirrorvault.gascost.v1.Query/EstimateStorageCost", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) QuoteFee(ctx context.Context, in *QueryQuoteFeeRequest, opts ...grpc.CallOption) (*QueryQuoteFeeResponse, error) {
+	out := new(QueryQuoteFeeResponse)
+	err := c.cc.Invoke(ctx, "/mirrorvault.gascost.v1.Query/QuoteFee", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// QueryServer is the server API for Query service.
+type QueryServer interface {
+	// EstimateStorageCost compares the cost of storing payloads through the
+	// EVM and through a Cosmos module KVStore, on the queried state.
+	EstimateStorageCost(context.Context, *QueryEstimateStorageCostRequest) (*QueryEstimateStorageCostResponse, error)
+	// QuoteFee simulates a tx and returns its gas and fee at the current gas
+	// prices.
+	QuoteFee(context.Context, *QueryQuoteFeeRequest) (*QueryQuoteFeeResponse, error)
+}
+
+// UnimplementedQueryServer can be embedded to have forward compatible implementations.
+type UnimplementedQueryServer struct {
+}
+
+func (*UnimplementedQueryServer) EstimateStorageCost(ctx context.Context, req *QueryEstimateStorageCostRequest) (*QueryEstimateStorageCostResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateStorageCost not implemented")
+}
+func (*UnimplementedQueryServer) QuoteFee(ctx context.Context, req *QueryQuoteFeeRequest) (*QueryQuoteFeeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QuoteFee not implemented")
+}
+
+func RegisterQueryServer(s grpc1.Server, srv QueryServer) {
+	s.RegisterService(&_Query_serviceDesc, srv)
+}
+
+func _Query_EstimateStorageCost_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryEstimateStorageCostRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).EstimateStorageCost(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mirrorvault.gascost.v1.Query/EstimateStorageCost",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).EstimateStorageCost(ctx, req.(*QueryEstimateStorageCostRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_QuoteFee_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryQuoteFeeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).QuoteFee(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/mirrorvault.gascost.v1.Query/QuoteFee",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).QuoteFee(ctx, req.(*QueryQuoteFeeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var Query_serviceDesc = _Query_serviceDesc
+var _Query_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "mirrorvault.gascost.v1.Query",
+	HandlerType: (*QueryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "EstimateStorageCost",
+			Handler:    _Query_EstimateStorageCost_Handler,
+		},
+		{
+			MethodName: "QuoteFee",
+			Handler:    _Query_QuoteFee_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "mirrorvault/gascost/v1/query.proto",
+}
+
+func (m *QueryEstimateStorageCostRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryEstimateStorageCostRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryEstimateStorageCostRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Sizes) > 0 {
+		dAtA2 := make([]byte, len(m.Sizes)*10)
+		var j1 int
+		for _, num := range m.Sizes {
+			for num >= 1<<7 {
+				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j1++
+			}
+			dAtA2[j1] = uint8(num)
+			j1++
+		}
+		i -= j1
+		copy(dAtA[i:], dAtA2[:j1])
+		i = encodeVarintQuery(dAtA, i, uint64(j1))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryEstimateStorageCostResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryEstimateStorageCostResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryEstimateStorageCostResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Estimates) > 0 {
+		for iNdEx := len(m.Estimates) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Estimates[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintQuery(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	{
+		size, err := m.MinGasPrice.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.BaseFee.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageEstimate) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageEstimate) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageEstimate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Cosmos.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.Evm.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.Size_ != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Size_))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StorageCost) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StorageCost) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StorageCost) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.BytesWritten != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.BytesWritten))
+		i--
+		dAtA[i] = 0x20
+	}
+	{
+		size, err := m.FeeAtMinGasPrice.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	{
+		size, err := m.FeeAtBaseFee.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	if m.GasUsed != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.GasUsed))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryQuoteFeeRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryQuoteFeeRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryQuoteFeeRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Cosmos != nil {
+		{
+			size, err := m.Cosmos.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Ethereum != nil {
+		{
+			size, err := m.Ethereum.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *EthereumCall) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *EthereumCall) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *EthereumCall) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Input) > 0 {
+		i -= len(m.Input)
+		copy(dAtA[i:], m.Input)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Input)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Gas != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Gas))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.To) > 0 {
+		i -= len(m.To)
+		copy(dAtA[i:], m.To)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.To)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.From) > 0 {
+		i -= len(m.From)
+		copy(dAtA[i:], m.From)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.From)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *CosmosTx) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *CosmosTx) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *CosmosTx) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Tx != nil {
+		{
+			size, err := m.Tx.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintQuery(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.TxBytes) > 0 {
+		i -= len(m.TxBytes)
+		copy(dAtA[i:], m.TxBytes)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.TxBytes)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryQuoteFeeResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryQuoteFeeResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryQuoteFeeResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.TotalFee.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x3a
+	{
+		size, err := m.GasPrice.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x32
+	{
+		size, err := m.MinGasPrice.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x2a
+	{
+		size, err := m.SuggestedTip.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x22
+	{
+		size, err := m.BaseFee.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x1a
+	if m.Gas != 0 {
+		i = encodeVarintQuery(dAtA, i, uint64(m.Gas))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Kind) > 0 {
+		i -= len(m.Kind)
+		copy(dAtA[i:], m.Kind)
+		i = encodeVarintQuery(dAtA, i, uint64(len(m.Kind)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *FeeAmount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *FeeAmount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *FeeAmount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	{
+		size, err := m.Extended.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0x12
+	{
+		size, err := m.Base.MarshalToSizedBuffer(dAtA[:i])
+		if err != nil {
+			return 0, err
+		}
+		i -= size
+		i = encodeVarintQuery(dAtA, i, uint64(size))
+	}
+	i--
+	dAtA[i] = 0xa
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintQuery(dAtA []byte, offset int, v uint64) int {
+	offset -= sovQuery(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *QueryEstimateStorageCostRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Sizes) > 0 {
+		l = 0
+		for _, e := range m.Sizes {
+			l += sovQuery(uint64(e))
+		}
+		n += 1 + sovQuery(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *QueryEstimateStorageCostResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.BaseFee.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.MinGasPrice.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	if len(m.Estimates) > 0 {
+		for _, e := range m.Estimates {
+			l = e.Size()
+			n += 1 + l + sovQuery(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StorageEstimate) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Size_ != 0 {
+		n += 1 + sovQuery(uint64(m.Size_))
+	}
+	l = m.Evm.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.Cosmos.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *StorageCost) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.GasUsed != 0 {
+		n += 1 + sovQuery(uint64(m.GasUsed))
+	}
+	l = m.FeeAtBaseFee.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.FeeAtMinGasPrice.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	if m.BytesWritten != 0 {
+		n += 1 + sovQuery(uint64(m.BytesWritten))
+	}
+	return n
+}
+
+func (m *QueryQuoteFeeRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Ethereum != nil {
+		l = m.Ethereum.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Cosmos != nil {
+		l = m.Cosmos.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *EthereumCall) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.From)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.To)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Gas != 0 {
+		n += 1 + sovQuery(uint64(m.Gas))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	l = len(m.Input)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *CosmosTx) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.TxBytes)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Tx != nil {
+		l = m.Tx.Size()
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryQuoteFeeResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Kind)
+	if l > 0 {
+		n += 1 + l + sovQuery(uint64(l))
+	}
+	if m.Gas != 0 {
+		n += 1 + sovQuery(uint64(m.Gas))
+	}
+	l = m.BaseFee.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.SuggestedTip.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.MinGasPrice.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.GasPrice.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.TotalFee.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func (m *FeeAmount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = m.Base.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	l = m.Extended.Size()
+	n += 1 + l + sovQuery(uint64(l))
+	return n
+}
+
+func sovQuery(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozQuery(x uint64) (n int) {
+	return sovQuery(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (m *QueryEstimateStorageCostRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryEstimateStorageCostRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryEstimateStorageCostRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowQuery
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Sizes = append(m.Sizes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowQuery
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthQuery
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthQuery
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.Sizes) == 0 {
+					m.Sizes = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowQuery
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Sizes = append(m.Sizes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sizes", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryEstimateStorageCostResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryEstimateStorageCostResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryEstimateStorageCostResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BaseFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinGasPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinGasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Estimates", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Estimates = append(m.Estimates, StorageEstimate{})
+			if err := m.Estimates[len(m.Estimates)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageEstimate) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageEstimate: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageEstimate: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Size_", wireType)
+			}
+			m.Size_ = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Size_ |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evm", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Evm.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cosmos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Cosmos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *StorageCost) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: StorageCost: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: StorageCost: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasUsed", wireType)
+			}
+			m.GasUsed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.GasUsed |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeAtBaseFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.FeeAtBaseFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FeeAtMinGasPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.FeeAtMinGasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BytesWritten", wireType)
+			}
+			m.BytesWritten = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.BytesWritten |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryQuoteFeeRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryQuoteFeeRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryQuoteFeeRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Ethereum", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Ethereum == nil {
+				m.Ethereum = &EthereumCall{}
+			}
+			if err := m.Ethereum.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Cosmos", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Cosmos == nil {
+				m.Cosmos = &CosmosTx{}
+			}
+			if err := m.Cosmos.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *EthereumCall) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: EthereumCall: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: EthereumCall: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field From", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.From = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field To", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.To = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gas", wireType)
+			}
+			m.Gas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Value", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Value = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Input", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Input = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *CosmosTx) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: CosmosTx: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: CosmosTx: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TxBytes", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.TxBytes = append(m.TxBytes[:0], dAtA[iNdEx:postIndex]...)
+			if m.TxBytes == nil {
+				m.TxBytes = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Tx", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Tx == nil {
+				m.Tx = &tx.Tx{}
+			}
+			if err := m.Tx.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *QueryQuoteFeeResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: QueryQuoteFeeResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: QueryQuoteFeeResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Kind", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Kind = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Gas", wireType)
+			}
+			m.Gas = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Gas |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BaseFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.BaseFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SuggestedTip", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.SuggestedTip.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinGasPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.MinGasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GasPrice", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.GasPrice.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field TotalFee", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.TotalFee.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *FeeAmount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: FeeAmount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: FeeAmount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Base", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Base.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Extended", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthQuery
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if err := m.Extended.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipQuery(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthQuery
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func skipQuery(dAtA []byte) (n int, err error) {
+	l := len(dAtA)
+	iNdEx := 0
+	depth := 0
+	for iNdEx < l {
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return 0, ErrIntOverflowQuery
+			}
+			if iNdEx >= l {
+				return 0, io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= (uint64(b) & 0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		wireType := int(wire & 0x7)
+		switch wireType {
+		case 0:
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				iNdEx++
+				if dAtA[iNdEx-1] < 0x80 {
+					break
+				}
+			}
+		case 1:
+			iNdEx += 8
+		case 2:
+			var length int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return 0, ErrIntOverflowQuery
+				}
+				if iNdEx >= l {
+					return 0, io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				length |= (int(b) & 0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if length < 0 {
+				return 0, ErrInvalidLengthQuery
+			}
+			iNdEx += length
+		case 3:
+			depth++
+		case 4:
+			if depth == 0 {
+				return 0, ErrUnexpectedEndOfGroupQuery
+			}
+			depth--
+		case 5:
+			iNdEx += 4
+		default:
+			return 0, fmt.Errorf("proto: illegal wireType %d", wireType)
+		}
+		if iNdEx < 0 {
+			return 0, ErrInvalidLengthQuery
+		}
+		if depth == 0 {
+			return iNdEx, nil
+		}
+	}
+	return 0, io.ErrUnexpectedEOF
+}
+
+var (
+	ErrInvalidLengthQuery        = fmt.Errorf("proto: negative length found during unmarshaling")
+	ErrIntOverflowQuery          = fmt.Errorf("proto: integer overflow")
+	ErrUnexpectedEndOfGroupQuery = fmt.Errorf("proto: unexpected end of group")
+)
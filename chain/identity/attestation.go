@@ -0,0 +1,198 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+)
+
+const (
+	// ADR036MsgType is the amino type of the single message in an ADR-036 sign doc.
+	ADR036MsgType = "sign/MsgSignData"
+
+	// PubKeyTypeEthSecp256k1 is the amino JSON type Keplr reports for eth_secp256k1 keys.
+	PubKeyTypeEthSecp256k1 = "ethermint/PubKeyEthSecp256k1"
+	// PubKeyTypeSecp256k1 is the amino JSON type Keplr reports for Cosmos secp256k1 keys.
+	PubKeyTypeSecp256k1 = "tendermint/PubKeySecp256k1"
+)
+
+// Attestation bundles the two signatures an account produced over the same
+// payload: an EIP-191 personal_sign signature from an Ethereum wallet and an
+// ADR-036 off-chain signature from a Cosmos wallet.
+type Attestation struct {
+	// Payload is the exact text both wallets signed.
+	Payload string `json:"payload"`
+	// EthSignature is the 65 byte [R || S || V] signature returned by
+	// personal_sign, hex encoded with a 0x prefix.
+	EthSignature string `json:"eth_signature"`
+	// CosmosSignature is the signArbitrary result returned by Keplr.
+	CosmosSignature CosmosSignature `json:"cosmos_signature"`
+}
+
+// CosmosSignature is an ADR-036 signature in the shape Keplr's signArbitrary
+// returns it.
+type CosmosSignature struct {
+	Signer    string `json:"signer"`
+	PubKey    PubKey `json:"pub_key"`
+	Signature string `json:"signature"`
+}
+
+// PubKey is an amino JSON encoded public key.
+type PubKey struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Result reports the addresses recovered from an attestation.
+type Result struct {
+	EthAddress    string `json:"eth_address"`
+	CosmosAddress string `json:"cosmos_address"`
+	// Match is true when both signatures resolve to the same 20 address bytes.
+	Match bool `json:"match"`
+}
+
+// Verify checks both signatures of the attestation and reports whether they
+// were produced by the same account. An error is returned only when a
+// signature is malformed or invalid; a valid pair of signatures from two
+// different accounts yields Match == false.
+func Verify(att Attestation) (Result, error) {
+	payload := []byte(att.Payload)
+
+	ethSig, err := hexutil.Decode(att.EthSignature)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid eth signature encoding: %w", err)
+	}
+
+	ethAddr, err := RecoverEIP191(payload, ethSig)
+	if err != nil {
+		return Result{}, err
+	}
+
+	pubKey, err := att.CosmosSignature.PubKey.Decode()
+	if err != nil {
+		return Result{}, err
+	}
+
+	cosmosSig, err := base64.StdEncoding.DecodeString(att.CosmosSignature.Signature)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid cosmos signature encoding: %w", err)
+	}
+
+	signer, err := sdk.AccAddressFromBech32(att.CosmosSignature.Signer)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid cosmos signer: %w", err)
+	}
+
+	if err := VerifyADR036(signer, payload, pubKey, cosmosSig); err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		EthAddress:    ethAddr.Hex(),
+		CosmosAddress: signer.String(),
+		Match:         bytes.Equal(ethAddr.Bytes(), signer.Bytes()),
+	}, nil
+}
+
+// RecoverEIP191 returns the address that produced sig over the EIP-191
+// "personal_sign" hash of payload. Both the 0/1 and the 27/28 recovery id
+// conventions are accepted.
+func RecoverEIP191(payload, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("invalid eth signature length: expected %d, got %d", crypto.SignatureLength, len(sig))
+	}
+
+	sig = bytes.Clone(sig)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+
+	pubKey, err := crypto.SigToPub(accounts.TextHash(payload), sig)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("failed to recover eth signer: %w", err)
+	}
+
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// VerifyADR036 checks that sig is a valid ADR-036 signature by pubKey over
+// payload and that pubKey belongs to signer.
+func VerifyADR036(signer sdk.AccAddress, payload []byte, pubKey cryptotypes.PubKey, sig []byte) error {
+	if !bytes.Equal(pubKey.Address(), signer) {
+		return fmt.Errorf("public key does not belong to signer %s", signer)
+	}
+
+	if !pubKey.VerifySignature(ADR036SignBytes(signer, payload), sig) {
+		return errors.New("invalid cosmos signature")
+	}
+
+	return nil
+}
+
+// ADR036SignBytes returns the canonical amino JSON sign doc that ADR-036
+// wallets sign for an arbitrary payload.
+func ADR036SignBytes(signer sdk.AccAddress, payload []byte) []byte {
+	doc := map[string]any{
+		"account_number": "0",
+		"chain_id":       "",
+		"fee": map[string]any{
+			"amount": []any{},
+			"gas":    "0",
+		},
+		"memo": "",
+		"msgs": []any{
+			map[string]any{
+				"type": ADR036MsgType,
+				"value": map[string]any{
+					"data":   base64.StdEncoding.EncodeToString(payload),
+					"signer": signer.String(),
+				},
+			},
+		},
+		"sequence": "0",
+	}
+
+	// encoding/json sorts map keys, which yields the canonical form.
+	bz, err := json.Marshal(doc)
+	if err != nil {
+		panic(err)
+	}
+
+	return bz
+}
+
+// Decode returns the public key described by the amino JSON encoding.
+func (pk PubKey) Decode() (cryptotypes.PubKey, error) {
+	key, err := base64.StdEncoding.DecodeString(pk.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key encoding: %w", err)
+	}
+
+	switch pk.Type {
+	case PubKeyTypeEthSecp256k1:
+		if len(key) != ethsecp256k1.PubKeySize {
+			return nil, fmt.Errorf("invalid public key length: expected %d, got %d", ethsecp256k1.PubKeySize, len(key))
+		}
+		return &ethsecp256k1.PubKey{Key: key}, nil
+	case PubKeyTypeSecp256k1:
+		if len(key) != secp256k1.PubKeySize {
+			return nil, fmt.Errorf("invalid public key length: expected %d, got %d", secp256k1.PubKeySize, len(key))
+		}
+		return &secp256k1.PubKey{Key: key}, nil
+	default:
+		return nil, fmt.Errorf("unsupported public key type %q", pk.Type)
+	}
+}
@@ -0,0 +1,107 @@
+package identity_test
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+
+	_ "mirrorvault/app" // sets the mirror bech32 prefixes
+	"mirrorvault/identity"
+	"mirrorvault/identity/types"
+)
+
+// attest signs payload the way MetaMask (personal_sign) and Keplr
+// (signArbitrary) would with the given key.
+func attest(t *testing.T, ethKey, cosmosKey *ethsecp256k1.PrivKey, payload string) identity.Attestation {
+	t.Helper()
+
+	ethSig, err := ethKey.Sign(accounts.TextHash([]byte(payload)))
+	require.NoError(t, err)
+	ethSig[64] += 27 // MetaMask reports V as 27/28
+
+	signer := sdk.AccAddress(cosmosKey.PubKey().Address())
+	cosmosSig, err := cosmosKey.Sign(identity.ADR036SignBytes(signer, []byte(payload)))
+	require.NoError(t, err)
+
+	return identity.Attestation{
+		Payload:      payload,
+		EthSignature: hexutil.Encode(ethSig),
+		CosmosSignature: identity.CosmosSignature{
+			Signer: signer.String(),
+			PubKey: identity.PubKey{
+				Type:  identity.PubKeyTypeEthSecp256k1,
+				Value: base64.StdEncoding.EncodeToString(cosmosKey.PubKey().Bytes()),
+			},
+			Signature: base64.StdEncoding.EncodeToString(cosmosSig),
+		},
+	}
+}
+
+func TestVerify(t *testing.T) {
+	key, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	other, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+
+	t.Run("same account", func(t *testing.T) {
+		res, err := identity.Verify(attest(t, key, key, "mirror vault identity"))
+		require.NoError(t, err)
+		require.True(t, res.Match)
+		require.Equal(t, sdk.AccAddress(key.PubKey().Address()).String(), res.CosmosAddress)
+	})
+
+	t.Run("different accounts", func(t *testing.T) {
+		res, err := identity.Verify(attest(t, key, other, "mirror vault identity"))
+		require.NoError(t, err)
+		require.False(t, res.Match)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		att := attest(t, key, key, "mirror vault identity")
+		att.CosmosSignature.Signature = attest(t, key, key, "something else").CosmosSignature.Signature
+		_, err := identity.Verify(att)
+		require.ErrorContains(t, err, "invalid cosmos signature")
+	})
+
+	t.Run("foreign public key", func(t *testing.T) {
+		att := attest(t, key, key, "mirror vault identity")
+		att.CosmosSignature.PubKey.Value = base64.StdEncoding.EncodeToString(other.PubKey().Bytes())
+		_, err := identity.Verify(att)
+		require.ErrorContains(t, err, "does not belong to signer")
+	})
+}
+
+func TestQueryServer(t *testing.T) {
+	key, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+
+	att := attest(t, key, key, "mirror vault identity")
+	req := &types.QueryVerifyAttestationRequest{
+		Payload:      att.Payload,
+		EthSignature: att.EthSignature,
+		CosmosSignature: &types.CosmosSignature{
+			Signer:    att.CosmosSignature.Signer,
+			PubKey:    &types.PubKey{Type: att.CosmosSignature.PubKey.Type, Value: att.CosmosSignature.PubKey.Value},
+			Signature: att.CosmosSignature.Signature,
+		},
+	}
+
+	res, err := identity.NewQueryServer().VerifyAttestation(context.Background(), req)
+	require.NoError(t, err)
+	require.True(t, res.Match)
+	require.Equal(t, sdk.AccAddress(key.PubKey().Address()).String(), res.CosmosAddress)
+
+	req.CosmosSignature = nil
+	_, err = identity.NewQueryServer().VerifyAttestation(context.Background(), req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
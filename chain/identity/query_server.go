@@ -0,0 +1,45 @@
+package identity
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"mirrorvault/identity/types"
+)
+
+type queryServer struct{}
+
+var _ types.QueryServer = queryServer{}
+
+// NewQueryServer returns the gRPC query server verifying attestations.
+func NewQueryServer() types.QueryServer {
+	return queryServer{}
+}
+
+// VerifyAttestation implements types.QueryServer.
+func (queryServer) VerifyAttestation(_ context.Context, req *types.QueryVerifyAttestationRequest) (*types.QueryVerifyAttestationResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	att := Attestation{Payload: req.Payload, EthSignature: req.EthSignature}
+	if sig := req.CosmosSignature; sig != nil {
+		att.CosmosSignature = CosmosSignature{Signer: sig.Signer, Signature: sig.Signature}
+		if sig.PubKey != nil {
+			att.CosmosSignature.PubKey = PubKey{Type: sig.PubKey.Type, Value: sig.PubKey.Value}
+		}
+	}
+
+	res, err := Verify(att)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &types.QueryVerifyAttestationResponse{
+		EthAddress:    res.EthAddress,
+		CosmosAddress: res.CosmosAddress,
+		Match:         res.Match,
+	}, nil
+}
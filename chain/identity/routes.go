@@ -0,0 +1,39 @@
+package identity
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// VerifyRoute is the API server path that verifies an attestation.
+const VerifyRoute = "/mirrorvault/identity/v1/verify"
+
+// RegisterRoutes registers the attestation endpoints on the API server router.
+func RegisterRoutes(rtr *mux.Router) {
+	rtr.HandleFunc(VerifyRoute, verifyHandler).Methods(http.MethodPost)
+}
+
+func verifyHandler(w http.ResponseWriter, req *http.Request) {
+	var att Attestation
+	if err := json.NewDecoder(req.Body).Decode(&att); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := Verify(att)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(res)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
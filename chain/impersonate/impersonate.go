@@ -0,0 +1,100 @@
+// Package impersonate lets a testnet run the Ethereum txs of addresses whose
+// keys it doesn't have, as anvil_impersonateAccount does, so a copy of a
+// network state can be exercised as its accounts.
+//
+// The EVM takes the sender of a tx from its signature. For a MsgEthereumTx
+// from an impersonated address, Decorator sets the sender go-ethereum caches
+// on the tx to that address, so the signature, by any key, is not checked
+// and the EVM runs the tx as the address. The impersonate_sendTransaction
+// JSON-RPC method sends such txs.
+//
+// Only the nodes impersonating the address accept its txs, so a network must
+// have a single validator to impersonate.
+package impersonate
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/spf13/cast"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// FlagImpersonate is the app option, and app.toml key, of the impersonated
+// addresses.
+const FlagImpersonate = "impersonate"
+
+// Addresses returns the impersonated addresses of the app options, a list or
+// a comma separated string of 0x addresses.
+func Addresses(appOpts servertypes.AppOptions) ([]common.Address, error) {
+	var values []string
+	for _, value := range cast.ToStringSlice(appOpts.Get(FlagImpersonate)) {
+		values = append(values, strings.Split(value, ",")...)
+	}
+
+	var addresses []common.Address
+	for _, value := range values {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		if !common.IsHexAddress(value) {
+			return nil, fmt.Errorf("invalid impersonated address %q", value)
+		}
+		addresses = append(addresses, common.HexToAddress(value))
+	}
+
+	return addresses, nil
+}
+
+// Decorator is the ante decorator making the EVM run the txs of impersonated
+// addresses as them. It must come before the EVM signature verification.
+type Decorator struct {
+	addresses map[common.Address]bool
+}
+
+// NewDecorator returns the decorator impersonating addresses.
+func NewDecorator(addresses []common.Address) Decorator {
+	d := Decorator{addresses: make(map[common.Address]bool, len(addresses))}
+	for _, addr := range addresses {
+		d.addresses[addr] = true
+	}
+	return d
+}
+
+// AnteHandle sets the sender of the Ethereum txs from impersonated addresses.
+func (d Decorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	signer := ethtypes.MakeSigner(evmtypes.GetEthChainConfig(), big.NewInt(ctx.BlockHeight()), uint64(ctx.BlockTime().Unix())) //#nosec G115 -- block time is positive
+
+	for _, msg := range tx.GetMsgs() {
+		ethMsg, ok := msg.(*evmtypes.MsgEthereumTx)
+		if !ok || !d.addresses[ethMsg.GetSender()] {
+			continue
+		}
+		// go-ethereum keeps the sender with the signer it came from, and
+		// gives it back to equal signers
+		if _, err := ethtypes.Sender(impersonatingSigner{Signer: signer, from: ethMsg.GetSender()}, ethMsg.AsTransaction()); err != nil {
+			return ctx, err
+		}
+	}
+
+	return next(ctx, tx, simulate)
+}
+
+// impersonatingSigner is a signer whose sender of any tx is from. It equals
+// the signer it wraps.
+type impersonatingSigner struct {
+	ethtypes.Signer
+	from common.Address
+}
+
+// Sender returns from.
+func (s impersonatingSigner) Sender(*ethtypes.Transaction) (common.Address, error) {
+	return s.from, nil
+}
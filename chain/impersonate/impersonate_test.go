@@ -0,0 +1,100 @@
+package impersonate_test
+
+import (
+	"math/big"
+	"testing"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/impersonate"
+	"mirrorvault/predeploy"
+	"mirrorvault/testutil/apptest"
+)
+
+func TestAddresses(t *testing.T) {
+	alice := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	bob := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e20d17dc79C")
+
+	for _, value := range []any{
+		"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266, 0x70997970c51812dc3a010c7d01b50e20d17dc79c",
+		[]string{"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", "0x70997970C51812dc3A010C7d01b50e20d17dc79C", ""},
+	} {
+		addresses, err := impersonate.Addresses(simtestutil.AppOptionsMap{impersonate.FlagImpersonate: value})
+		require.NoError(t, err)
+		require.Equal(t, []common.Address{alice, bob}, addresses)
+	}
+
+	addresses, err := impersonate.Addresses(simtestutil.AppOptionsMap{})
+	require.NoError(t, err)
+	require.Empty(t, addresses)
+
+	_, err = impersonate.Addresses(simtestutil.AppOptionsMap{impersonate.FlagImpersonate: "mirror1qqqq"})
+	require.Error(t, err)
+}
+
+// TestDecorator sends txs signed by another key through the ante handler
+// and the EVM of an in-memory app impersonating an address.
+func TestDecorator(t *testing.T) {
+	impersonated := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	a, ctx, _ := apptest.Setup(t, simtestutil.AppOptionsMap{
+		impersonate.FlagImpersonate: impersonated.Hex(),
+	})
+	ctx = ctx.WithConsensusParams(cmtproto.ConsensusParams{Block: &cmtproto.BlockParams{MaxGas: 10_000_000}})
+
+	// the impersonated address has no funds, so the txs are free
+	feemarketParams := a.FeeMarketKeeper.GetParams(ctx)
+	feemarketParams.NoBaseFee = true
+	feemarketParams.BaseFee = math.LegacyZeroDec()
+	require.NoError(t, a.FeeMarketKeeper.SetParams(ctx, feemarketParams))
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	gate := predeploy.VaultGateArtifact()
+	data, err := gate.ABI.Pack("payToUnlock")
+	require.NoError(t, err)
+	newTx := func(from common.Address) *evmtypes.MsgEthereumTx {
+		signer := ethtypes.LatestSignerForChainID(evmtypes.GetEthChainConfig().ChainID)
+		signed, err := ethtypes.SignTx(ethtypes.NewTx(&ethtypes.LegacyTx{
+			GasPrice: big.NewInt(0),
+			Gas:      100_000,
+			To:       &predeploy.VaultGateAddress,
+			Data:     data,
+		}), signer, key)
+		require.NoError(t, err)
+
+		msg := &evmtypes.MsgEthereumTx{}
+		msg.FromEthereumTx(signed)
+		msg.From = from.Bytes()
+		return msg
+	}
+
+	// the key signs as the impersonated address
+	msg := newTx(impersonated)
+	tx, err := msg.BuildTx(a.TxConfig().NewTxBuilder(), evmtypes.GetEVMCoinDenom())
+	require.NoError(t, err)
+	newCtx, err := a.AnteHandler()(ctx, tx, false)
+	require.NoError(t, err)
+	res, err := a.EVMKeeper.EthereumTx(newCtx, msg)
+	require.NoError(t, err)
+	require.Empty(t, res.VmError)
+	require.Len(t, res.Logs, 1)
+	require.Equal(t, common.BytesToHash(impersonated.Bytes()).Hex(), res.Logs[0].Topics[1])
+	require.Equal(t, uint64(1), a.EVMKeeper.GetNonce(ctx, impersonated))
+
+	// and not as other addresses
+	msg = newTx(common.HexToAddress("0x2222222222222222222222222222222222222222"))
+	tx, err = msg.BuildTx(a.TxConfig().NewTxBuilder(), evmtypes.GetEVMCoinDenom())
+	require.NoError(t, err)
+	_, err = a.AnteHandler()(ctx, tx, false)
+	require.ErrorContains(t, err, "sender verification failed")
+}
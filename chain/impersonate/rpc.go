@@ -0,0 +1,124 @@
+package impersonate
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/server"
+
+	evmmempool "github.com/cosmos/evm/mempool"
+	"github.com/cosmos/evm/rpc"
+	"github.com/cosmos/evm/rpc/backend"
+	"github.com/cosmos/evm/rpc/stream"
+	servertypes "github.com/cosmos/evm/server/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// Namespace is the JSON-RPC namespace of the API, which json-rpc.api of
+// app.toml must list.
+const Namespace = "impersonate"
+
+func init() {
+	if err := rpc.RegisterAPINamespace(Namespace, newAPIs); err != nil {
+		panic(err)
+	}
+}
+
+// newAPIs returns the API of the node, which impersonates the addresses of
+// its app options.
+func newAPIs(
+	ctx *server.Context,
+	clientCtx client.Context,
+	_ *stream.RPCStream,
+	allowUnprotectedTxs bool,
+	indexer servertypes.EVMTxIndexer,
+	mempool *evmmempool.ExperimentalEVMMempool,
+) []gethrpc.API {
+	addresses, err := Addresses(ctx.Viper)
+	if err != nil {
+		panic(err)
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+
+	return []gethrpc.API{{
+		Namespace: Namespace,
+		Version:   "1.0",
+		Service: &API{
+			backend:   backend.NewBackend(ctx, ctx.Logger, clientCtx, allowUnprotectedTxs, indexer, mempool),
+			decorator: NewDecorator(addresses),
+			key:       key,
+		},
+		Public: true,
+	}}
+}
+
+// API is the impersonate JSON-RPC API.
+type API struct {
+	backend   *backend.Backend
+	decorator Decorator
+	// key signs the txs, whose signature the node doesn't check.
+	key *ecdsa.PrivateKey
+}
+
+// SendTransaction sends a tx from an impersonated address, as
+// eth_sendTransaction does from a key of the node.
+func (api *API) SendTransaction(args evmtypes.TransactionArgs) (common.Hash, error) {
+	from := args.GetFrom()
+	if !api.decorator.addresses[from] {
+		return common.Hash{}, fmt.Errorf("%s is not impersonated", from)
+	}
+
+	args, err := api.backend.SetTxDefaults(args)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	bn, err := api.backend.BlockNumber()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	header, err := api.backend.CurrentHeader()
+	if err != nil {
+		return common.Hash{}, err
+	}
+	signer := ethtypes.MakeSigner(api.backend.ChainConfig(), new(big.Int).SetUint64(uint64(bn)), header.Time)
+
+	signed, err := ethtypes.SignTx(evmtypes.NewTxFromArgs(&args).AsTransaction(), signer, api.key)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	msg := &evmtypes.MsgEthereumTx{}
+	msg.FromEthereumTx(signed)
+	msg.From = from.Bytes()
+	if err := msg.ValidateBasic(); err != nil {
+		return common.Hash{}, err
+	}
+
+	tx, err := msg.BuildTx(api.backend.ClientCtx.TxConfig.NewTxBuilder(), evmtypes.GetEVMCoinDenom())
+	if err != nil {
+		return common.Hash{}, err
+	}
+	txBytes, err := api.backend.ClientCtx.TxConfig.TxEncoder()(tx)
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	rsp, err := api.backend.ClientCtx.WithBroadcastMode(flags.BroadcastSync).BroadcastTx(txBytes)
+	if rsp != nil && rsp.Code != 0 {
+		err = errorsmod.ABCIError(rsp.Codespace, rsp.Code, rsp.RawLog)
+	}
+
+	return signed.Hash(), err
+}
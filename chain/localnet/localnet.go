@@ -0,0 +1,333 @@
+package localnet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	"github.com/cosmos/go-bip39"
+	"github.com/ethereum/go-ethereum/common"
+
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	evmhd "github.com/cosmos/evm/crypto/hd"
+
+	"mirrorvault/app"
+	"mirrorvault/network"
+	"mirrorvault/pairs"
+)
+
+// Options are the inputs of Init.
+type Options struct {
+	Spec Spec
+	// Config is the CometBFT config of the node, rooted at its home.
+	Config *cmtcfg.Config
+	// Keyring receives the keys of the accounts.
+	Keyring      keyring.Keyring
+	Codec        codec.Codec
+	TxConfig     client.TxConfig
+	BasicManager module.BasicManager
+}
+
+// Result describes an initialized localnet.
+type Result struct {
+	ChainID  string       `json:"chain_id"`
+	NodeID   string       `json:"node_id"`
+	Accounts []AccountKey `json:"accounts"`
+}
+
+// AccountKey is a genesis account. Mnemonic is only set for keys Init created.
+type AccountKey struct {
+	Name          string `json:"name"`
+	EthAddress    string `json:"eth_address"`
+	CosmosAddress string `json:"cosmos_address"`
+	Mnemonic      string `json:"mnemonic,omitempty"`
+}
+
+// Init creates the keys of the spec accounts, the node validator files and a
+// genesis file funding the accounts, with the gentx of the validator. Keys
+// already in the keyring are reused.
+func Init(ctx context.Context, opts Options) (*Result, error) {
+	spec := opts.Spec
+	if spec.DefaultDenom != app.BaseDenom {
+		return nil, fmt.Errorf("default_denom is %s, but the EVM runs on %s", spec.DefaultDenom, app.BaseDenom)
+	}
+
+	chainID := spec.ChainID()
+	if chainID == "" {
+		chainID = network.MustGet(network.Localnet).ChainID
+	}
+
+	res := &Result{ChainID: chainID}
+
+	var (
+		genAccounts []authtypes.GenesisAccount
+		genBalances []banktypes.Balance
+	)
+	for _, acc := range spec.Accounts {
+		key, err := accountKey(opts.Keyring, acc)
+		if err != nil {
+			return nil, err
+		}
+		res.Accounts = append(res.Accounts, key)
+
+		addr := sdk.MustAccAddressFromBech32(key.CosmosAddress)
+		coins, err := acc.ParseCoins()
+		if err != nil {
+			return nil, err
+		}
+
+		genAccounts = append(genAccounts, authtypes.NewBaseAccount(addr, nil, 0, 0))
+		genBalances = append(genBalances, banktypes.Balance{Address: addr.String(), Coins: coins})
+	}
+
+	for _, dir := range []string{filepath.Dir(opts.Config.GenesisFile()), filepath.Dir(opts.Config.PrivValidatorStateFile())} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+
+	nodeID, valPubKey, err := genutil.InitializeNodeValidatorFiles(opts.Config)
+	if err != nil {
+		return nil, err
+	}
+	res.NodeID = nodeID
+
+	appState, err := genesisState(opts, genAccounts, genBalances)
+	if err != nil {
+		return nil, err
+	}
+
+	genTx, err := signGenTx(ctx, opts, chainID, nodeID, valPubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	appState, err = genutil.SetGenTxsInAppGenesisState(opts.Codec, opts.TxConfig.TxJSONEncoder(), appState, []sdk.Tx{genTx})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := writeGenesis(opts, chainID, appState); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// accountKey returns the key of acc, creating it in the keyring if needed.
+func accountKey(kr keyring.Keyring, acc Account) (AccountKey, error) {
+	if acc.Address != "" {
+		addr := sdk.MustAccAddressFromBech32(acc.Address)
+		return AccountKey{
+			Name:          acc.Name,
+			EthAddress:    common.BytesToAddress(addr).Hex(),
+			CosmosAddress: addr.String(),
+		}, nil
+	}
+
+	var mnemonic string
+	record, err := kr.Key(acc.Name)
+	if err != nil {
+		mnemonic = acc.Mnemonic
+		if mnemonic == "" {
+			entropy, err := bip39.NewEntropy(256)
+			if err != nil {
+				return AccountKey{}, err
+			}
+			if mnemonic, err = bip39.NewMnemonic(entropy); err != nil {
+				return AccountKey{}, err
+			}
+		}
+
+		record, err = kr.NewAccount(acc.Name, mnemonic, "", pairs.HDPath(0), evmhd.EthSecp256k1)
+		if err != nil {
+			return AccountKey{}, fmt.Errorf("account %s: %w", acc.Name, err)
+		}
+	}
+
+	addr, err := record.GetAddress()
+	if err != nil {
+		return AccountKey{}, err
+	}
+
+	return AccountKey{
+		Name:          acc.Name,
+		EthAddress:    common.BytesToAddress(addr).Hex(),
+		CosmosAddress: addr.String(),
+		Mnemonic:      mnemonic,
+	}, nil
+}
+
+// genesisState returns the default app genesis with the accounts funded. Its
+// bond denom and EVM denom are the native denom.
+func genesisState(opts Options, genAccounts []authtypes.GenesisAccount, genBalances []banktypes.Balance) (map[string]json.RawMessage, error) {
+	cdc := opts.Codec
+	appState := opts.BasicManager.DefaultGenesis(cdc)
+
+	var authGenState authtypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[authtypes.ModuleName], &authGenState)
+	accounts, err := authtypes.PackAccounts(genAccounts)
+	if err != nil {
+		return nil, err
+	}
+	authGenState.Accounts = accounts
+	appState[authtypes.ModuleName] = cdc.MustMarshalJSON(&authGenState)
+
+	var bankGenState banktypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[banktypes.ModuleName], &bankGenState)
+	bankGenState.Balances = banktypes.SanitizeGenesisBalances(genBalances)
+	bankGenState.Supply = sdk.NewCoins()
+	for _, bal := range bankGenState.Balances {
+		bankGenState.Supply = bankGenState.Supply.Add(bal.Coins...)
+	}
+	appState[banktypes.ModuleName] = cdc.MustMarshalJSON(&bankGenState)
+
+	// the bank metadata, bond denom, EVM and fee market genesis are the app
+	// defaults, see app.NewBankGenesisState
+
+	return appState, nil
+}
+
+// signGenTx returns the MsgCreateValidator of the spec validator, signed
+// with its key.
+func signGenTx(ctx context.Context, opts Options, chainID, nodeID string, valPubKey cryptotypes.PubKey) (sdk.Tx, error) {
+	val := opts.Spec.Validators[0]
+	record, err := opts.Keyring.Key(val.Name)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := record.GetAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	bonded, err := val.ParseBonded()
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := stakingtypes.NewMsgCreateValidator(
+		sdk.ValAddress(addr).String(),
+		valPubKey,
+		bonded,
+		stakingtypes.NewDescription(val.Name, "", "", "", ""),
+		stakingtypes.NewCommissionRates(
+			math.LegacyMustNewDecFromStr("0.1"),
+			math.LegacyMustNewDecFromStr("0.2"),
+			math.LegacyMustNewDecFromStr("0.01"),
+		),
+		math.OneInt(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p2pPort := strconv.Itoa(DefaultPorts().P2P)
+	if _, port, err := net.SplitHostPort(opts.Config.P2P.ListenAddress); err == nil {
+		p2pPort = port
+	}
+	memo := fmt.Sprintf("%s@%s", nodeID, net.JoinHostPort("127.0.0.1", p2pPort))
+
+	txBuilder := opts.TxConfig.NewTxBuilder()
+	if err := txBuilder.SetMsgs(msg); err != nil {
+		return nil, err
+	}
+	txBuilder.SetMemo(memo)
+
+	txFactory := tx.Factory{}.
+		WithChainID(chainID).
+		WithMemo(memo).
+		WithKeybase(opts.Keyring).
+		WithTxConfig(opts.TxConfig)
+
+	if err := tx.Sign(ctx, txFactory, val.Name, txBuilder, true); err != nil {
+		return nil, fmt.Errorf("failed to sign gentx: %w", err)
+	}
+
+	return txBuilder.GetTx(), nil
+}
+
+// writeGenesis merges the spec genesis into the app genesis, validates it
+// and writes it to the node genesis file.
+func writeGenesis(opts Options, chainID string, appState map[string]json.RawMessage) error {
+	appStateJSON, err := json.MarshalIndent(appState, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	genesis := genutiltypes.NewAppGenesisWithVersion(chainID, appStateJSON)
+	if len(opts.Spec.Genesis) > 0 {
+		if genesis, err = mergeGenesis(genesis, opts.Spec.Genesis); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(genesis.AppState, &appState); err != nil {
+			return err
+		}
+	}
+
+	if err := opts.BasicManager.ValidateGenesis(opts.Codec, opts.TxConfig, appState); err != nil {
+		return fmt.Errorf("invalid genesis: %w", err)
+	}
+	if err := app.ValidateGenesisDenoms(opts.Codec, appState); err != nil {
+		return fmt.Errorf("invalid genesis: %w", err)
+	}
+
+	return genutil.ExportGenesisFile(genesis, opts.Config.GenesisFile())
+}
+
+// mergeGenesis returns genesis with overrides deep merged into it.
+func mergeGenesis(genesis *genutiltypes.AppGenesis, overrides map[string]any) (*genutiltypes.AppGenesis, error) {
+	bz, err := json.Marshal(genesis)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(bz, &doc); err != nil {
+		return nil, err
+	}
+	merge(doc, overrides)
+
+	if bz, err = json.Marshal(doc); err != nil {
+		return nil, err
+	}
+
+	var merged genutiltypes.AppGenesis
+	if err := json.Unmarshal(bz, &merged); err != nil {
+		return nil, fmt.Errorf("invalid genesis override: %w", err)
+	}
+
+	return &merged, nil
+}
+
+// merge sets the values of src into dst, recursing into objects present in
+// both.
+func merge(dst, src map[string]any) {
+	for k, v := range src {
+		srcMap, ok := v.(map[string]any)
+		if dstMap, isMap := dst[k].(map[string]any); ok && isMap {
+			merge(dstMap, srcMap)
+			continue
+		}
+		dst[k] = v
+	}
+}
@@ -0,0 +1,183 @@
+package localnet_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/depinject"
+	"cosmossdk.io/log"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/types/module"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	genutiltypes "github.com/cosmos/cosmos-sdk/x/genutil/types"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	evmhd "github.com/cosmos/evm/crypto/hd"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/app"
+	"mirrorvault/localnet"
+)
+
+const spec = `version: 1
+validation: sovereign
+default_denom: umvlt
+accounts:
+- name: alice
+  coins:
+  - 1000000000umvlt
+  mnemonic: test test test test test test test test test test test junk
+- name: bob
+  coins:
+  - 1000000000umvlt
+faucet:
+  name: bob
+  coins:
+  - 10000000umvlt
+validators:
+- name: alice
+  bonded: 200000000umvlt
+genesis:
+  chain_id: mirror-vault-test
+  app_state:
+    feemarket:
+      params:
+        no_base_fee: true
+`
+
+func TestLoadChainSpec(t *testing.T) {
+	s, err := localnet.LoadSpec("../" + localnet.DefaultSpecFile)
+	require.NoError(t, err)
+	require.Equal(t, app.BaseDenom, s.DefaultDenom)
+	require.Len(t, s.Accounts, 3)
+	require.Equal(t, "bob", s.Faucet.Name)
+	require.Equal(t, "alice", s.Validators[0].Name)
+}
+
+func TestParseSpecErrors(t *testing.T) {
+	tests := map[string]string{
+		"no accounts": `default_denom: umvlt
+validators: [{name: alice, bonded: 1umvlt}]`,
+		"duplicate account": `default_denom: umvlt
+accounts: [{name: alice, coins: [1umvlt]}, {name: alice, coins: [1umvlt]}]
+validators: [{name: alice, bonded: 1umvlt}]`,
+		"unknown faucet": `default_denom: umvlt
+accounts: [{name: alice, coins: [1umvlt]}]
+faucet: {name: bob}
+validators: [{name: alice, bonded: 1umvlt}]`,
+		"no validator": `default_denom: umvlt
+accounts: [{name: alice, coins: [1umvlt]}]`,
+		"bond denom": `default_denom: umvlt
+accounts: [{name: alice, coins: [1umvlt, 1stake]}]
+validators: [{name: alice, bonded: 1stake}]`,
+		"bonded above balance": `default_denom: umvlt
+accounts: [{name: alice, coins: [1umvlt]}]
+validators: [{name: alice, bonded: 2umvlt}]`,
+	}
+
+	for name, bz := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := localnet.ParseSpec([]byte(bz))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPortsOffset(t *testing.T) {
+	ports := localnet.DefaultPorts().Offset(100)
+
+	cfg := cmtcfg.DefaultConfig()
+	ports.ApplyComet(cfg)
+	require.Equal(t, "tcp://127.0.0.1:26757", cfg.RPC.ListenAddress)
+	require.Equal(t, "tcp://0.0.0.0:26756", cfg.P2P.ListenAddress)
+	require.Equal(t, "tcp://127.0.0.1:26758", cfg.ProxyApp)
+	require.Equal(t, ":26760", cfg.Instrumentation.PrometheusListenAddr)
+	// disabled servers stay disabled
+	require.Empty(t, cfg.RPC.PprofListenAddress)
+}
+
+func TestInit(t *testing.T) {
+	s, err := localnet.ParseSpec([]byte(spec))
+	require.NoError(t, err)
+
+	var (
+		cdc               codec.Codec
+		txConfig          client.TxConfig
+		interfaceRegistry codectypes.InterfaceRegistry
+		basicManager      module.BasicManager
+	)
+	require.NoError(t, depinject.Inject(
+		depinject.Configs(app.AppConfig(), depinject.Supply(log.NewNopLogger())),
+		&cdc,
+		&txConfig,
+		&interfaceRegistry,
+		&basicManager,
+	))
+	for name, mod := range app.RegisterEVM(interfaceRegistry) {
+		basicManager[name] = module.CoreAppModuleBasicAdaptor(name, mod)
+	}
+
+	cfg := cmtcfg.DefaultConfig()
+	cfg.SetRoot(t.TempDir())
+	kr := keyring.NewInMemory(cdc, evmhd.EthSecp256k1Option())
+
+	res, err := localnet.Init(context.Background(), localnet.Options{
+		Spec:         s,
+		Config:       cfg,
+		Keyring:      kr,
+		Codec:        cdc,
+		TxConfig:     txConfig,
+		BasicManager: basicManager,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "mirror-vault-test", res.ChainID)
+	require.NotEmpty(t, res.NodeID)
+	require.Len(t, res.Accounts, 2)
+
+	// alice is recovered from the spec mnemonic, bob gets a new one
+	require.Equal(t, "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266", res.Accounts[0].EthAddress)
+	require.Equal(t, s.Accounts[0].Mnemonic, res.Accounts[0].Mnemonic)
+	require.NotEmpty(t, res.Accounts[1].Mnemonic)
+	_, err = kr.Key("bob")
+	require.NoError(t, err)
+
+	genesis, err := genutiltypes.AppGenesisFromFile(cfg.GenesisFile())
+	require.NoError(t, err)
+	require.Equal(t, "mirror-vault-test", genesis.ChainID)
+
+	var appState map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(genesis.AppState, &appState))
+
+	var staking stakingtypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[stakingtypes.ModuleName], &staking)
+	require.Equal(t, app.BaseDenom, staking.Params.BondDenom)
+
+	var bank banktypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[banktypes.ModuleName], &bank)
+	require.Len(t, bank.Balances, 2)
+	require.Equal(t, "2000000000umvlt", bank.Supply.String())
+	require.Equal(t, app.BaseDenom, bank.DenomMetadata[0].Base)
+
+	var evm evmtypes.GenesisState
+	cdc.MustUnmarshalJSON(appState[evmtypes.ModuleName], &evm)
+	require.Equal(t, app.BaseDenom, evm.Params.EvmDenom)
+
+	// the spec genesis is merged in
+	require.Contains(t, string(appState["feemarket"]), `"no_base_fee": true`)
+
+	genState := genutiltypes.GetGenesisStateFromAppState(cdc, appState)
+	require.Len(t, genState.GenTxs, 1)
+	genTx, err := txConfig.TxJSONDecoder()(genState.GenTxs[0])
+	require.NoError(t, err)
+	msg, ok := genTx.GetMsgs()[0].(*stakingtypes.MsgCreateValidator)
+	require.True(t, ok)
+	require.Equal(t, "200000000umvlt", msg.Value.String())
+}
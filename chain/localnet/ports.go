@@ -0,0 +1,11 @@
+package localnet
+
+import "mirrorvault/network"
+
+// Ports are the ports a localnet node listens on.
+type Ports = network.Ports
+
+// DefaultPorts returns the ports of the localnet network profile.
+func DefaultPorts() Ports {
+	return network.MustGet(network.Localnet).Ports
+}
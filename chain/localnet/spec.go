@@ -0,0 +1,191 @@
+package localnet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultSpecFile is the spec read when none is given, the Ignite config of
+// the chain.
+const DefaultSpecFile = "config.yml"
+
+// Spec describes a single node local network. It is the subset of the Ignite
+// config.yml format the dev flow uses, so the same file drives both.
+type Spec struct {
+	DefaultDenom string      `yaml:"default_denom"`
+	Accounts     []Account   `yaml:"accounts"`
+	Faucet       Faucet      `yaml:"faucet"`
+	Validators   []Validator `yaml:"validators"`
+	// Genesis is merged into the generated genesis file, e.g.
+	// app_state.feemarket.params.base_fee or chain_id.
+	Genesis map[string]any `yaml:"genesis"`
+}
+
+// Account is a genesis account. Its key is created in the node keyring,
+// recovered from Mnemonic when set. Accounts with an Address have no key.
+type Account struct {
+	Name     string   `yaml:"name"`
+	Coins    []string `yaml:"coins"`
+	Mnemonic string   `yaml:"mnemonic"`
+	Address  string   `yaml:"address"`
+}
+
+// Faucet is the account the faucet sends Coins from on each request.
+type Faucet struct {
+	Name  string   `yaml:"name"`
+	Coins []string `yaml:"coins"`
+}
+
+// Validator is an account bonding Bonded in the gentx of the node.
+type Validator struct {
+	Name   string `yaml:"name"`
+	Bonded string `yaml:"bonded"`
+}
+
+// LoadSpec reads and validates the spec at path.
+func LoadSpec(path string) (Spec, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return Spec{}, err
+	}
+
+	spec, err := ParseSpec(bz)
+	if err != nil {
+		return Spec{}, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return spec, nil
+}
+
+// ParseSpec decodes and validates a YAML spec.
+func ParseSpec(bz []byte) (Spec, error) {
+	var spec Spec
+	if err := yaml.Unmarshal(bz, &spec); err != nil {
+		return Spec{}, err
+	}
+
+	return spec, spec.Validate()
+}
+
+// Validate checks the spec describes a network that can start: accounts are
+// unique and funded with valid coins, and a single validator bonds the
+// default denom out of its own balance.
+func (s Spec) Validate() error {
+	if err := sdk.ValidateDenom(s.DefaultDenom); err != nil {
+		return fmt.Errorf("invalid default_denom: %w", err)
+	}
+
+	if len(s.Accounts) == 0 {
+		return errors.New("no accounts")
+	}
+
+	balances := make(map[string]sdk.Coins, len(s.Accounts))
+	for _, acc := range s.Accounts {
+		if acc.Name == "" {
+			return errors.New("account without name")
+		}
+		if _, ok := balances[acc.Name]; ok {
+			return fmt.Errorf("duplicate account %s", acc.Name)
+		}
+		if acc.Address != "" {
+			if _, err := sdk.AccAddressFromBech32(acc.Address); err != nil {
+				return fmt.Errorf("account %s: invalid address: %w", acc.Name, err)
+			}
+		}
+
+		coins, err := acc.ParseCoins()
+		if err != nil {
+			return fmt.Errorf("account %s: %w", acc.Name, err)
+		}
+		balances[acc.Name] = coins
+	}
+
+	if s.Faucet.Name != "" {
+		if _, ok := balances[s.Faucet.Name]; !ok {
+			return fmt.Errorf("faucet account %s is not in accounts", s.Faucet.Name)
+		}
+		if _, err := parseCoins(s.Faucet.Coins); err != nil {
+			return fmt.Errorf("faucet: %w", err)
+		}
+	}
+
+	if len(s.Validators) != 1 {
+		return fmt.Errorf("a localnet runs a single validator, got %d", len(s.Validators))
+	}
+
+	val := s.Validators[0]
+	balance, ok := balances[val.Name]
+	if !ok {
+		return fmt.Errorf("validator account %s is not in accounts", val.Name)
+	}
+	if s.account(val.Name).Address != "" {
+		return fmt.Errorf("validator account %s has no key", val.Name)
+	}
+
+	bonded, err := val.ParseBonded()
+	if err != nil {
+		return fmt.Errorf("validator %s: %w", val.Name, err)
+	}
+	if bonded.Denom != s.DefaultDenom {
+		return fmt.Errorf("validator %s bonds %s, the bond denom is %s", val.Name, bonded.Denom, s.DefaultDenom)
+	}
+	if balance.AmountOf(bonded.Denom).LT(bonded.Amount) {
+		return fmt.Errorf("validator %s bonds %s, more than its balance %s", val.Name, bonded, balance)
+	}
+
+	return nil
+}
+
+// ChainID returns the chain id set in the genesis section, if any.
+func (s Spec) ChainID() string {
+	chainID, _ := s.Genesis["chain_id"].(string)
+	return chainID
+}
+
+func (s Spec) account(name string) Account {
+	for _, acc := range s.Accounts {
+		if acc.Name == name {
+			return acc
+		}
+	}
+
+	return Account{}
+}
+
+// ParseCoins returns the genesis balance of the account.
+func (a Account) ParseCoins() (sdk.Coins, error) {
+	return parseCoins(a.Coins)
+}
+
+// ParseCoins returns the coins sent on each faucet request.
+func (f Faucet) ParseCoins() (sdk.Coins, error) {
+	return parseCoins(f.Coins)
+}
+
+// ParseBonded returns the self delegation of the validator.
+func (v Validator) ParseBonded() (sdk.Coin, error) {
+	coin, err := sdk.ParseCoinNormalized(v.Bonded)
+	if err != nil {
+		return sdk.Coin{}, fmt.Errorf("invalid bonded amount: %w", err)
+	}
+	if !coin.IsPositive() {
+		return sdk.Coin{}, errors.New("bonded amount must be positive")
+	}
+
+	return coin, nil
+}
+
+func parseCoins(coins []string) (sdk.Coins, error) {
+	parsed, err := sdk.ParseCoinsNormalized(strings.Join(coins, ","))
+	if err != nil {
+		return nil, fmt.Errorf("invalid coins: %w", err)
+	}
+
+	return parsed, nil
+}
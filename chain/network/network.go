@@ -0,0 +1,222 @@
+// Package network is the registry of the networks the chain runs as. A
+// profile holds the constants the node config, the genesis and the wallets
+// of a network must agree on.
+package network
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	bech32precompile "mirrorvault/precompiles/bech32"
+	bridgeprecompile "mirrorvault/x/bridge/precompile"
+	callbacksprecompile "mirrorvault/x/callbacks/precompile"
+	dispatchprecompile "mirrorvault/x/dispatch/precompile"
+	feeabsprecompile "mirrorvault/x/feeabs/precompile"
+	sponsorprecompile "mirrorvault/x/sponsor/precompile"
+)
+
+// Names of the built-in profiles.
+const (
+	Localnet = "localnet"
+	Testnet  = "testnet"
+	Mainnet  = "mainnet"
+)
+
+const (
+	// FlagNetwork is the flag selecting the profile of a command.
+	FlagNetwork = "network"
+	// EnvNetwork selects the profile when FlagNetwork isn't set.
+	EnvNetwork = "MIRRORVAULT_NETWORK"
+	// DefaultName is the profile used when neither selects one.
+	DefaultName = Localnet
+)
+
+// Constants shared by all the profiles. The address prefixes and the denoms
+// are compiled into the binary: the SDK config is sealed at init.
+const (
+	// Bech32Prefix is the prefix of account addresses.
+	Bech32Prefix = "mirror"
+	// CoinType is the BIP-44 coin type of the keys.
+	CoinType = 60
+	// BaseDenom is the denom of the native coin.
+	BaseDenom = "umvlt"
+	// DisplayDenom is the human readable denom of the native coin.
+	DisplayDenom = "MVLT"
+	// BaseDenomDecimals is the exponent between BaseDenom and DisplayDenom.
+	BaseDenomDecimals = 6
+	// ExtendedDenom is the 18 decimals denom of the native coin in the EVM.
+	ExtendedDenom = "amvlt"
+	// ExtendedDenomDecimals is the exponent between ExtendedDenom and
+	// DisplayDenom.
+	ExtendedDenomDecimals = 18
+)
+
+// Profile describes a network.
+type Profile struct {
+	Name string `json:"name"`
+	// ChainID is the Cosmos chain id. A localnet may run under another one,
+	// e.g. the one of its spec.
+	ChainID string `json:"chain_id"`
+	// EVMChainID is the EIP-155 chain id, the evm-chain-id of app.toml.
+	EVMChainID uint64 `json:"evm_chain_id"`
+
+	Bech32Prefix          string `json:"bech32_prefix"`
+	CoinType              uint32 `json:"coin_type"`
+	BaseDenom             string `json:"base_denom"`
+	DisplayDenom          string `json:"display_denom"`
+	BaseDenomDecimals     uint32 `json:"base_denom_decimals"`
+	ExtendedDenom         string `json:"extended_denom"`
+	ExtendedDenomDecimals uint32 `json:"extended_denom_decimals"`
+
+	// MinGasPrices is the minimum-gas-prices of a new app.toml.
+	MinGasPrices string `json:"min_gas_prices"`
+	// Ports are the default ports of a node.
+	Ports Ports `json:"ports"`
+	// Precompiles are the addresses of the static precompiles, by name.
+	Precompiles map[string]common.Address `json:"precompiles"`
+}
+
+var profiles = map[string]Profile{
+	Localnet: newProfile(Localnet, "mirror-vault-localnet", 7777, "0"+BaseDenom),
+	Testnet:  newProfile(Testnet, "mirror-vault-testnet-1", 7778, "0.01"+BaseDenom),
+	Mainnet:  newProfile(Mainnet, "mirror-vault-1", 7779, "0.01"+BaseDenom),
+}
+
+// newProfile returns a profile with the shared constants.
+func newProfile(name, chainID string, evmChainID uint64, minGasPrices string) Profile {
+	return Profile{
+		Name:                  name,
+		ChainID:               chainID,
+		EVMChainID:            evmChainID,
+		Bech32Prefix:          Bech32Prefix,
+		CoinType:              CoinType,
+		BaseDenom:             BaseDenom,
+		DisplayDenom:          DisplayDenom,
+		BaseDenomDecimals:     BaseDenomDecimals,
+		ExtendedDenom:         ExtendedDenom,
+		ExtendedDenomDecimals: ExtendedDenomDecimals,
+		MinGasPrices:          minGasPrices,
+		Ports:                 DefaultPorts(),
+		Precompiles: map[string]common.Address{
+			"paymaster":    feeabsprecompile.Address,
+			"sponsor":      sponsorprecompile.Address,
+			"bech32":       bech32precompile.Address,
+			"dispatch":     dispatchprecompile.Address,
+			"callbacks":    callbacksprecompile.Address,
+			"bridge":       bridgeprecompile.Address,
+			"staking":      common.HexToAddress(evmtypes.StakingPrecompileAddress),
+			"distribution": common.HexToAddress(evmtypes.DistributionPrecompileAddress),
+			"bank":         common.HexToAddress(evmtypes.BankPrecompileAddress),
+			"gov":          common.HexToAddress(evmtypes.GovPrecompileAddress),
+		},
+	}
+}
+
+// Names returns the names of the profiles, sorted.
+func Names() []string {
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the profile of name.
+func Get(name string) (Profile, error) {
+	p, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown network %q, expected one of %s", name, strings.Join(Names(), ", "))
+	}
+	return p, nil
+}
+
+// MustGet returns the profile of name and panics if there is none.
+func MustGet(name string) Profile {
+	p, err := Get(name)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// Select returns the profile of name, the value of FlagNetwork. When it is
+// empty the profile is read from EnvNetwork, then defaults to DefaultName.
+func Select(name string) (Profile, error) {
+	if name == "" {
+		name = os.Getenv(EnvNetwork)
+	}
+	if name == "" {
+		name = DefaultName
+	}
+	return Get(name)
+}
+
+// PrecompileAddresses returns the hex addresses of the static precompiles,
+// sorted.
+func (p Profile) PrecompileAddresses() []string {
+	addrs := make([]string, 0, len(p.Precompiles))
+	for _, addr := range p.Precompiles {
+		addrs = append(addrs, addr.Hex())
+	}
+	sort.Strings(addrs)
+	return addrs
+}
+
+// Check returns an error if a node of the network doesn't run with the
+// profile: its chain id, EVM chain id, sealed SDK config or registered
+// static precompiles differ. A localnet runs under any chain ids.
+func (p Profile) Check(chainID string, evmChainID uint64, precompiles []string) error {
+	if err := p.CheckChainID(chainID); err != nil {
+		return err
+	}
+	if err := p.CheckEVMChainID(evmChainID); err != nil {
+		return err
+	}
+
+	cfg := sdk.GetConfig()
+	if prefix := cfg.GetBech32AccountAddrPrefix(); prefix != p.Bech32Prefix {
+		return fmt.Errorf("%s network uses bech32 prefix %s, the binary %s", p.Name, p.Bech32Prefix, prefix)
+	}
+	if coinType := cfg.GetCoinType(); coinType != p.CoinType {
+		return fmt.Errorf("%s network uses coin type %d, the binary %d", p.Name, p.CoinType, coinType)
+	}
+	if sdk.DefaultBondDenom != p.BaseDenom {
+		return fmt.Errorf("%s network bonds %s, the binary %s", p.Name, p.BaseDenom, sdk.DefaultBondDenom)
+	}
+
+	registered := slices.Clone(precompiles)
+	sort.Strings(registered)
+	if !slices.Equal(registered, p.PrecompileAddresses()) {
+		return fmt.Errorf("%s network has precompiles %v, the app registers %v", p.Name, p.PrecompileAddresses(), registered)
+	}
+
+	return nil
+}
+
+// CheckChainID returns an error if chainID isn't the one of the network. A
+// localnet accepts any.
+func (p Profile) CheckChainID(chainID string) error {
+	if p.Name == Localnet || chainID == p.ChainID {
+		return nil
+	}
+	return fmt.Errorf("%s network runs chain id %s, got %s", p.Name, p.ChainID, chainID)
+}
+
+// CheckEVMChainID returns an error if evmChainID isn't the one of the
+// network. A localnet accepts any.
+func (p Profile) CheckEVMChainID(evmChainID uint64) error {
+	if p.Name == Localnet || evmChainID == p.EVMChainID {
+		return nil
+	}
+	return fmt.Errorf("%s network runs EVM chain id %d, got %d", p.Name, p.EVMChainID, evmChainID)
+}
@@ -0,0 +1,77 @@
+package network_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"mirrorvault/app"
+	"mirrorvault/network"
+)
+
+func TestSelect(t *testing.T) {
+	t.Setenv(network.EnvNetwork, "")
+	p, err := network.Select("")
+	require.NoError(t, err)
+	require.Equal(t, network.DefaultName, p.Name)
+
+	t.Setenv(network.EnvNetwork, network.Testnet)
+	p, err = network.Select("")
+	require.NoError(t, err)
+	require.Equal(t, network.Testnet, p.Name)
+
+	// the flag wins over the environment
+	p, err = network.Select(network.Mainnet)
+	require.NoError(t, err)
+	require.Equal(t, network.Mainnet, p.Name)
+
+	_, err = network.Select("devnet")
+	require.ErrorContains(t, err, "localnet, mainnet, testnet")
+}
+
+func TestCheck(t *testing.T) {
+	chainIDs := map[string]bool{}
+	evmChainIDs := map[uint64]bool{}
+	for _, name := range network.Names() {
+		p := network.MustGet(name)
+		require.NoError(t, p.Check(p.ChainID, p.EVMChainID, app.StaticPrecompileAddresses()), name)
+
+		// networks can't replay each other's txs
+		require.False(t, chainIDs[p.ChainID], name)
+		require.False(t, evmChainIDs[p.EVMChainID], name)
+		chainIDs[p.ChainID] = true
+		evmChainIDs[p.EVMChainID] = true
+
+		if name != network.Localnet {
+			require.Error(t, p.Check(p.ChainID, p.EVMChainID+1, app.StaticPrecompileAddresses()), name)
+		}
+		require.Error(t, p.Check(p.ChainID, p.EVMChainID, app.StaticPrecompileAddresses()[1:]), name)
+	}
+
+	// a localnet may run under the chain ids of its spec
+	localnet := network.MustGet(network.Localnet)
+	require.NoError(t, localnet.Check("mirror-vault-dev", 31337, app.StaticPrecompileAddresses()))
+
+	testnet := network.MustGet(network.Testnet)
+	require.ErrorContains(t, testnet.CheckChainID(localnet.ChainID), "testnet network runs chain id")
+	require.ErrorContains(t, testnet.CheckEVMChainID(localnet.EVMChainID), "testnet network runs EVM chain id")
+}
+
+func TestCheckStride(t *testing.T) {
+	ports := network.DefaultPorts()
+	require.NoError(t, ports.CheckStride(10, 4))
+	// one node doesn't shift
+	require.NoError(t, ports.CheckStride(1000, 1))
+
+	for name, stride := range map[string]int{
+		"zero":     0,
+		"negative": -10,
+		// the CometBFT ports span 26656 to 26660
+		"cometbft": 3,
+		// the pprof port of node 1 is the JSON-RPC metrics port of node 0
+		"pprof":              5,
+		"past the last port": 20000,
+	} {
+		require.Error(t, ports.CheckStride(stride, 4), name)
+	}
+}
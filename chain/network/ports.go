@@ -0,0 +1,133 @@
+package network
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+
+	cmtcfg "github.com/cometbft/cometbft/config"
+
+	evmserverconfig "github.com/cosmos/evm/server/config"
+)
+
+// Ports are the ports a node listens on.
+type Ports struct {
+	P2P            int
+	RPC            int
+	ABCI           int
+	Pprof          int
+	Prometheus     int
+	GRPC           int
+	API            int
+	JSONRPC        int
+	JSONRPCWS      int
+	JSONRPCMetrics int
+	GethMetrics    int
+}
+
+// DefaultPorts returns the default ports of CometBFT, the SDK and Cosmos EVM.
+func DefaultPorts() Ports {
+	return Ports{
+		P2P:            26656,
+		RPC:            26657,
+		ABCI:           26658,
+		Pprof:          6060,
+		Prometheus:     26660,
+		GRPC:           9090,
+		API:            1317,
+		JSONRPC:        8545,
+		JSONRPCWS:      8546,
+		JSONRPCMetrics: 6065,
+		GethMetrics:    8100,
+	}
+}
+
+// Offset returns the ports shifted by offset, so several nodes can run
+// side by side.
+func (p Ports) Offset(offset int) Ports {
+	return Ports{
+		P2P:            p.P2P + offset,
+		RPC:            p.RPC + offset,
+		ABCI:           p.ABCI + offset,
+		Pprof:          p.Pprof + offset,
+		Prometheus:     p.Prometheus + offset,
+		GRPC:           p.GRPC + offset,
+		API:            p.API + offset,
+		JSONRPC:        p.JSONRPC + offset,
+		JSONRPCWS:      p.JSONRPCWS + offset,
+		JSONRPCMetrics: p.JSONRPCMetrics + offset,
+		GethMetrics:    p.GethMetrics + offset,
+	}
+}
+
+// List returns the ports.
+func (p Ports) List() []int {
+	return []int{p.P2P, p.RPC, p.ABCI, p.Pprof, p.Prometheus, p.GRPC, p.API, p.JSONRPC, p.JSONRPCWS, p.JSONRPCMetrics, p.GethMetrics}
+}
+
+// CheckStride returns an error if n nodes, each listening on the ports
+// shifted by stride from the previous one, would share a port or listen past
+// the last port.
+func (p Ports) CheckStride(stride, n int) error {
+	if stride <= 0 {
+		return fmt.Errorf("stride must be positive, got %d", stride)
+	}
+
+	taken := make(map[int]int)
+	for i := 0; i < n; i++ {
+		for _, port := range p.Offset(i * stride).List() {
+			if port > 65535 {
+				return fmt.Errorf("node %d would listen on port %d, past the last port", i, port)
+			}
+			if node, ok := taken[port]; ok {
+				return fmt.Errorf("nodes %d and %d would both listen on port %d with a stride of %d", node, i, port, stride)
+			}
+			taken[port] = i
+		}
+	}
+
+	return nil
+}
+
+// ApplyComet sets the ports of the CometBFT config, keeping its hosts.
+func (p Ports) ApplyComet(cfg *cmtcfg.Config) {
+	cfg.P2P.ListenAddress = withPort(cfg.P2P.ListenAddress, p.P2P)
+	cfg.RPC.ListenAddress = withPort(cfg.RPC.ListenAddress, p.RPC)
+	cfg.ProxyApp = withPort(cfg.ProxyApp, p.ABCI)
+	cfg.RPC.PprofListenAddress = withPort(cfg.RPC.PprofListenAddress, p.Pprof)
+	cfg.Instrumentation.PrometheusListenAddr = withPort(cfg.Instrumentation.PrometheusListenAddr, p.Prometheus)
+}
+
+// ApplyApp sets the ports of the app config, keeping its hosts, and enables
+// the REST and JSON-RPC servers.
+func (p Ports) ApplyApp(cfg *evmserverconfig.Config) {
+	cfg.API.Enable = true
+	cfg.API.Address = withPort(cfg.API.Address, p.API)
+	cfg.GRPC.Address = withPort(cfg.GRPC.Address, p.GRPC)
+	cfg.JSONRPC.Enable = true
+	cfg.JSONRPC.Address = withPort(cfg.JSONRPC.Address, p.JSONRPC)
+	cfg.JSONRPC.WsAddress = withPort(cfg.JSONRPC.WsAddress, p.JSONRPCWS)
+	cfg.JSONRPC.MetricsAddress = withPort(cfg.JSONRPC.MetricsAddress, p.JSONRPCMetrics)
+	cfg.EVM.GethMetricsAddress = withPort(cfg.EVM.GethMetricsAddress, p.GethMetrics)
+}
+
+// withPort replaces the port of a host:port or scheme://host:port address.
+// Empty addresses, which disable a server, are left empty.
+func withPort(addr string, port int) string {
+	if addr == "" {
+		return ""
+	}
+
+	if u, err := url.Parse(addr); err == nil && u.Host != "" {
+		u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(port))
+		return u.String()
+	}
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+
+	return net.JoinHostPort(host, strconv.Itoa(port))
+}
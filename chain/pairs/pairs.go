@@ -0,0 +1,78 @@
+package pairs
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	evmhd "github.com/cosmos/evm/crypto/hd"
+)
+
+// CoinType is the BIP-44 coin type pairs are derived with. Both MetaMask and
+// Keplr (with the eth-address-gen feature) derive along m/44'/60'/0'/0/i.
+const CoinType = 60
+
+// Pair is an account seen from both of its wallets: the 0x address MetaMask
+// shows and the bech32 address Keplr shows. Both are the same 20 bytes.
+type Pair struct {
+	Name          string `json:"name"`
+	Index         uint32 `json:"index"`
+	HDPath        string `json:"hd_path"`
+	EthAddress    string `json:"eth_address"`
+	CosmosAddress string `json:"cosmos_address"`
+	// PrivateKey is the hex encoded eth_secp256k1 private key, the form
+	// MetaMask imports. It is only set on pairs returned by Derive.
+	PrivateKey string `json:"private_key,omitempty"`
+}
+
+// Bundle is everything needed to import a set of pairs into wallets: the
+// mnemonic for Keplr, which derives index i with its advanced HD path option,
+// and the per-pair private keys for MetaMask.
+type Bundle struct {
+	Mnemonic string `json:"mnemonic"`
+	Pairs    []Pair `json:"pairs"`
+}
+
+// HDPath returns the derivation path of the pair at index.
+func HDPath(index uint32) string {
+	return hd.CreateHDPath(CoinType, 0, index).String()
+}
+
+// Name returns the keyring name of the pair at index.
+func Name(prefix string, index uint32) string {
+	return fmt.Sprintf("%s%d", prefix, index)
+}
+
+// Derive derives count eth_secp256k1 accounts from mnemonic, starting at index
+// start. Keys are named prefix followed by their index.
+func Derive(mnemonic, prefix string, start, count uint32) ([]Pair, error) {
+	if count > math.MaxUint32-start {
+		return nil, fmt.Errorf("%d pairs from index %d run past the last index %d", count, start, uint32(math.MaxUint32))
+	}
+
+	pairs := make([]Pair, 0, count)
+	for i := start; i < start+count; i++ {
+		path := HDPath(i)
+		bz, err := evmhd.EthSecp256k1.Derive()(mnemonic, "", path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive %s: %w", path, err)
+		}
+
+		addr := evmhd.EthSecp256k1.Generate()(bz).PubKey().Address()
+		pairs = append(pairs, Pair{
+			Name:          Name(prefix, i),
+			Index:         i,
+			HDPath:        path,
+			EthAddress:    common.BytesToAddress(addr).Hex(),
+			CosmosAddress: sdk.AccAddress(addr).String(),
+			PrivateKey:    hex.EncodeToString(bz),
+		})
+	}
+
+	return pairs, nil
+}
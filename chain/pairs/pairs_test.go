@@ -0,0 +1,63 @@
+package pairs_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"mirrorvault/app"
+	"mirrorvault/pairs"
+)
+
+// the Hardhat/Anvil development mnemonic and its well known accounts
+const mnemonic = "test test test test test test test test test test test junk"
+
+var wantAddresses = []string{
+	"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+	"0x70997970C51812dc3A010C7d01b50e0d17dc79C8",
+	"0x3C44CdDdB6a900fa2b585dd299e03d12FA4293BC",
+}
+
+func TestDerive(t *testing.T) {
+	got, err := pairs.Derive(mnemonic, "pair", 0, 3)
+	require.NoError(t, err)
+	require.Len(t, got, 3)
+
+	for i, pair := range got {
+		require.Equal(t, pairs.Name("pair", uint32(i)), pair.Name)
+		require.Equal(t, wantAddresses[i], pair.EthAddress)
+		require.Equal(t, "m/44'/60'/0'/0/"+string(rune('0'+i)), pair.HDPath)
+
+		// both forms are the same 20 bytes
+		accAddr, err := sdk.AccAddressFromBech32(pair.CosmosAddress)
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress(pair.EthAddress).Bytes(), accAddr.Bytes())
+		require.True(t, strings.HasPrefix(pair.CosmosAddress, app.AccountAddressPrefix+"1"))
+	}
+
+	// Hardhat account #0 private key
+	require.Equal(t, "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80", got[0].PrivateKey)
+
+	offset, err := pairs.Derive(mnemonic, "pair", 2, 1)
+	require.NoError(t, err)
+	require.Equal(t, got[2], offset[0])
+}
+
+func TestDeriveInvalidMnemonic(t *testing.T) {
+	_, err := pairs.Derive("not a mnemonic", "pair", 0, 1)
+	require.Error(t, err)
+}
+
+func TestDeriveOverflow(t *testing.T) {
+	_, err := pairs.Derive(mnemonic, "pair", math.MaxUint32-1, 2)
+	require.Error(t, err)
+
+	got, err := pairs.Derive(mnemonic, "pair", math.MaxUint32-1, 1)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+pragma solidity >=0.8.17;
+
+/// @dev The address of the bech32 precompile.
+address constant BECH32_PRECOMPILE_ADDRESS = 0x0000000000000000000000000000000000000902;
+
+/// @dev The bech32 precompile.
+IBech32 constant BECH32_CONTRACT = IBech32(BECH32_PRECOMPILE_ADDRESS);
+
+/// @title Bech32 precompile
+/// @dev Converts between EVM addresses and the bech32 addresses of Cosmos
+/// chains, e.g. mirror1... for accounts or mirrorvaloper1... for
+/// validators. The gas of a call grows with the length of its input.
+/// @custom:address 0x0000000000000000000000000000000000000902
+interface IBech32 {
+    /// @dev Returns the bech32 address of addr with prefix, the account
+    /// prefix "mirror" when prefix is empty.
+    function toBech32(address addr, string memory prefix) external view returns (string memory bech32Address);
+
+    /// @dev Returns the address of a 20 bytes bech32 address of any prefix.
+    /// Reverts if bech32Address is invalid.
+    function fromBech32(string memory bech32Address) external view returns (address addr);
+
+    /// @dev Returns whether fromBech32 accepts bech32Address.
+    function isValidBech32(string memory bech32Address) external view returns (bool valid);
+}
@@ -0,0 +1,187 @@
+// Package bech32 is the bech32 precompile, through which EVM contracts
+// convert between EVM addresses and the bech32 addresses of Cosmos chains.
+// It reads no state, so its gas only depends on the length of its input.
+package bech32
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/bech32"
+
+	cmn "github.com/cosmos/evm/precompiles/common"
+)
+
+// Address is the address of the bech32 precompile, next to the other
+// static precompiles of the app.
+var Address = common.HexToAddress("0x0000000000000000000000000000000000000902")
+
+// Methods of the bech32 precompile.
+const (
+	ToBech32Method      = "toBech32"
+	FromBech32Method    = "fromBech32"
+	IsValidBech32Method = "isValidBech32"
+)
+
+// Gas of a call: BaseGas plus GasPerByte for each byte of its input, the
+// work of the bech32 checksum growing with the address length.
+const (
+	BaseGas    = 3_000
+	GasPerByte = 16
+)
+
+var _ vm.PrecompiledContract = &Precompile{}
+
+var (
+	// Embed abi json file to the executable binary. Needed when importing as dependency.
+	//
+	//go:embed abi.json
+	f   embed.FS
+	ABI abi.ABI
+)
+
+func init() {
+	var err error
+	ABI, err = cmn.LoadABI(f, "abi.json")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Precompile is the bech32 precompile.
+type Precompile struct {
+	abi.ABI
+}
+
+// NewPrecompile returns the bech32 precompile.
+func NewPrecompile() *Precompile {
+	return &Precompile{ABI: ABI}
+}
+
+// Address returns the address of the precompile.
+func (Precompile) Address() common.Address {
+	return Address
+}
+
+// RequiredGas returns the gas of a call with input.
+func (Precompile) RequiredGas(input []byte) uint64 {
+	return BaseGas + GasPerByte*uint64(len(input))
+}
+
+// Run executes the bech32 methods.
+func (p Precompile) Run(_ *vm.EVM, contract *vm.Contract, _ bool) ([]byte, error) {
+	// NOTE: This check avoid panicking when trying to decode the method ID
+	if len(contract.Input) < 4 {
+		return nil, vm.ErrExecutionReverted
+	}
+
+	method, err := p.MethodById(contract.Input[:4])
+	if err != nil {
+		return nil, err
+	}
+	args, err := method.Inputs.Unpack(contract.Input[4:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case ToBech32Method:
+		return p.ToBech32(method, args)
+	case FromBech32Method:
+		return p.FromBech32(method, args)
+	case IsValidBech32Method:
+		return p.IsValidBech32(method, args)
+	default:
+		return nil, fmt.Errorf(cmn.ErrUnknownMethod, method.Name)
+	}
+}
+
+// ToBech32 returns the bech32 address of an address with a prefix, the
+// account prefix when it is empty.
+func (p Precompile) ToBech32(method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 2, len(args))
+	}
+	addr, ok := args[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("invalid address: %v", args[0])
+	}
+	prefix, ok := args[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid prefix: %v", args[1])
+	}
+	if prefix == "" {
+		prefix = sdk.GetConfig().GetBech32AccountAddrPrefix()
+	}
+
+	// the encoding doesn't check the prefix characters, decoding does
+	bech32Addr, err := bech32.ConvertAndEncode(prefix, addr.Bytes())
+	if err == nil {
+		var hrp string
+		if hrp, _, err = bech32.DecodeAndConvert(bech32Addr); err == nil && hrp != prefix {
+			err = errors.New("not lower case")
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix %q: %w", prefix, err)
+	}
+
+	return method.Outputs.Pack(bech32Addr)
+}
+
+// FromBech32 returns the address of a 20 bytes bech32 address.
+func (p Precompile) FromBech32(method *abi.Method, args []interface{}) ([]byte, error) {
+	bech32Addr, err := parseBech32Arg(args)
+	if err != nil {
+		return nil, err
+	}
+	addr, err := decode(bech32Addr)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(addr)
+}
+
+// IsValidBech32 returns whether FromBech32 accepts a bech32 address.
+func (p Precompile) IsValidBech32(method *abi.Method, args []interface{}) ([]byte, error) {
+	bech32Addr, err := parseBech32Arg(args)
+	if err != nil {
+		return nil, err
+	}
+	_, err = decode(bech32Addr)
+
+	return method.Outputs.Pack(err == nil)
+}
+
+// decode returns the address of a 20 bytes bech32 address of any prefix.
+func decode(bech32Addr string) (common.Address, error) {
+	_, bz, err := bech32.DecodeAndConvert(bech32Addr)
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid bech32 address %q: %w", bech32Addr, err)
+	}
+	if len(bz) != common.AddressLength {
+		return common.Address{}, fmt.Errorf("bech32 address %q has %d bytes, an EVM address %d", bech32Addr, len(bz), common.AddressLength)
+	}
+
+	return common.BytesToAddress(bz), nil
+}
+
+// parseBech32Arg parses the bech32 address argument of the methods.
+func parseBech32Arg(args []interface{}) (string, error) {
+	if len(args) != 1 {
+		return "", fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 1, len(args))
+	}
+	bech32Addr, ok := args[0].(string)
+	if !ok {
+		return "", fmt.Errorf("invalid bech32 address: %v", args[0])
+	}
+
+	return bech32Addr, nil
+}
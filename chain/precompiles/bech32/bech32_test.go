@@ -0,0 +1,109 @@
+package bech32_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkbech32 "github.com/cosmos/cosmos-sdk/types/bech32"
+
+	"mirrorvault/precompiles/bech32"
+	"mirrorvault/testutil/apptest"
+)
+
+// TestPrecompile calls the precompile through the EVM of an in-memory app.
+func TestPrecompile(t *testing.T) {
+	a, ctx, _ := apptest.Setup(t, simtestutil.AppOptionsMap{})
+
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	a.AuthKeeper.SetAccount(ctx, a.AuthKeeper.NewAccountWithAddress(ctx, from.Bytes()))
+	call := func(method string, args ...interface{}) ([]interface{}, uint64, error) {
+		// a failed call consumes the whole gas cap of the context
+		ctx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		res, err := a.EVMKeeper.CallEVM(ctx, bech32.ABI, from, bech32.Address, false, nil, method, args...)
+		if err != nil {
+			return nil, 0, err
+		}
+		out, err := bech32.ABI.Unpack(method, res.Ret)
+		require.NoError(t, err)
+		return out, res.GasUsed, nil
+	}
+
+	accAddr := sdk.AccAddress(from.Bytes()).String()
+	require.True(t, strings.HasPrefix(accAddr, "mirror1"))
+
+	t.Run("toBech32", func(t *testing.T) {
+		out, _, err := call(bech32.ToBech32Method, from, "")
+		require.NoError(t, err)
+		require.Equal(t, accAddr, out[0])
+
+		out, _, err = call(bech32.ToBech32Method, from, "mirrorvaloper")
+		require.NoError(t, err)
+		require.Equal(t, sdk.ValAddress(from.Bytes()).String(), out[0])
+
+		for _, prefix := range []string{"Mirror", "mirror vault"} {
+			_, _, err = call(bech32.ToBech32Method, from, prefix)
+			require.Error(t, err, prefix)
+		}
+	})
+
+	t.Run("fromBech32", func(t *testing.T) {
+		out, _, err := call(bech32.FromBech32Method, accAddr)
+		require.NoError(t, err)
+		require.Equal(t, from, out[0])
+
+		// any prefix
+		cosmosAddr, err := sdkbech32.ConvertAndEncode("cosmos", from.Bytes())
+		require.NoError(t, err)
+		out, _, err = call(bech32.FromBech32Method, cosmosAddr)
+		require.NoError(t, err)
+		require.Equal(t, from, out[0])
+
+		// 32 bytes addresses are not EVM addresses
+		long, err := sdkbech32.ConvertAndEncode("mirror", make([]byte, 32))
+		require.NoError(t, err)
+		_, _, err = call(bech32.FromBech32Method, long)
+		require.Error(t, err)
+
+		// a wrong checksum
+		_, _, err = call(bech32.FromBech32Method, accAddr[:len(accAddr)-1]+"q")
+		require.Error(t, err)
+	})
+
+	t.Run("isValidBech32", func(t *testing.T) {
+		long, err := sdkbech32.ConvertAndEncode("mirror", make([]byte, 32))
+		require.NoError(t, err)
+
+		for addr, valid := range map[string]bool{
+			accAddr:                               true,
+			sdk.ValAddress(from.Bytes()).String(): true,
+			long:                                  false,
+			accAddr[:len(accAddr)-1] + "q":        false,
+			from.Hex():                            false,
+			"":                                    false,
+		} {
+			out, _, err := call(bech32.IsValidBech32Method, addr)
+			require.NoError(t, err, addr)
+			require.Equal(t, valid, out[0], addr)
+		}
+	})
+
+	t.Run("gas", func(t *testing.T) {
+		// the input grows by 32 bytes with each 32 bytes of address
+		short := strings.Repeat("a", 32)
+		_, shortGas, err := call(bech32.IsValidBech32Method, short)
+		require.NoError(t, err)
+		_, longGas, err := call(bech32.IsValidBech32Method, short+short)
+		require.NoError(t, err)
+
+		// the precompile gas and the calldata gas of 32 non-zero bytes
+		require.Equal(t, uint64(32*bech32.GasPerByte+32*16), longGas-shortGas)
+		require.Equal(t, uint64(bech32.BaseGas+(4+3*32)*bech32.GasPerByte), bech32.NewPrecompile().RequiredGas(make([]byte, 4+3*32)))
+	})
+}
@@ -0,0 +1,4 @@
+// Package precompiles holds the static precompiles of the app that no module
+// owns, in its subpackages. Its tests run the cosmos/evm precompiles the app
+// registers along them.
+package precompiles
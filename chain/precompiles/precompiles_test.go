@@ -0,0 +1,103 @@
+package precompiles_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	stakingprecompile "github.com/cosmos/evm/precompiles/staking"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/app"
+	"mirrorvault/testutil/apptest"
+)
+
+// stakingCaller returns the init code of a contract forwarding its calldata
+// to the staking precompile, so that the contract is the msg.sender the
+// precompile sees. It reverts with the revert data of the precompile.
+func stakingCaller() []byte {
+	runtime := []byte{
+		byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.CALLDATACOPY),
+		// call(gas, 0x800, 0, 0, calldatasize, 0, 0)
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.PUSH2), 0x08, 0x00, byte(vm.GAS), byte(vm.CALL),
+		byte(vm.RETURNDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.RETURNDATACOPY),
+		byte(vm.PUSH1), 33, byte(vm.JUMPI),
+		byte(vm.RETURNDATASIZE), byte(vm.PUSH1), 0, byte(vm.REVERT),
+		byte(vm.JUMPDEST), byte(vm.RETURNDATASIZE), byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}
+	return apptest.InitCode(runtime)
+}
+
+// TestStakingPrecompile delegates to the validator of an in-memory app from a
+// contract, then turns the staking precompile off through the EVM params as
+// gov would.
+func TestStakingPrecompile(t *testing.T) {
+	a, ctx, valAddr := apptest.Setup(t, simtestutil.AppOptionsMap{})
+
+	from := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	a.AuthKeeper.SetAccount(ctx, a.AuthKeeper.NewAccountWithAddress(ctx, from.Bytes()))
+	call := func(to *common.Address, data []byte) error {
+		// a failed call consumes the whole gas cap of the context
+		ctx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		_, err := a.EVMKeeper.CallEVMWithData(ctx, from, to, data, true, nil)
+		return err
+	}
+
+	contract := crypto.CreateAddress(from, 0)
+	require.NoError(t, call(nil, stakingCaller()))
+	require.NotEmpty(t, a.EVMKeeper.GetCode(ctx, common.BytesToHash(a.EVMKeeper.GetAccount(ctx, contract).CodeHash)))
+
+	amount := math.NewInt(100_000)
+	funds := sdk.NewCoins(sdk.NewCoin(app.BaseDenom, amount.MulRaw(2)))
+	require.NoError(t, a.BankKeeper.SendCoins(ctx, sdk.AccAddress(valAddr), contract.Bytes(), funds))
+	delegate, err := stakingprecompile.ABI.Pack(stakingprecompile.DelegateMethod, contract, valAddr.String(), amount.BigInt())
+	require.NoError(t, err)
+
+	delegated := func() math.Int {
+		delegation, err := a.StakingKeeper.GetDelegation(ctx, contract.Bytes(), valAddr)
+		require.NoError(t, err)
+		val, err := a.StakingKeeper.GetValidator(ctx, valAddr)
+		require.NoError(t, err)
+		return val.TokensFromShares(delegation.Shares).TruncateInt()
+	}
+
+	require.NoError(t, call(&contract, delegate))
+	require.Equal(t, amount, delegated())
+	require.Equal(t, amount, a.BankKeeper.GetBalance(ctx, contract.Bytes(), app.BaseDenom).Amount)
+
+	// the precompile only delegates for its caller
+	other, err := stakingprecompile.ABI.Pack(stakingprecompile.DelegateMethod, from, valAddr.String(), amount.BigInt())
+	require.NoError(t, err)
+	require.Error(t, call(&contract, other))
+
+	// gov turns the precompile off
+	params := a.EVMKeeper.GetParams(ctx)
+	staking := common.HexToAddress(evmtypes.StakingPrecompileAddress).Hex()
+	require.Contains(t, params.ActiveStaticPrecompiles, staking)
+	params.ActiveStaticPrecompiles = slices.DeleteFunc(params.ActiveStaticPrecompiles, func(addr string) bool {
+		return addr == staking
+	})
+	_, err = a.EVMKeeper.UpdateParams(ctx, &evmtypes.MsgUpdateParams{
+		Authority: authtypes.NewModuleAddress(govtypes.ModuleName).String(),
+		Params:    params,
+	})
+	require.NoError(t, err)
+
+	// 0x800 is an empty account then, calling it does nothing
+	require.NoError(t, call(&contract, delegate))
+	require.Equal(t, amount, delegated())
+	require.Equal(t, amount, a.BankKeeper.GetBalance(ctx, contract.Bytes(), app.BaseDenom).Amount)
+}
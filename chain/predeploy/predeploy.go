@@ -0,0 +1,121 @@
+// Package predeploy holds the contracts the EVM genesis places at fixed
+// addresses, so a new network can use them without deploying them first.
+package predeploy
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+)
+
+// Names of the predeploys.
+const (
+	VaultGate  = "VaultGate"
+	Multicall3 = "Multicall3"
+	// Create2 is the deterministic deployment proxy: it creates the code
+	// of its calldata, after a 32 bytes salt, with CREATE2.
+	Create2 = "Create2"
+)
+
+// VaultGateAddress is the address of the VaultGate predeploy.
+var VaultGateAddress = common.HexToAddress("0x0000000000000000000000000000000000001000")
+
+// vaultGateJSON is the hardhat artifact of contracts/contracts/VaultGate.sol,
+// copied from contracts/artifacts so the chain builds without solc. CI checks
+// it still matches the compiled contract, see contracts/scripts.
+//
+//go:embed VaultGate.json
+var vaultGateJSON []byte
+
+// Contract is a predeployed contract.
+type Contract struct {
+	Name    string
+	Address common.Address
+	Code    []byte
+}
+
+// Artifact is the part of a Hardhat or Foundry artifact a predeploy needs.
+type Artifact struct {
+	ContractName     string        `json:"contractName"`
+	ABI              abi.ABI       `json:"abi"`
+	DeployedBytecode hexutil.Bytes `json:"deployedBytecode"`
+}
+
+// ParseArtifact parses a contract artifact. Foundry nests the bytecode in a
+// deployedBytecode object, whose object field it reads then.
+func ParseArtifact(bz []byte) (Artifact, error) {
+	var artifact struct {
+		Artifact
+		DeployedBytecode json.RawMessage `json:"deployedBytecode"`
+	}
+	if err := json.Unmarshal(bz, &artifact); err != nil {
+		return Artifact{}, fmt.Errorf("invalid artifact: %w", err)
+	}
+
+	code := artifact.DeployedBytecode
+	var foundry struct {
+		Object json.RawMessage `json:"object"`
+	}
+	if json.Unmarshal(code, &foundry) == nil && foundry.Object != nil {
+		code = foundry.Object
+	}
+	if err := json.Unmarshal(code, &artifact.Artifact.DeployedBytecode); err != nil {
+		return Artifact{}, fmt.Errorf("invalid artifact deployed bytecode: %w", err)
+	}
+	if len(artifact.Artifact.DeployedBytecode) == 0 {
+		return Artifact{}, errors.New("artifact has no deployed bytecode")
+	}
+
+	return artifact.Artifact, nil
+}
+
+// VaultGateArtifact returns the artifact of the VaultGate predeploy.
+func VaultGateArtifact() Artifact {
+	artifact, err := ParseArtifact(vaultGateJSON)
+	if err != nil {
+		panic(err)
+	}
+	return artifact
+}
+
+// Defaults returns the predeploys of a new network: VaultGate, Multicall3
+// and the CREATE2 factory, the latter two at the addresses they have on
+// Ethereum.
+func Defaults() []Contract {
+	contracts := []Contract{{
+		Name:    VaultGate,
+		Address: VaultGateAddress,
+		Code:    VaultGateArtifact().DeployedBytecode,
+	}}
+
+	for _, name := range []string{Multicall3, Create2} {
+		for _, preinstall := range evmtypes.DefaultPreinstalls {
+			if preinstall.Name == name {
+				contracts = append(contracts, Contract{
+					Name:    name,
+					Address: common.HexToAddress(preinstall.Address),
+					Code:    common.FromHex(preinstall.Code),
+				})
+			}
+		}
+	}
+
+	return contracts
+}
+
+// Preinstall returns the EVM genesis preinstall of c. The EVM creates its
+// account at genesis.
+func (c Contract) Preinstall() evmtypes.Preinstall {
+	return evmtypes.Preinstall{
+		Name:    c.Name,
+		Address: c.Address.Hex(),
+		Code:    hexutil.Encode(c.Code),
+	}
+}
@@ -0,0 +1,131 @@
+package predeploy_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/predeploy"
+	"mirrorvault/testutil/apptest"
+)
+
+func TestParseArtifact(t *testing.T) {
+	gate := predeploy.VaultGateArtifact()
+	require.Equal(t, predeploy.VaultGate, gate.ContractName)
+	require.Contains(t, gate.ABI.Methods, "payToUnlock")
+	require.Contains(t, gate.ABI.Events, "Unlocked")
+	// the runtime is compiler output: it ends with the CBOR metadata solc
+	// appends, naming solc 0.8.21 as in contracts/hardhat.config.ts
+	require.True(t, bytes.HasSuffix(gate.DeployedBytecode, common.FromHex("0x64736f6c63430008150033")))
+
+	hardhat := `{"contractName":"Counter","abi":[],"deployedBytecode":"0x602a"}`
+	foundry := `{"abi":[],"deployedBytecode":{"object":"0x602a","sourceMap":""}}`
+	for _, bz := range []string{hardhat, foundry} {
+		artifact, err := predeploy.ParseArtifact([]byte(bz))
+		require.NoError(t, err, bz)
+		require.Equal(t, []byte{0x60, 0x2a}, []byte(artifact.DeployedBytecode))
+	}
+
+	for _, bz := range []string{
+		`{"abi":[],"deployedBytecode":"0x"}`,
+		`{"abi":[]}`,
+		`{"abi":[],"deployedBytecode":"602a"}`,
+		`[]`,
+	} {
+		_, err := predeploy.ParseArtifact([]byte(bz))
+		require.Error(t, err, bz)
+	}
+}
+
+// TestPredeploys runs the predeploys of an in-memory app. The EVM global
+// config allows a single app per process, so all cases share it.
+func TestPredeploys(t *testing.T) {
+	a, ctx, valAddr := apptest.Setup(t, simtestutil.AppOptionsMap{})
+	from := common.BytesToAddress(valAddr)
+
+	for _, contract := range predeploy.Defaults() {
+		require.Equal(t, contract.Code, a.EVMKeeper.GetCode(ctx, crypto.Keccak256Hash(contract.Code)), contract.Name)
+		require.NotNil(t, a.AuthKeeper.GetAccount(ctx, contract.Address.Bytes()), contract.Name)
+	}
+
+	t.Run("vault gate", func(t *testing.T) {
+		gate := predeploy.VaultGateArtifact()
+		res, err := a.EVMKeeper.CallEVM(ctx, gate.ABI, from, predeploy.VaultGateAddress, false, nil, "MIRROR_VAULT_PRECOMPILE")
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		out, err := gate.ABI.Unpack("MIRROR_VAULT_PRECOMPILE", res.Ret)
+		require.NoError(t, err)
+		require.Equal(t, common.HexToAddress("0x0000000000000000000000000000000000000101"), out[0])
+
+		res, err = a.EVMKeeper.CallEVM(ctx, gate.ABI, from, predeploy.VaultGateAddress, false, nil, "payToUnlock")
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		require.Len(t, res.Logs, 1)
+		require.Equal(t, predeploy.VaultGateAddress.Hex(), res.Logs[0].Address)
+		require.Equal(t, []string{gate.ABI.Events["Unlocked"].ID.Hex(), common.BytesToHash(from.Bytes()).Hex()}, res.Logs[0].Topics)
+	})
+
+	t.Run("multicall3", func(t *testing.T) {
+		multicall := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+		// getChainId()
+		res, err := a.EVMKeeper.CallEVMWithData(ctx, from, &multicall, crypto.Keccak256([]byte("getChainId()"))[:4], false, nil)
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		require.Equal(t, evmtypes.GetEthChainConfig().ChainID, new(big.Int).SetBytes(res.Ret))
+	})
+
+	t.Run("create2", func(t *testing.T) {
+		factory := common.HexToAddress("0x4e59b44847b379578588920ca78fbf26c0b4956c")
+		// creates a contract returning 42
+		initCode := common.FromHex("600a600c600039600a6000f3602a60005260206000f3")
+		salt := common.HexToHash("0x01")
+		res, err := a.EVMKeeper.CallEVMWithData(ctx, from, &factory, append(salt.Bytes(), initCode...), true, nil)
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+
+		created := crypto.CreateAddress2(factory, salt, crypto.Keccak256(initCode))
+		require.Equal(t, created.Bytes(), res.Ret)
+		res, err = a.EVMKeeper.CallEVMWithData(ctx, from, &created, nil, false, nil)
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(42), new(big.Int).SetBytes(res.Ret))
+	})
+
+	t.Run("export", func(t *testing.T) {
+		exported, err := a.ExportAppStateAndValidators(false, nil, nil)
+		require.NoError(t, err)
+		var genState map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(exported.AppState, &genState))
+
+		// the predeploys are exported as contracts, which init genesis
+		// creates again with their auth accounts
+		var evmGenState evmtypes.GenesisState
+		require.NoError(t, a.AppCodec().UnmarshalJSON(genState[evmtypes.ModuleName], &evmGenState))
+		require.NoError(t, evmGenState.Validate())
+		authGenState := authtypes.GetGenesisStateFromAppState(a.AppCodec(), genState)
+		accounts, err := authtypes.UnpackAccounts(authGenState.Accounts)
+		require.NoError(t, err)
+
+		for _, contract := range predeploy.Defaults() {
+			i := -1
+			for j, acc := range evmGenState.Accounts {
+				if common.HexToAddress(acc.Address) == contract.Address {
+					i = j
+				}
+			}
+			require.NotEqual(t, -1, i, contract.Name)
+			require.Equal(t, common.Bytes2Hex(contract.Code), evmGenState.Accounts[i].Code, contract.Name)
+			require.True(t, accounts.Contains(sdk.AccAddress(contract.Address.Bytes())), contract.Name)
+		}
+	})
+}
@@ -0,0 +1,89 @@
+// Package apptest sets up the in-memory apps of the tests running the EVM.
+package apptest
+
+import (
+	"testing"
+	"time"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/ethereum/go-ethereum/core/vm/program"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/log"
+
+	servertypes "github.com/cosmos/cosmos-sdk/server/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	minttypes "github.com/cosmos/cosmos-sdk/x/mint/types"
+
+	"mirrorvault/app"
+)
+
+// Setup returns an in-memory app, a context of its second block and the
+// operator of its validator, whose account holds funds. The EVM global
+// config allows a single app per process, so a test binary sets up one.
+func Setup(t testing.TB, appOpts servertypes.AppOptions) (*app.App, sdk.Context, sdk.ValAddress) {
+	t.Helper()
+
+	a, err := app.NewInMemory(log.NewNopLogger(), appOpts)
+	require.NoError(t, err)
+	ctx, valAddr := ProposerContext(t, a, 2)
+
+	return a, ctx, valAddr
+}
+
+// ProposerContext returns an uncached context of a block at height proposed
+// by the validator of a, since the EVM pays the block proposer, and the
+// operator of the validator.
+func ProposerContext(t testing.TB, a *app.App, height int64) (sdk.Context, sdk.ValAddress) {
+	t.Helper()
+
+	ctx := a.NewUncachedContext(false, cmtproto.Header{Height: height})
+	vals, err := a.StakingKeeper.GetAllValidators(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, vals)
+	consAddr, err := vals[0].GetConsAddr()
+	require.NoError(t, err)
+	valAddr, err := sdk.ValAddressFromBech32(vals[0].OperatorAddress)
+	require.NoError(t, err)
+
+	return a.NewUncachedContext(false, cmtproto.Header{Height: height, ProposerAddress: consAddr}), valAddr
+}
+
+// PassProposal submits a proposal of msgs from the validator operator of a,
+// deposits and votes yes with it, and ends the voting period. The validator
+// holds all the stake, so the proposal passes and gov executes msgs. It
+// returns the tallied proposal, which failed if a message did.
+func PassProposal(t testing.TB, a *app.App, ctx sdk.Context, msgs ...sdk.Msg) govv1.Proposal {
+	t.Helper()
+
+	_, valAddr := ProposerContext(t, a, ctx.BlockHeight())
+	operator := sdk.AccAddress(valAddr)
+
+	params, err := a.GovKeeper.Params.Get(ctx)
+	require.NoError(t, err)
+	require.NoError(t, a.BankKeeper.MintCoins(ctx, minttypes.ModuleName, params.MinDeposit))
+	require.NoError(t, a.BankKeeper.SendCoinsFromModuleToAccount(ctx, minttypes.ModuleName, operator, params.MinDeposit))
+
+	proposal, err := a.GovKeeper.SubmitProposal(ctx, msgs, "", "proposal", "a proposal of the tests", operator, false)
+	require.NoError(t, err)
+	_, err = a.GovKeeper.AddDeposit(ctx, proposal.Id, operator, params.MinDeposit)
+	require.NoError(t, err)
+	require.NoError(t, a.GovKeeper.AddVote(ctx, proposal.Id, operator, govv1.NewNonSplitVoteOption(govv1.OptionYes), ""))
+
+	proposal, err = a.GovKeeper.Proposals.Get(ctx, proposal.Id)
+	require.NoError(t, err)
+	require.NoError(t, gov.EndBlocker(ctx.WithBlockTime(proposal.VotingEndTime.Add(time.Second)), a.GovKeeper))
+
+	proposal, err = a.GovKeeper.Proposals.Get(ctx, proposal.Id)
+	require.NoError(t, err)
+
+	return proposal
+}
+
+// InitCode returns the init code of a contract running runtime: it copies
+// runtime from the code and returns it.
+func InitCode(runtime []byte) []byte {
+	return program.New().ReturnViaCodeCopy(runtime).Bytes()
+}
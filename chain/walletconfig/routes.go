@@ -0,0 +1,35 @@
+package walletconfig
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// Route is the API server path that serves the wallet payloads, {kind} being
+// one of Kinds.
+const Route = "/mirrorvault/wallet/v1/{kind}"
+
+// RegisterRoutes registers the wallet config endpoints on the API server
+// router.
+func RegisterRoutes(rtr *mux.Router, cfg Config) {
+	rtr.HandleFunc(Route, func(w http.ResponseWriter, req *http.Request) {
+		payload, err := cfg.Payload(mux.Vars(req)["kind"])
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// the dashboard is usually served from another origin
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		_ = json.NewEncoder(w).Encode(payload)
+	}).Methods(http.MethodGet)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
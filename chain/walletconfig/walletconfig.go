@@ -0,0 +1,368 @@
+package walletconfig
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	// Keplr is the kind of the experimentalSuggestChain payload.
+	Keplr = "keplr"
+	// MetaMask is the kind of the wallet_addEthereumChain payload.
+	MetaMask = "metamask"
+	// ChainRegistry is the kind of the cosmos/chain-registry chain.json entry.
+	ChainRegistry = "chain-registry"
+)
+
+// Kinds lists the supported payload kinds.
+var Kinds = []string{Keplr, MetaMask, ChainRegistry}
+
+// Default gas price steps, used when the node accepts zero gas prices. They
+// are the Keplr defaults.
+var (
+	DefaultLowGasPrice     = math.LegacyMustNewDecFromStr("0.01")
+	DefaultAverageGasPrice = math.LegacyMustNewDecFromStr("0.025")
+	DefaultHighGasPrice    = math.LegacyMustNewDecFromStr("0.04")
+)
+
+// Config holds the node settings the wallet payloads are derived from.
+type Config struct {
+	ChainName    string
+	ChainID      string
+	EVMChainID   uint64
+	Bech32Prefix string
+	CoinType     uint32
+
+	// BaseDenom is the on-chain denom, DisplayDenom its human readable form
+	// and Decimals the exponent between them on the Cosmos side. The EVM
+	// always shows the native coin with 18 decimals.
+	BaseDenom    string
+	DisplayDenom string
+	Decimals     uint32
+
+	RPC     string
+	REST    string
+	JSONRPC string
+
+	// MinGasPrices are the minimum gas prices of the node.
+	MinGasPrices sdk.DecCoins
+}
+
+// GasPriceStep is the low/average/high gas price of the base denom.
+type GasPriceStep struct {
+	Low     float64 `json:"low"`
+	Average float64 `json:"average"`
+	High    float64 `json:"high"`
+}
+
+// GasPriceStep returns the gas price steps of the base denom. The node minimum
+// is the low step; average and high are 2x and 4x of it. A zero minimum falls
+// back to the default steps.
+func (c Config) GasPriceStep() GasPriceStep {
+	low := c.MinGasPrices.AmountOf(c.BaseDenom)
+	if !low.IsPositive() {
+		return GasPriceStep{
+			Low:     DefaultLowGasPrice.MustFloat64(),
+			Average: DefaultAverageGasPrice.MustFloat64(),
+			High:    DefaultHighGasPrice.MustFloat64(),
+		}
+	}
+
+	return GasPriceStep{
+		Low:     low.MustFloat64(),
+		Average: low.MulInt64(2).MustFloat64(),
+		High:    low.MulInt64(4).MustFloat64(),
+	}
+}
+
+// Payload returns the payload of the given kind.
+func (c Config) Payload(kind string) (any, error) {
+	switch kind {
+	case Keplr:
+		return c.Keplr(), nil
+	case MetaMask:
+		return c.MetaMask()
+	case ChainRegistry:
+		return c.ChainRegistry(), nil
+	default:
+		return nil, fmt.Errorf("unknown wallet config %q, expected one of %s", kind, strings.Join(Kinds, ", "))
+	}
+}
+
+// KeplrCurrency is a currency of the Keplr chain info.
+type KeplrCurrency struct {
+	CoinDenom        string        `json:"coinDenom"`
+	CoinMinimalDenom string        `json:"coinMinimalDenom"`
+	CoinDecimals     uint32        `json:"coinDecimals"`
+	GasPriceStep     *GasPriceStep `json:"gasPriceStep,omitempty"`
+}
+
+// KeplrBech32Config is the bech32 configuration of the Keplr chain info.
+type KeplrBech32Config struct {
+	Bech32PrefixAccAddr  string `json:"bech32PrefixAccAddr"`
+	Bech32PrefixAccPub   string `json:"bech32PrefixAccPub"`
+	Bech32PrefixValAddr  string `json:"bech32PrefixValAddr"`
+	Bech32PrefixValPub   string `json:"bech32PrefixValPub"`
+	Bech32PrefixConsAddr string `json:"bech32PrefixConsAddr"`
+	Bech32PrefixConsPub  string `json:"bech32PrefixConsPub"`
+}
+
+// KeplrChainInfo is the argument of window.keplr.experimentalSuggestChain.
+type KeplrChainInfo struct {
+	ChainID       string            `json:"chainId"`
+	ChainName     string            `json:"chainName"`
+	RPC           string            `json:"rpc"`
+	REST          string            `json:"rest"`
+	EVM           *KeplrEVM         `json:"evm,omitempty"`
+	BIP44         KeplrBIP44        `json:"bip44"`
+	Bech32Config  KeplrBech32Config `json:"bech32Config"`
+	Currencies    []KeplrCurrency   `json:"currencies"`
+	FeeCurrencies []KeplrCurrency   `json:"feeCurrencies"`
+	StakeCurrency KeplrCurrency     `json:"stakeCurrency"`
+	Features      []string          `json:"features"`
+}
+
+// KeplrBIP44 is the BIP-44 configuration of the Keplr chain info.
+type KeplrBIP44 struct {
+	CoinType uint32 `json:"coinType"`
+}
+
+// KeplrEVM links the Keplr chain info to the EVM of the chain.
+type KeplrEVM struct {
+	ChainID uint64 `json:"chainId"`
+	RPC     string `json:"rpc"`
+}
+
+// Keplr returns the experimentalSuggestChain payload.
+func (c Config) Keplr() KeplrChainInfo {
+	step := c.GasPriceStep()
+	currency := KeplrCurrency{
+		CoinDenom:        c.DisplayDenom,
+		CoinMinimalDenom: c.BaseDenom,
+		CoinDecimals:     c.Decimals,
+	}
+	feeCurrency := currency
+	feeCurrency.GasPriceStep = &step
+
+	info := KeplrChainInfo{
+		ChainID:   c.ChainID,
+		ChainName: c.ChainName,
+		RPC:       c.RPC,
+		REST:      c.REST,
+		BIP44:     KeplrBIP44{CoinType: c.CoinType},
+		Bech32Config: KeplrBech32Config{
+			Bech32PrefixAccAddr:  c.Bech32Prefix,
+			Bech32PrefixAccPub:   c.Bech32Prefix + "pub",
+			Bech32PrefixValAddr:  c.Bech32Prefix + "valoper",
+			Bech32PrefixValPub:   c.Bech32Prefix + "valoperpub",
+			Bech32PrefixConsAddr: c.Bech32Prefix + "valcons",
+			Bech32PrefixConsPub:  c.Bech32Prefix + "valconspub",
+		},
+		Currencies:    []KeplrCurrency{currency},
+		FeeCurrencies: []KeplrCurrency{feeCurrency},
+		StakeCurrency: currency,
+		// eth-address-gen and eth-key-sign make Keplr derive keys and sign
+		// like MetaMask, so both wallets share the same account
+		Features: []string{"eth-address-gen", "eth-key-sign"},
+	}
+	if c.JSONRPC != "" {
+		info.EVM = &KeplrEVM{ChainID: c.EVMChainID, RPC: c.JSONRPC}
+	}
+
+	return info
+}
+
+// NativeCurrency is the native currency of a wallet_addEthereumChain payload.
+type NativeCurrency struct {
+	Name     string `json:"name"`
+	Symbol   string `json:"symbol"`
+	Decimals uint32 `json:"decimals"`
+}
+
+// EthereumChain is the argument of the wallet_addEthereumChain RPC method
+// (EIP-3085).
+type EthereumChain struct {
+	ChainID           string         `json:"chainId"`
+	ChainName         string         `json:"chainName"`
+	NativeCurrency    NativeCurrency `json:"nativeCurrency"`
+	RPCUrls           []string       `json:"rpcUrls"`
+	BlockExplorerUrls []string       `json:"blockExplorerUrls,omitempty"`
+}
+
+// MetaMask returns the wallet_addEthereumChain payload. EIP-3085 expects the
+// chain id as a 0x prefixed hex string. MetaMask needs an RPC URL, so it
+// fails when the node does not serve JSON-RPC.
+func (c Config) MetaMask() (EthereumChain, error) {
+	if c.JSONRPC == "" {
+		return EthereumChain{}, errors.New("metamask needs a JSON-RPC URL, but JSON-RPC is disabled")
+	}
+
+	return EthereumChain{
+		ChainID:   "0x" + strconv.FormatUint(c.EVMChainID, 16),
+		ChainName: c.ChainName,
+		NativeCurrency: NativeCurrency{
+			Name:     c.DisplayDenom,
+			Symbol:   c.DisplayDenom,
+			Decimals: 18,
+		},
+		RPCUrls: []string{c.JSONRPC},
+	}, nil
+}
+
+// RegistryChain is a cosmos/chain-registry chain.json entry.
+type RegistryChain struct {
+	Schema       string          `json:"$schema"`
+	ChainName    string          `json:"chain_name"`
+	ChainType    string          `json:"chain_type"`
+	ChainID      string          `json:"chain_id"`
+	PrettyName   string          `json:"pretty_name"`
+	Status       string          `json:"status"`
+	NetworkType  string          `json:"network_type"`
+	Bech32Prefix string          `json:"bech32_prefix"`
+	Slip44       uint32          `json:"slip44"`
+	KeyAlgos     []string        `json:"key_algos"`
+	ExtraCodecs  []string        `json:"extra_codecs"`
+	Fees         RegistryFees    `json:"fees"`
+	Staking      RegistryStaking `json:"staking"`
+	APIs         RegistryAPIs    `json:"apis"`
+	Assets       []RegistryAsset `json:"assets"`
+}
+
+// RegistryFees lists the fee tokens of a chain.json entry.
+type RegistryFees struct {
+	FeeTokens []RegistryFeeToken `json:"fee_tokens"`
+}
+
+// RegistryFeeToken is a fee token of a chain.json entry.
+type RegistryFeeToken struct {
+	Denom            string  `json:"denom"`
+	FixedMinGasPrice float64 `json:"fixed_min_gas_price"`
+	LowGasPrice      float64 `json:"low_gas_price"`
+	AverageGasPrice  float64 `json:"average_gas_price"`
+	HighGasPrice     float64 `json:"high_gas_price"`
+}
+
+// RegistryStaking lists the staking tokens of a chain.json entry.
+type RegistryStaking struct {
+	StakingTokens []RegistryDenom `json:"staking_tokens"`
+}
+
+// RegistryDenom refers to a denom of a chain.json entry.
+type RegistryDenom struct {
+	Denom string `json:"denom"`
+}
+
+// RegistryAPIs lists the endpoints of a chain.json entry.
+type RegistryAPIs struct {
+	RPC     []RegistryEndpoint `json:"rpc"`
+	REST    []RegistryEndpoint `json:"rest"`
+	EVMHTTP []RegistryEndpoint `json:"evm-http-jsonrpc,omitempty"`
+}
+
+// RegistryEndpoint is an endpoint of a chain.json entry.
+type RegistryEndpoint struct {
+	Address string `json:"address"`
+}
+
+// RegistryAsset is the asset of the native denom. The chain registry keeps it
+// in assetlist.json; it is inlined here so one document carries everything.
+type RegistryAsset struct {
+	Base       string              `json:"base"`
+	Display    string              `json:"display"`
+	Name       string              `json:"name"`
+	Symbol     string              `json:"symbol"`
+	DenomUnits []RegistryDenomUnit `json:"denom_units"`
+	TypeAsset  string              `json:"type_asset"`
+}
+
+// RegistryDenomUnit is a denom unit of a RegistryAsset.
+type RegistryDenomUnit struct {
+	Denom    string `json:"denom"`
+	Exponent uint32 `json:"exponent"`
+}
+
+// ChainRegistry returns the chain.json entry.
+func (c Config) ChainRegistry() RegistryChain {
+	step := c.GasPriceStep()
+	chain := RegistryChain{
+		Schema:       "../chain.schema.json",
+		ChainName:    c.ChainName,
+		ChainType:    "cosmos",
+		ChainID:      c.ChainID,
+		PrettyName:   c.ChainName,
+		Status:       "live",
+		NetworkType:  "devnet",
+		Bech32Prefix: c.Bech32Prefix,
+		Slip44:       c.CoinType,
+		KeyAlgos:     []string{"ethsecp256k1"},
+		ExtraCodecs:  []string{"ethermint"},
+		Fees: RegistryFees{FeeTokens: []RegistryFeeToken{{
+			Denom:            c.BaseDenom,
+			FixedMinGasPrice: c.MinGasPrices.AmountOf(c.BaseDenom).MustFloat64(),
+			LowGasPrice:      step.Low,
+			AverageGasPrice:  step.Average,
+			HighGasPrice:     step.High,
+		}}},
+		Staking: RegistryStaking{StakingTokens: []RegistryDenom{{Denom: c.BaseDenom}}},
+		APIs: RegistryAPIs{
+			RPC:  []RegistryEndpoint{{Address: c.RPC}},
+			REST: []RegistryEndpoint{{Address: c.REST}},
+		},
+		Assets: []RegistryAsset{{
+			Base:    c.BaseDenom,
+			Display: strings.ToLower(c.DisplayDenom),
+			Name:    c.DisplayDenom,
+			Symbol:  c.DisplayDenom,
+			DenomUnits: []RegistryDenomUnit{
+				{Denom: c.BaseDenom, Exponent: 0},
+				{Denom: strings.ToLower(c.DisplayDenom), Exponent: c.Decimals},
+			},
+			TypeAsset: "sdk.coin",
+		}},
+	}
+	if c.JSONRPC != "" {
+		chain.APIs.EVMHTTP = []RegistryEndpoint{{Address: c.JSONRPC}}
+	}
+
+	return chain
+}
+
+// PublicURL turns a listen address such as "tcp://0.0.0.0:1317" into a URL a
+// wallet can reach, e.g. "http://localhost:1317". Wildcard hosts are replaced
+// with localhost.
+func PublicURL(listenAddr string) string {
+	if listenAddr == "" {
+		return ""
+	}
+	if !strings.Contains(listenAddr, "://") {
+		listenAddr = "tcp://" + listenAddr
+	}
+
+	u, err := url.Parse(listenAddr)
+	if err != nil {
+		return listenAddr
+	}
+
+	host, port := u.Hostname(), u.Port()
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+
+	scheme := u.Scheme
+	if scheme == "tcp" {
+		scheme = "http"
+	}
+
+	if port == "" {
+		return scheme + "://" + host
+	}
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
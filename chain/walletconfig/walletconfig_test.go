@@ -0,0 +1,102 @@
+package walletconfig_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"mirrorvault/walletconfig"
+)
+
+func testConfig() walletconfig.Config {
+	return walletconfig.Config{
+		ChainName:    "mirrorvault",
+		ChainID:      "mirror-vault-localnet",
+		EVMChainID:   7777,
+		Bech32Prefix: "mirror",
+		CoinType:     60,
+		BaseDenom:    "umvlt",
+		DisplayDenom: "MVLT",
+		Decimals:     6,
+		RPC:          "http://localhost:26657",
+		REST:         "http://localhost:1317",
+		JSONRPC:      "http://localhost:8545",
+	}
+}
+
+func TestPublicURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"tcp://0.0.0.0:1317":    "http://localhost:1317",
+		"tcp://127.0.0.1:26657": "http://127.0.0.1:26657",
+		"0.0.0.0:8545":          "http://localhost:8545",
+		":8545":                 "http://localhost:8545",
+		"https://rpc.example":   "https://rpc.example",
+		"":                      "",
+	} {
+		require.Equal(t, want, walletconfig.PublicURL(in), in)
+	}
+}
+
+func TestGasPriceStep(t *testing.T) {
+	cfg := testConfig()
+	require.Equal(t, walletconfig.GasPriceStep{Low: 0.01, Average: 0.025, High: 0.04}, cfg.GasPriceStep())
+
+	cfg.MinGasPrices = sdk.NewDecCoins(sdk.NewDecCoinFromDec("umvlt", math.LegacyMustNewDecFromStr("0.5")))
+	require.Equal(t, walletconfig.GasPriceStep{Low: 0.5, Average: 1, High: 2}, cfg.GasPriceStep())
+}
+
+func TestPayloads(t *testing.T) {
+	cfg := testConfig()
+
+	keplr := cfg.Keplr()
+	require.Equal(t, "mirror-vault-localnet", keplr.ChainID)
+	require.Equal(t, uint32(60), keplr.BIP44.CoinType)
+	require.Equal(t, "mirrorvaloper", keplr.Bech32Config.Bech32PrefixValAddr)
+	require.Equal(t, uint64(7777), keplr.EVM.ChainID)
+	require.NotNil(t, keplr.FeeCurrencies[0].GasPriceStep)
+
+	metaMask, err := cfg.MetaMask()
+	require.NoError(t, err)
+	require.Equal(t, "0x1e61", metaMask.ChainID)
+	require.Equal(t, uint32(18), metaMask.NativeCurrency.Decimals)
+	require.Equal(t, []string{"http://localhost:8545"}, metaMask.RPCUrls)
+
+	registry := cfg.ChainRegistry()
+	require.Equal(t, "mirror", registry.Bech32Prefix)
+	require.Equal(t, "umvlt", registry.Fees.FeeTokens[0].Denom)
+	require.Equal(t, "http://localhost:8545", registry.APIs.EVMHTTP[0].Address)
+
+	_, err = cfg.Payload("trust-wallet")
+	require.Error(t, err)
+
+	cfg.JSONRPC = ""
+	_, err = cfg.Payload(walletconfig.MetaMask)
+	require.Error(t, err)
+}
+
+func TestRoutes(t *testing.T) {
+	rtr := mux.NewRouter()
+	walletconfig.RegisterRoutes(rtr, testConfig())
+
+	rec := httptest.NewRecorder()
+	rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mirrorvault/wallet/v1/metamask", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var chain walletconfig.EthereumChain
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &chain))
+	want, err := testConfig().MetaMask()
+	require.NoError(t, err)
+	require.Equal(t, want, chain)
+
+	rec = httptest.NewRecorder()
+	rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/mirrorvault/wallet/v1/unknown", nil))
+	require.Equal(t, http.StatusNotFound, rec.Code)
+}
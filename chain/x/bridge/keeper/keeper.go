@@ -0,0 +1,151 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	corestore "cosmossdk.io/core/store"
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"mirrorvault/x/bridge/types"
+)
+
+// Keeper keeps the storage credits the bridge grants.
+type Keeper struct {
+	storeService          corestore.KVStoreService
+	transientStoreService corestore.TransientStoreService
+	// authority can update the params, the gov module by default
+	authority string
+	cdc       codec.BinaryCodec
+
+	callbacksKeeper types.CallbacksKeeper
+}
+
+// NewKeeper returns the bridge keeper.
+func NewKeeper(
+	storeService corestore.KVStoreService,
+	transientStoreService corestore.TransientStoreService,
+	authority string,
+	cdc codec.BinaryCodec,
+	callbacksKeeper types.CallbacksKeeper,
+) Keeper {
+	return Keeper{
+		storeService:          storeService,
+		transientStoreService: transientStoreService,
+		authority:             authority,
+		cdc:                   cdc,
+		callbacksKeeper:       callbacksKeeper,
+	}
+}
+
+// GetAuthority returns the address that can update the params.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// GetParams returns the params.
+func (k Keeper) GetParams(ctx context.Context) (types.Params, error) {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.ParamsKey)
+	if err != nil || bz == nil {
+		return types.DefaultParams(), err
+	}
+
+	var params types.Params
+	if err := k.cdc.Unmarshal(bz, &params); err != nil {
+		return types.Params{}, err
+	}
+
+	return params, nil
+}
+
+// SetParams validates and sets the params.
+func (k Keeper) SetParams(ctx context.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return k.storeService.OpenKVStore(ctx).Set(types.ParamsKey, k.cdc.MustMarshal(&params))
+}
+
+// UpdateParams sets the params on behalf of authority, which must be the
+// keeper authority. This is how governance turns the log mode on and
+// registers emitters.
+func (k Keeper) UpdateParams(ctx context.Context, authority string, params types.Params) error {
+	if authority != k.authority {
+		return errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, authority)
+	}
+
+	return k.SetParams(ctx, params)
+}
+
+// GetCredits returns the storage credits of addr.
+func (k Keeper) GetCredits(ctx context.Context, addr sdk.AccAddress) (uint64, error) {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.CreditKey(addr))
+	if err != nil || bz == nil {
+		return 0, err
+	}
+
+	return binary.BigEndian.Uint64(bz), nil
+}
+
+// GrantCredit adds a storage credit to addr and returns its credits.
+func (k Keeper) GrantCredit(ctx context.Context, addr sdk.AccAddress) (uint64, error) {
+	credits, err := k.GetCredits(ctx, addr)
+	if err != nil {
+		return 0, err
+	}
+	credits++
+
+	return credits, k.setCredits(ctx, addr, credits)
+}
+
+func (k Keeper) setCredits(ctx context.Context, addr sdk.AccAddress, credits uint64) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.CreditKey(addr), binary.BigEndian.AppendUint64(nil, credits))
+}
+
+// InitGenesis sets the params and credits of genState.
+func (k Keeper) InitGenesis(ctx context.Context, genState types.GenesisState) error {
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		return fmt.Errorf("invalid bridge params: %w", err)
+	}
+	for _, c := range genState.Credits {
+		addr, err := sdk.AccAddressFromBech32(c.Address)
+		if err != nil {
+			return err
+		}
+		if err := k.setCredits(ctx, addr, c.Count); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ExportGenesis returns the params and credits.
+func (k Keeper) ExportGenesis(ctx context.Context) (*types.GenesisState, error) {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	iter := storetypes.KVStorePrefixIterator(store, types.CreditPrefix)
+	defer iter.Close()
+
+	var credits []types.Credit
+	for ; iter.Valid(); iter.Next() {
+		credits = append(credits, types.Credit{
+			Address: sdk.AccAddress(iter.Key()[len(types.CreditPrefix):]).String(),
+			Count:   binary.BigEndian.Uint64(iter.Value()),
+		})
+	}
+
+	return &types.GenesisState{Params: params, Credits: credits}, nil
+}
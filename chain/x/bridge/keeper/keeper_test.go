@@ -0,0 +1,223 @@
+package keeper_test
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/predeploy"
+	"mirrorvault/testutil/apptest"
+	"mirrorvault/x/bridge"
+	"mirrorvault/x/bridge/precompile"
+	"mirrorvault/x/bridge/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+	require.False(t, types.DefaultParams().LogMode)
+	require.True(t, types.DefaultParams().IsEmitter(predeploy.VaultGateAddress))
+
+	gate := predeploy.VaultGateAddress.Hex()
+	for name, params := range map[string]types.Params{
+		"address":   {Emitters: []string{"mirror1qqqq"}},
+		"duplicate": {Emitters: []string{gate, common.HexToAddress(gate).String()}},
+	} {
+		require.Error(t, params.Validate(), name)
+	}
+
+	gs := types.DefaultGenesis()
+	gs.Credits = []types.Credit{{Address: sdk.AccAddress(predeploy.VaultGateAddress.Bytes()).String()}}
+	require.Error(t, gs.Validate())
+}
+
+// multicallABI is the aggregate method of Multicall3, which calls VaultGate
+// from a contract.
+const multicallABI = `[{"inputs":[{"components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}],"name":"calls","type":"tuple[]"}],"name":"aggregate","outputs":[{"name":"blockNumber","type":"uint256"},{"name":"returnData","type":"bytes[]"}],"stateMutability":"payable","type":"function"}]`
+
+// TestUnlocks unlocks storage through VaultGate in EVM txs of an in-memory
+// app, through the precompile and in log mode. The EVM global config allows a single
+// app per process, so all cases share it.
+func TestUnlocks(t *testing.T) {
+	a, ctx, _ := apptest.Setup(t, simtestutil.AppOptionsMap{})
+	ctx = ctx.WithConsensusParams(cmtproto.ConsensusParams{Block: &cmtproto.BlockParams{MaxGas: 10_000_000}})
+
+	// the user has no funds, so the txs are free
+	feemarketParams := a.FeeMarketKeeper.GetParams(ctx)
+	feemarketParams.NoBaseFee = true
+	feemarketParams.BaseFee = math.LegacyZeroDec()
+	require.NoError(t, a.FeeMarketKeeper.SetParams(ctx, feemarketParams))
+
+	k := a.BridgeKeeper
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	user := crypto.PubkeyToAddress(key.PublicKey)
+
+	// send delivers a tx of the user, with its own hash. The ante handler,
+	// which increments the nonce, is skipped.
+	var nonce uint64
+	send := func(to *common.Address, data []byte) *evmtypes.MsgEthereumTxResponse {
+		signer := ethtypes.LatestSignerForChainID(evmtypes.GetEthChainConfig().ChainID)
+		signed, err := ethtypes.SignTx(ethtypes.NewTx(&ethtypes.LegacyTx{
+			Nonce:    nonce,
+			GasPrice: big.NewInt(0),
+			Gas:      200_000,
+			To:       to,
+			Data:     data,
+		}), signer, key)
+		require.NoError(t, err)
+		nonce++
+
+		msg := &evmtypes.MsgEthereumTx{}
+		msg.FromEthereumTx(signed)
+		msg.From = user.Bytes()
+		res, err := a.EVMKeeper.EthereumTx(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()), msg)
+		require.NoError(t, err)
+		require.Empty(t, res.VmError)
+		return res
+	}
+	unlock, err := predeploy.VaultGateArtifact().ABI.Pack("payToUnlock")
+	require.NoError(t, err)
+	payToUnlock := func() {
+		res := send(&predeploy.VaultGateAddress, unlock)
+		require.Len(t, res.Logs, 1)
+		require.Equal(t, types.UnlockedTopic.Hex(), res.Logs[0].Topics[0])
+	}
+	// the user unlocks through Multicall3, so VaultGate unlocks for it
+	multicall := common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+	multicallABI, err := abi.JSON(strings.NewReader(multicallABI))
+	require.NoError(t, err)
+	aggregate, err := multicallABI.Pack("aggregate", []struct {
+		Target   common.Address
+		CallData []byte
+	}{{predeploy.VaultGateAddress, unlock}})
+	require.NoError(t, err)
+	payToUnlockThroughContract := func() {
+		res := send(&multicall, aggregate)
+		require.Len(t, res.Logs, 1)
+		require.Equal(t, common.BytesToHash(multicall.Bytes()).Hex(), res.Logs[0].Topics[1])
+	}
+	creditsOf := func(addr common.Address) uint64 {
+		credits, err := k.GetCredits(ctx, addr.Bytes())
+		require.NoError(t, err)
+		return credits
+	}
+	credits := func() uint64 { return creditsOf(user) }
+	endBlock := func() int {
+		ctx := ctx.WithEventManager(sdk.NewEventManager())
+		require.NoError(t, k.GrantUnlocks(ctx))
+		return len(ctx.EventManager().Events())
+	}
+
+	t.Run("precompile mode", func(t *testing.T) {
+		// VaultGate calls the bridge precompile, which grants the credits in
+		// the tx. The logs are ignored.
+		payToUnlock()
+		payToUnlock()
+		require.Equal(t, uint64(2), credits())
+		require.Zero(t, endBlock())
+		require.Equal(t, uint64(2), credits())
+
+		// the credit goes to the user of the Unlocked log, the caller of
+		// VaultGate, not to the tx origin
+		payToUnlockThroughContract()
+		require.Equal(t, uint64(1), creditsOf(multicall))
+		require.Equal(t, uint64(2), credits())
+
+		res, err := a.EVMKeeper.CallEVM(ctx, precompile.ABI, user, precompile.Address, false, nil, precompile.CreditsMethod, user)
+		require.NoError(t, err)
+		out, err := precompile.ABI.Unpack(precompile.CreditsMethod, res.Ret)
+		require.NoError(t, err)
+		require.Equal(t, uint64(2), out[0])
+
+		// only the emitters unlock
+		_, err = a.EVMKeeper.CallEVM(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()), precompile.ABI, user, precompile.Address, true, nil, precompile.UnlockMethod, user)
+		require.Error(t, err)
+		require.Equal(t, uint64(2), credits())
+	})
+
+	t.Run("log mode", func(t *testing.T) {
+		params := types.DefaultParams()
+		params.LogMode = true
+		require.Error(t, k.UpdateParams(ctx, sdk.AccAddress(user.Bytes()).String(), params))
+		proposal := apptest.PassProposal(t, a, ctx, &types.MsgUpdateParams{Authority: k.GetAuthority(), Params: params})
+		require.Equal(t, govv1.StatusPassed, proposal.Status, proposal.FailedReason)
+		stored, err := k.GetParams(ctx)
+		require.NoError(t, err)
+		require.Equal(t, params, stored)
+
+		// the same unlocks grant the same credits, at the end of the block
+		payToUnlock()
+		payToUnlock()
+		require.Equal(t, uint64(2), credits())
+		require.Equal(t, 2, endBlock())
+		require.Equal(t, uint64(4), credits())
+
+		// the logs of the block grant once
+		require.Zero(t, endBlock())
+		require.Equal(t, uint64(4), credits())
+
+		// through a contract, the same account as in precompile mode
+		payToUnlockThroughContract()
+		require.Equal(t, 1, endBlock())
+		require.Equal(t, uint64(2), creditsOf(multicall))
+		require.Equal(t, uint64(4), credits())
+	})
+
+	t.Run("emitters", func(t *testing.T) {
+		// emits Unlocked(msg.sender), but isn't an emitter
+		runtime := []byte{byte(vm.CALLER), byte(vm.PUSH32)}
+		runtime = append(runtime, types.UnlockedTopic.Bytes()...)
+		runtime = append(runtime, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG2), byte(vm.STOP))
+		res := send(nil, apptest.InitCode(runtime))
+		require.Empty(t, res.VmError)
+		emitter := crypto.CreateAddress(user, nonce-1)
+
+		send(&emitter, nil)
+		require.Zero(t, endBlock())
+		require.Equal(t, uint64(4), credits())
+
+		params, err := k.GetParams(ctx)
+		require.NoError(t, err)
+		params.Emitters = append(params.Emitters, emitter.Hex())
+		require.NoError(t, k.UpdateParams(ctx, k.GetAuthority(), params))
+		send(&emitter, nil)
+		require.Equal(t, 1, endBlock())
+		require.Equal(t, uint64(5), credits())
+
+		gs, err := k.ExportGenesis(ctx)
+		require.NoError(t, err)
+		require.NoError(t, gs.Validate())
+		require.ElementsMatch(t, []types.Credit{
+			{Address: sdk.AccAddress(user.Bytes()).String(), Count: 5},
+			{Address: sdk.AccAddress(multicall.Bytes()).String(), Count: 2},
+		}, gs.Credits)
+
+		// the genesis goes through the codec of the app, as in a genesis file
+		am := bridge.NewAppModule(k)
+		bz := am.ExportGenesis(ctx, a.AppCodec())
+		require.NoError(t, am.ValidateGenesis(a.AppCodec(), nil, bz))
+		imported, _ := ctx.CacheContext()
+		require.NoError(t, k.SetParams(imported, types.DefaultParams()))
+		am.InitGenesis(imported, a.AppCodec(), bz)
+		reexported, err := k.ExportGenesis(imported)
+		require.NoError(t, err)
+		require.Equal(t, gs, reexported)
+	})
+}
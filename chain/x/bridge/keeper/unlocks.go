@@ -0,0 +1,119 @@
+package keeper
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/bridge/types"
+)
+
+var _ evmtypes.EvmHooks = Hooks{}
+
+// Hooks records the Unlocked logs of the EVM txs, for the end blocker to
+// grant their credits.
+type Hooks struct {
+	k Keeper
+}
+
+// Hooks returns the EVM hooks of the bridge.
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// PostTxProcessing records the Unlocked logs of the emitters in the receipt
+// of a successful tx, when the log mode is on. Failed txs have no logs.
+func (h Hooks) PostTxProcessing(ctx sdk.Context, _ common.Address, _ core.Message, receipt *ethtypes.Receipt) error {
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful || len(receipt.Logs) == 0 {
+		return nil
+	}
+	params, err := h.k.GetParams(ctx)
+	if err != nil || !params.LogMode {
+		return err
+	}
+
+	store := h.k.transientStoreService.OpenTransientStore(ctx)
+	for _, log := range receipt.Logs {
+		user, ok := types.ParseUnlocked(log)
+		if !ok || !params.IsEmitter(log.Address) {
+			continue
+		}
+		if err := store.Set(types.LogKey(types.UnlockPrefix, log.TxHash, log.Index), append(user.Bytes(), log.Address.Bytes()...)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GrantUnlocks grants a storage credit to the user of each Unlocked log of
+// the block, at the end of it. The logs are deleted once granted, so a log
+// grants once.
+func (k Keeper) GrantUnlocks(ctx context.Context) error {
+	params, err := k.GetParams(ctx)
+	if err != nil || !params.LogMode {
+		return err
+	}
+
+	store := k.transientStoreService.OpenTransientStore(ctx)
+	iter := storetypes.KVStorePrefixIterator(runtime.KVStoreAdapter(store), types.UnlockPrefix)
+	defer iter.Close()
+
+	var granted [][]byte
+	for ; iter.Valid(); iter.Next() {
+		granted = append(granted, iter.Key())
+		logKey := iter.Key()[len(types.UnlockPrefix):]
+		user, emitter := iter.Value()[:common.AddressLength], common.BytesToAddress(iter.Value()[common.AddressLength:])
+		txHash := common.BytesToHash(logKey[:common.HashLength])
+		index := sdk.BigEndianToUint64(logKey[common.HashLength:])
+		if _, err := k.GrantUnlock(ctx, user, emitter,
+			sdk.NewAttribute(types.AttributeKeyTxHash, txHash.Hex()),
+			sdk.NewAttribute(types.AttributeKeyLogIndex, strconv.FormatUint(index, 10)),
+		); err != nil {
+			return err
+		}
+	}
+
+	for _, key := range granted {
+		if err := store.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GrantUnlock grants a storage credit to user for an unlock of emitter, and
+// notifies the contracts subscribed to credit_granted. It returns the
+// credits of user. The credit_granted event has attrs too.
+func (k Keeper) GrantUnlock(ctx context.Context, user sdk.AccAddress, emitter common.Address, attrs ...sdk.Attribute) (uint64, error) {
+	credits, err := k.GrantCredit(ctx, user)
+	if err != nil {
+		return 0, err
+	}
+	data, err := types.CreditGrantedData.Pack(common.BytesToAddress(user), credits)
+	if err != nil {
+		return 0, err
+	}
+	if err := k.callbacksKeeper.Notify(ctx, types.EventTypeCreditGranted, data); err != nil {
+		return 0, err
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(types.EventTypeCreditGranted, append([]sdk.Attribute{
+		sdk.NewAttribute(types.AttributeKeyAddress, user.String()),
+		sdk.NewAttribute(types.AttributeKeyEmitter, emitter.Hex()),
+		sdk.NewAttribute(types.AttributeKeyCredits, strconv.FormatUint(credits, 10)),
+	}, attrs...)...))
+
+	return credits, nil
+}
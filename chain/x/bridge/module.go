@@ -0,0 +1,123 @@
+// Package bridge grants the storage credits VaultGate unlocks are paid for.
+// The canonical bridge is the precompile VaultGate calls, which grants the
+// credit of each unlock of a registered emitter in the tx. The log mode is
+// the event-driven alternative, for contracts that cannot call a precompile:
+// when governance turns it on, an EVM hook records the Unlocked(address)
+// logs of the emitters instead, and the end blocker grants each logged user
+// a credit, once per log. Either way the contracts subscribed to
+// credit_granted are called back through x/callbacks. The credits are kept
+// here until x/vault, which is not part of the app yet, consumes them.
+//
+// Governance sets the log mode and the emitters with MsgUpdateParams. The
+// genesis is still JSON and there is no Query service: the precompile
+// returns the credits.
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc"
+
+	"cosmossdk.io/core/appmodule"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/codec"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/module"
+
+	"mirrorvault/x/bridge/keeper"
+	"mirrorvault/x/bridge/types"
+)
+
+var (
+	_ module.AppModuleBasic   = AppModule{}
+	_ module.HasGenesis       = AppModule{}
+	_ appmodule.AppModule     = AppModule{}
+	_ appmodule.HasServices   = AppModule{}
+	_ appmodule.HasEndBlocker = AppModule{}
+)
+
+// AppModule is the bridge module.
+type AppModule struct {
+	keeper keeper.Keeper
+}
+
+// NewAppModule returns the bridge module.
+func NewAppModule(k keeper.Keeper) AppModule {
+	return AppModule{keeper: k}
+}
+
+// IsOnePerModuleType implements depinject.OnePerModuleType.
+func (AppModule) IsOnePerModuleType() {}
+
+// IsAppModule implements appmodule.AppModule.
+func (AppModule) IsAppModule() {}
+
+// Name returns the module name.
+func (AppModule) Name() string {
+	return types.ModuleName
+}
+
+// RegisterLegacyAminoCodec registers the messages for amino JSON signing.
+func (AppModule) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	types.RegisterLegacyAminoCodec(cdc)
+}
+
+// RegisterInterfaces registers the messages.
+func (AppModule) RegisterInterfaces(registry codectypes.InterfaceRegistry) {
+	types.RegisterInterfaces(registry)
+}
+
+// RegisterServices registers the Msg service.
+func (am AppModule) RegisterServices(registrar grpc.ServiceRegistrar) error {
+	types.RegisterMsgServer(registrar, keeper.NewMsgServerImpl(am.keeper))
+	return nil
+}
+
+// RegisterGRPCGatewayRoutes is a no-op, the module has no query service.
+func (AppModule) RegisterGRPCGatewayRoutes(client.Context, *gwruntime.ServeMux) {}
+
+// DefaultGenesis returns the default genesis.
+func (AppModule) DefaultGenesis(cdc codec.JSONCodec) json.RawMessage {
+	return cdc.MustMarshalJSON(types.DefaultGenesis())
+}
+
+// ValidateGenesis validates the genesis.
+func (AppModule) ValidateGenesis(cdc codec.JSONCodec, _ client.TxEncodingConfig, bz json.RawMessage) error {
+	var genState types.GenesisState
+	if err := cdc.UnmarshalJSON(bz, &genState); err != nil {
+		return fmt.Errorf("failed to unmarshal %s genesis state: %w", types.ModuleName, err)
+	}
+
+	return genState.Validate()
+}
+
+// InitGenesis initializes the module state from genesis.
+func (am AppModule) InitGenesis(ctx sdk.Context, cdc codec.JSONCodec, bz json.RawMessage) {
+	var genState types.GenesisState
+	if err := cdc.UnmarshalJSON(bz, &genState); err != nil {
+		panic(fmt.Errorf("failed to unmarshal %s genesis state: %w", types.ModuleName, err))
+	}
+	if err := am.keeper.InitGenesis(ctx, genState); err != nil {
+		panic(err)
+	}
+}
+
+// ExportGenesis exports the module state.
+func (am AppModule) ExportGenesis(ctx sdk.Context, cdc codec.JSONCodec) json.RawMessage {
+	genState, err := am.keeper.ExportGenesis(ctx)
+	if err != nil {
+		panic(err)
+	}
+
+	return cdc.MustMarshalJSON(genState)
+}
+
+// EndBlock grants the credits of the Unlocked logs of the block.
+func (am AppModule) EndBlock(ctx context.Context) error {
+	return am.keeper.GrantUnlocks(ctx)
+}
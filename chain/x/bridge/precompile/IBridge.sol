@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: MIT
+pragma solidity >=0.8.17;
+
+/// @dev The address of the bridge precompile.
+address constant BRIDGE_PRECOMPILE_ADDRESS = 0x0000000000000000000000000000000000000101;
+
+/// @dev The bridge precompile.
+IBridge constant BRIDGE_CONTRACT = IBridge(BRIDGE_PRECOMPILE_ADDRESS);
+
+/// @title Bridge precompile
+/// @dev Grants the storage credits x/vault consumes. An emitter, VaultGate by
+/// default, calls unlock on each unlock with the user it emits Unlocked for,
+/// which grants that user a credit. While the log mode of x/bridge is on the
+/// call grants nothing: the Unlocked log of the emitter grants the credit at
+/// the end of the block instead.
+/// @custom:address 0x0000000000000000000000000000000000000101
+interface IBridge {
+    /// @dev Grants a credit to user. Reverts if the caller is not an emitter.
+    function unlock(address user) external;
+
+    /// @dev Returns the storage credits of user.
+    function credits(address user) external view returns (uint64);
+}
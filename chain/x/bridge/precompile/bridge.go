@@ -0,0 +1,161 @@
+// Package precompile is the bridge precompile VaultGate calls on each
+// unlock, which grants the storage credit of the unlock in the tx itself.
+package precompile
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	cmn "github.com/cosmos/evm/precompiles/common"
+
+	"mirrorvault/x/bridge/keeper"
+)
+
+// Address is the address of the bridge precompile, which VaultGate calls.
+var Address = common.HexToAddress("0x0000000000000000000000000000000000000101")
+
+const (
+	// UnlockMethod is the method the emitters call on each unlock.
+	UnlockMethod = "unlock"
+	// CreditsMethod is the method returning the credits of an address.
+	CreditsMethod = "credits"
+)
+
+var _ vm.PrecompiledContract = &Precompile{}
+
+var (
+	// Embed abi json file to the executable binary. Needed when importing as dependency.
+	//
+	//go:embed abi.json
+	f   embed.FS
+	ABI abi.ABI
+)
+
+func init() {
+	var err error
+	ABI, err = cmn.LoadABI(f, "abi.json")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Precompile is the bridge precompile.
+type Precompile struct {
+	cmn.Precompile
+
+	abi.ABI
+	keeper keeper.Keeper
+}
+
+// NewPrecompile returns the bridge precompile.
+func NewPrecompile(k keeper.Keeper, bankKeeper cmn.BankKeeper) *Precompile {
+	return &Precompile{
+		Precompile: cmn.Precompile{
+			KvGasConfig:           storetypes.KVGasConfig(),
+			TransientKVGasConfig:  storetypes.TransientGasConfig(),
+			ContractAddress:       Address,
+			BalanceHandlerFactory: cmn.NewBalanceHandlerFactory(bankKeeper),
+		},
+		ABI:    ABI,
+		keeper: k,
+	}
+}
+
+// RequiredGas returns the required bare minimum gas to execute the precompile.
+func (p Precompile) RequiredGas(input []byte) uint64 {
+	// NOTE: This check avoid panicking when trying to decode the method ID
+	if len(input) < 4 {
+		return 0
+	}
+
+	method, err := p.MethodById(input[:4])
+	if err != nil {
+		// This should never happen since this method is going to fail during Run
+		return 0
+	}
+
+	return p.Precompile.RequiredGas(input, p.IsTransaction(method))
+}
+
+// Run executes the bridge methods.
+func (p Precompile) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
+	return p.RunNativeAction(evm, contract, func(ctx sdk.Context) ([]byte, error) {
+		return p.Execute(ctx, contract, readonly)
+	})
+}
+
+// Execute executes the bridge method called by contract.
+func (p Precompile) Execute(ctx sdk.Context, contract *vm.Contract, readOnly bool) ([]byte, error) {
+	method, args, err := cmn.SetupABI(p.ABI, contract, readOnly, p.IsTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case UnlockMethod:
+		return p.Unlock(ctx, contract, args)
+	case CreditsMethod:
+		return p.Credits(ctx, method, args)
+	default:
+		return nil, fmt.Errorf(cmn.ErrUnknownMethod, method.Name)
+	}
+}
+
+// IsTransaction returns whether method changes state: the unlocks do.
+func (Precompile) IsTransaction(method *abi.Method) bool {
+	return method.Name == UnlockMethod
+}
+
+// Unlock grants a storage credit to the user the calling emitter unlocked
+// for, the same user as its Unlocked log. In log mode, that log grants the
+// credit at the end of the block instead, so the call grants nothing.
+func (p Precompile) Unlock(ctx sdk.Context, contract *vm.Contract, args []interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 1, len(args))
+	}
+	user, ok := args[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("invalid user address: %v", args[0])
+	}
+
+	params, err := p.keeper.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	emitter := contract.Caller()
+	if !params.IsEmitter(emitter) {
+		return nil, fmt.Errorf("%s is not a bridge emitter", emitter)
+	}
+	if params.LogMode {
+		return nil, nil
+	}
+
+	_, err = p.keeper.GrantUnlock(ctx, user.Bytes(), emitter)
+	return nil, err
+}
+
+// Credits returns the storage credits of an address.
+func (p Precompile) Credits(ctx sdk.Context, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 1, len(args))
+	}
+	user, ok := args[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("invalid user address: %v", args[0])
+	}
+
+	credits, err := p.keeper.GetCredits(ctx, user.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(credits)
+}
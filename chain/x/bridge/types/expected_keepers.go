@@ -0,0 +1,8 @@
+package types
+
+import "context"
+
+// CallbacksKeeper notifies the contracts subscribed to the credits granted.
+type CallbacksKeeper interface {
+	Notify(ctx context.Context, event string, data []byte) error
+}
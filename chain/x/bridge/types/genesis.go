@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultGenesis returns the default params and no credits.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{Params: DefaultParams()}
+}
+
+// Validate validates the genesis state.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(gs.Credits))
+	for _, c := range gs.Credits {
+		addr, err := sdk.AccAddressFromBech32(c.Address)
+		if err != nil {
+			return fmt.Errorf("invalid credit address %q: %w", c.Address, err)
+		}
+		if seen[addr.String()] {
+			return fmt.Errorf("duplicate credits of %s", c.Address)
+		}
+		seen[addr.String()] = true
+		if c.Count == 0 {
+			return fmt.Errorf("%s has no credits", c.Address)
+		}
+	}
+
+	return nil
+}
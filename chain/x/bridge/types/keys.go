@@ -0,0 +1,54 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ModuleName is the name of the bridge module.
+	ModuleName = "bridge"
+
+	// StoreKey is the store key of the bridge module.
+	StoreKey = ModuleName
+
+	// TransientKey is the key of the transient store, where the unlocks of the
+	// block wait for the end blocker.
+	TransientKey = "transient_" + ModuleName
+)
+
+var (
+	// ParamsKey is the key of the params.
+	ParamsKey = []byte{0x01}
+
+	// CreditPrefix prefixes the storage credits, by address.
+	CreditPrefix = []byte{0x02}
+
+	// UnlockPrefix prefixes the unlock logs of the block in the transient
+	// store, by tx hash and log index.
+	UnlockPrefix = []byte{0x01}
+)
+
+// CreditKey returns the key of the credits of addr.
+func CreditKey(addr []byte) []byte {
+	return append(append([]byte{}, CreditPrefix...), addr...)
+}
+
+// LogKey returns the key of the log at index of the tx of txHash, after
+// prefix.
+func LogKey(prefix []byte, txHash common.Hash, index uint) []byte {
+	key := append(append([]byte{}, prefix...), txHash.Bytes()...)
+	return binary.BigEndian.AppendUint64(key, uint64(index))
+}
+
+// Events of the bridge module.
+const (
+	EventTypeCreditGranted = "credit_granted"
+
+	AttributeKeyAddress  = "address"
+	AttributeKeyEmitter  = "emitter"
+	AttributeKeyTxHash   = "tx_hash"
+	AttributeKeyLogIndex = "log_index"
+	AttributeKeyCredits  = "credits"
+)
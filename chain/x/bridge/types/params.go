@@ -0,0 +1,47 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"mirrorvault/predeploy"
+)
+
+// DefaultParams returns the params of the precompile bridge: the log mode is
+// off, and only the VaultGate predeploy is an emitter.
+func DefaultParams() Params {
+	return Params{
+		LogMode:  false,
+		Emitters: []string{predeploy.VaultGateAddress.Hex()},
+	}
+}
+
+// Validate checks the emitters are distinct addresses.
+func (p Params) Validate() error {
+	seen := make(map[common.Address]bool, len(p.Emitters))
+	for _, emitter := range p.Emitters {
+		if !common.IsHexAddress(emitter) {
+			return fmt.Errorf("invalid emitter address %q", emitter)
+		}
+		addr := common.HexToAddress(emitter)
+		if seen[addr] {
+			return fmt.Errorf("duplicate emitter %s", emitter)
+		}
+		seen[addr] = true
+	}
+
+	return nil
+}
+
+// IsEmitter returns whether the unlocks of addr grant credits.
+func (p Params) IsEmitter(addr common.Address) bool {
+	for _, emitter := range p.Emitters {
+		if strings.EqualFold(emitter, addr.Hex()) {
+			return true
+		}
+	}
+
+	return false
+}
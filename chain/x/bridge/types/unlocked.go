@@ -0,0 +1,37 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// UnlockedTopic is the topic of the Unlocked(address indexed user) event of
+// VaultGate.
+var UnlockedTopic = crypto.Keccak256Hash([]byte("Unlocked(address)"))
+
+// CreditGrantedData is the data of the credit_granted notification the
+// callbacks module gets: the address granted and its credits.
+var CreditGrantedData = abi.Arguments{
+	{Name: "user", Type: mustNewType("address")},
+	{Name: "credits", Type: mustNewType("uint64")},
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+
+	return typ
+}
+
+// ParseUnlocked returns the user of an Unlocked log, and whether log is one.
+func ParseUnlocked(log *ethtypes.Log) (common.Address, bool) {
+	if log.Removed || len(log.Topics) != 2 || log.Topics[0] != UnlockedTopic {
+		return common.Address{}, false
+	}
+
+	return common.BytesToAddress(log.Topics[1].Bytes()), true
+}
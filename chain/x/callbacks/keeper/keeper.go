@@ -0,0 +1,122 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	corestore "cosmossdk.io/core/store"
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"mirrorvault/x/callbacks/types"
+)
+
+// Keeper keeps the contracts subscribed to the events of the modules, and
+// calls them at the end of the block a module notifies one in.
+type Keeper struct {
+	storeService corestore.KVStoreService
+	// authority can update the params, the gov module by default
+	authority string
+	cdc       codec.BinaryCodec
+
+	evmKeeper     types.EVMKeeper
+	accountKeeper types.AccountKeeper
+}
+
+// NewKeeper returns the callbacks keeper.
+func NewKeeper(
+	storeService corestore.KVStoreService,
+	authority string,
+	cdc codec.BinaryCodec,
+	evmKeeper types.EVMKeeper,
+	accountKeeper types.AccountKeeper,
+) Keeper {
+	return Keeper{
+		storeService:  storeService,
+		authority:     authority,
+		cdc:           cdc,
+		evmKeeper:     evmKeeper,
+		accountKeeper: accountKeeper,
+	}
+}
+
+// GetAuthority returns the address that can update the params.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// ModuleAddress returns the address the callbacks come from, which the
+// contracts check to trust them.
+func (k Keeper) ModuleAddress() common.Address {
+	return common.BytesToAddress(authtypes.NewModuleAddress(types.ModuleName))
+}
+
+// GetParams returns the params.
+func (k Keeper) GetParams(ctx context.Context) (types.Params, error) {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.ParamsKey)
+	if err != nil || bz == nil {
+		return types.DefaultParams(), err
+	}
+
+	var params types.Params
+	if err := k.cdc.Unmarshal(bz, &params); err != nil {
+		return types.Params{}, err
+	}
+
+	return params, nil
+}
+
+// SetParams validates and sets the params.
+func (k Keeper) SetParams(ctx context.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return k.storeService.OpenKVStore(ctx).Set(types.ParamsKey, k.cdc.MustMarshal(&params))
+}
+
+// UpdateParams sets the params on behalf of authority, which must be the
+// keeper authority, for governance to change the caps. Lowering the caps
+// leaves the existing subscriptions as they are, the new ones must fit.
+func (k Keeper) UpdateParams(ctx context.Context, authority string, params types.Params) error {
+	if authority != k.authority {
+		return errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, authority)
+	}
+
+	return k.SetParams(ctx, params)
+}
+
+// InitGenesis sets the params and subscriptions of genState, and creates the
+// module account the callbacks come from.
+func (k Keeper) InitGenesis(ctx context.Context, genState types.GenesisState) error {
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		return fmt.Errorf("invalid callbacks params: %w", err)
+	}
+	for _, s := range genState.Subscriptions {
+		if err := k.setSubscription(ctx, s); err != nil {
+			return err
+		}
+	}
+	k.accountKeeper.GetModuleAccount(ctx, types.ModuleName)
+
+	return nil
+}
+
+// ExportGenesis returns the params and subscriptions.
+func (k Keeper) ExportGenesis(ctx context.Context) (*types.GenesisState, error) {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	subs, err := k.AllSubscriptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GenesisState{Params: params, Subscriptions: subs}, nil
+}
@@ -0,0 +1,313 @@
+package keeper_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/predeploy"
+	"mirrorvault/testutil/apptest"
+	bridgetypes "mirrorvault/x/bridge/types"
+	"mirrorvault/x/callbacks"
+	"mirrorvault/x/callbacks/precompile"
+	"mirrorvault/x/callbacks/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+
+	for name, params := range map[string]types.Params{
+		"gas limit":     {MaxGasLimit: 20_999, MaxSubscriptions: 1, MaxBlockGas: 100_000},
+		"subscriptions": {MaxGasLimit: 100_000, MaxBlockGas: 100_000},
+		"block gas":     {MaxGasLimit: 100_000, MaxSubscriptions: 1, MaxBlockGas: 99_999},
+	} {
+		require.Error(t, params.Validate(), name)
+	}
+
+	gs := types.DefaultGenesis()
+	sub := types.Subscription{
+		Event:    types.EventSecretStored,
+		Contract: "0x00000000000000000000000000000000000000cc",
+		Owner:    sdk.AccAddress(common.HexToAddress("0xdd").Bytes()).String(),
+		GasLimit: 50_000,
+	}
+	gs.Subscriptions = []types.Subscription{sub}
+	require.NoError(t, gs.Validate())
+	gs.Subscriptions = []types.Subscription{sub, sub}
+	require.Error(t, gs.Validate())
+
+	for name, event := range map[string]string{"empty": "", "upper case": "Secret", "space": "secret stored"} {
+		require.Error(t, types.ValidateEvent(event), name)
+	}
+}
+
+// TestCallbacks subscribes contracts through the precompile of an in-memory
+// app and notifies them. The EVM global config allows a single app per
+// process, so all cases share it.
+func TestCallbacks(t *testing.T) {
+	a, ctx, _ := apptest.Setup(t, simtestutil.AppOptionsMap{})
+
+	k := a.CallbacksKeeper
+	params, err := k.GetParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultParams(), params)
+
+	owner := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	other := common.HexToAddress("0x70997970C51812dc3A010C7d01b50e0d17dc79C8")
+	for _, addr := range []common.Address{owner, other} {
+		a.AuthKeeper.SetAccount(ctx, a.AuthKeeper.NewAccountWithAddress(ctx, addr.Bytes()))
+	}
+
+	// create deploys a contract running runtime
+	create := func(nonce uint64, runtime ...byte) common.Address {
+		ctx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		_, err := a.EVMKeeper.CallEVMWithData(ctx, owner, nil, apptest.InitCode(runtime), true, nil)
+		require.NoError(t, err)
+		return crypto.CreateAddress(owner, nonce)
+	}
+	// ownable returns the runtime running body, whose owner() returns owner.
+	// Only the owner call has 4 bytes of calldata.
+	ownable := func(body ...byte) []byte {
+		dest := byte(7 + len(body))
+		runtime := []byte{byte(vm.CALLDATASIZE), byte(vm.PUSH1), 4, byte(vm.EQ), byte(vm.PUSH1), dest, byte(vm.JUMPI)}
+		runtime = append(runtime, body...)
+		runtime = append(runtime, byte(vm.JUMPDEST), byte(vm.PUSH20))
+		runtime = append(runtime, owner.Bytes()...)
+		return append(runtime, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN))
+	}
+	// stores the caller in slot 0 and the calldata size in slot 1
+	good := create(0, ownable(
+		byte(vm.CALLER), byte(vm.PUSH1), 0, byte(vm.SSTORE),
+		byte(vm.CALLDATASIZE), byte(vm.PUSH1), 1, byte(vm.SSTORE), byte(vm.STOP),
+	)...)
+	// stores the caller in slot 0, then reverts
+	reverting := create(1, ownable(
+		byte(vm.CALLER), byte(vm.PUSH1), 0, byte(vm.SSTORE),
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT),
+	)...)
+	// loops until it runs out of gas
+	looping := create(2, ownable(byte(vm.JUMPDEST), byte(vm.PUSH1), 7, byte(vm.JUMP))...)
+	// has no owner and forwards its calldata to the precompile, reverting if
+	// the call does
+	forwarder := create(3,
+		byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.CALLDATACOPY),
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.CALLDATASIZE), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0,
+		byte(vm.PUSH2), 0x09, 0x04, byte(vm.GAS), byte(vm.CALL),
+		byte(vm.PUSH1), 27, byte(vm.JUMPI),
+		byte(vm.PUSH1), 0, byte(vm.DUP1), byte(vm.REVERT),
+		byte(vm.JUMPDEST), byte(vm.STOP),
+	)
+
+	call := func(from common.Address, method string, args ...interface{}) (*evmtypes.MsgEthereumTxResponse, error) {
+		// a failed call consumes the whole gas cap of the context
+		ctx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		return a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, true, nil, method, args...)
+	}
+	subscriptions := func(event string) []precompile.Subscription {
+		res, err := call(owner, precompile.SubscriptionsMethod, event)
+		require.NoError(t, err)
+		out, err := precompile.ABI.Unpack(precompile.SubscriptionsMethod, res.Ret)
+		require.NoError(t, err)
+		var subs []precompile.Subscription
+		require.NoError(t, precompile.ABI.Methods[precompile.SubscriptionsMethod].Outputs.Copy(&subs, out))
+		return subs
+	}
+	slot := func(contract common.Address, n byte) common.Hash {
+		return a.EVMKeeper.GetState(ctx, contract, common.BytesToHash([]byte{n}))
+	}
+	// deliver runs the end blocker and returns the number of callbacks that
+	// succeeded and the errors of those that failed
+	deliver := func() (succeeded int, failed []string) {
+		ctx := ctx.WithGasMeter(storetypes.NewGasMeter(10_000_000)).WithEventManager(sdk.NewEventManager())
+		require.NoError(t, k.DeliverNotifications(ctx))
+		// the callbacks are charged to no tx
+		require.Less(t, ctx.GasMeter().GasConsumed(), uint64(50_000))
+
+		for _, event := range ctx.EventManager().Events() {
+			switch event.Type {
+			case types.EventTypeCallback:
+				succeeded++
+			case types.EventTypeCallbackFailed:
+				attr, ok := event.GetAttribute(types.AttributeKeyError)
+				require.True(t, ok)
+				failed = append(failed, attr.Value)
+			}
+		}
+		return succeeded, failed
+	}
+
+	t.Run("subscribe", func(t *testing.T) {
+		for _, contract := range []common.Address{good, reverting, looping} {
+			res, err := call(owner, precompile.SubscribeMethod, types.EventSecretStored, contract, uint64(50_000))
+			require.NoError(t, err)
+			require.False(t, res.Failed(), res.VmError)
+		}
+		res, err := call(owner, precompile.SubscribeMethod, types.EventSecretStored, good, uint64(100_000))
+		require.NoError(t, err)
+		require.Len(t, res.Logs, 1)
+		require.Equal(t, precompile.ABI.Events[precompile.EventTypeSubscribe].ID.Hex(), res.Logs[0].Topics[0])
+
+		for name, args := range map[string][]interface{}{
+			"not a contract": {types.EventSecretStored, other, uint64(50_000)},
+			"gas limit":      {types.EventSecretStored, good, params.MaxGasLimit + 1},
+			"event":          {"Secret Stored", good, uint64(50_000)},
+			// the forwarder has no owner method
+			"no owner": {types.EventSecretStored, forwarder, uint64(50_000)},
+		} {
+			_, err := call(owner, precompile.SubscribeMethod, args...)
+			require.Error(t, err, name)
+		}
+		// other accounts can't subscribe a contract, nor take over its
+		// subscription
+		_, err = call(other, precompile.SubscribeMethod, types.EventSecretStored, good, uint64(50_000))
+		require.Error(t, err)
+		require.ErrorIs(t, k.Subscribe(ctx, other.Bytes(), types.EventSecretStored, good, 50_000), types.ErrUnauthorized)
+
+		// a contract subscribes itself
+		input, err := precompile.ABI.Pack(precompile.SubscribeMethod, types.EventSecretStored, forwarder, uint64(50_000))
+		require.NoError(t, err)
+		res, err = a.EVMKeeper.CallEVMWithData(ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()), other, &forwarder, input, true, nil)
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+
+		subs := subscriptions(types.EventSecretStored)
+		require.Len(t, subs, 4)
+		for _, sub := range subs {
+			switch sub.Target {
+			case forwarder:
+				require.Equal(t, forwarder, sub.Owner)
+			case good:
+				require.Equal(t, uint64(100_000), sub.GasLimit)
+				fallthrough
+			default:
+				require.Equal(t, owner, sub.Owner)
+			}
+		}
+	})
+
+	t.Run("notify", func(t *testing.T) {
+		// the module notifying pays for the record only
+		notifyCtx := ctx.WithGasMeter(storetypes.NewGasMeter(10_000_000))
+		require.NoError(t, k.Notify(notifyCtx, types.EventSecretStored, []byte("secret")))
+		require.Less(t, notifyCtx.GasMeter().GasConsumed(), uint64(50_000))
+		// nothing is subscribed to other events
+		require.NoError(t, k.Notify(ctx, "other", nil))
+		require.Equal(t, common.Hash{}, slot(good, 0))
+
+		// the forwarder doesn't implement the callback
+		succeeded, failed := deliver()
+		require.Equal(t, 1, succeeded)
+		require.Len(t, failed, 3)
+		require.Equal(t, common.BytesToHash(k.ModuleAddress().Bytes()), slot(good, 0))
+		require.NotEqual(t, common.Hash{}, slot(good, 1))
+		// the changes of the failed callbacks are discarded
+		require.Equal(t, common.Hash{}, slot(reverting, 0))
+
+		// the notifications are delivered once
+		succeeded, failed = deliver()
+		require.Zero(t, succeeded)
+		require.Empty(t, failed)
+	})
+
+	t.Run("bridge credits", func(t *testing.T) {
+		res, err := call(owner, precompile.SubscribeMethod, bridgetypes.EventTypeCreditGranted, good, uint64(50_000))
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+
+		bridgeParams := bridgetypes.DefaultParams()
+		bridgeParams.LogMode = true
+		require.NoError(t, a.BridgeKeeper.SetParams(ctx, bridgeParams))
+		require.NoError(t, a.BridgeKeeper.Hooks().PostTxProcessing(ctx, other, core.Message{}, &ethtypes.Receipt{
+			Status: ethtypes.ReceiptStatusSuccessful,
+			Logs: []*ethtypes.Log{{
+				Address: predeploy.VaultGateAddress,
+				Topics:  []common.Hash{bridgetypes.UnlockedTopic, common.BytesToHash(other.Bytes())},
+				TxHash:  common.HexToHash("0x01"),
+			}},
+		}))
+		require.NoError(t, a.BridgeKeeper.GrantUnlocks(ctx))
+
+		succeeded, failed := deliver()
+		require.Equal(t, 1, succeeded)
+		require.Empty(t, failed)
+		// the data of the callback is the address granted and its credits
+		input, err := types.HookABI.Pack(types.HookMethod, bridgetypes.EventTypeCreditGranted, mustPack(t, other, uint64(1)))
+		require.NoError(t, err)
+		require.Equal(t, common.BigToHash(big.NewInt(int64(len(input)))), slot(good, 1))
+	})
+
+	t.Run("unsubscribe", func(t *testing.T) {
+		_, err := call(other, precompile.UnsubscribeMethod, types.EventSecretStored, good)
+		require.Error(t, err)
+		res, err := call(owner, precompile.UnsubscribeMethod, types.EventSecretStored, good)
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		_, err = call(owner, precompile.UnsubscribeMethod, types.EventSecretStored, good)
+		require.Error(t, err)
+
+		subs, err := k.Subscriptions(ctx, types.EventSecretStored)
+		require.NoError(t, err)
+		require.Len(t, subs, 3)
+
+		gs, err := k.ExportGenesis(ctx)
+		require.NoError(t, err)
+		require.NoError(t, gs.Validate())
+		require.Len(t, gs.Subscriptions, 4)
+
+		// the genesis goes through the codec of the app, as in a genesis file
+		am := callbacks.NewAppModule(k)
+		bz := am.ExportGenesis(ctx, a.AppCodec())
+		require.NoError(t, am.ValidateGenesis(a.AppCodec(), nil, bz))
+		var decoded types.GenesisState
+		require.NoError(t, a.AppCodec().UnmarshalJSON(bz, &decoded))
+		require.Equal(t, *gs, decoded)
+		imported, _ := ctx.CacheContext()
+		am.InitGenesis(imported, a.AppCodec(), bz)
+		reexported, err := k.ExportGenesis(imported)
+		require.NoError(t, err)
+		require.Equal(t, gs, reexported)
+	})
+
+	t.Run("governance", func(t *testing.T) {
+		params := types.Params{MaxGasLimit: 100_000, MaxSubscriptions: 3, MaxBlockGas: 100_000}
+		require.Error(t, k.UpdateParams(ctx, sdk.AccAddress(owner.Bytes()).String(), params))
+		proposal := apptest.PassProposal(t, a, ctx, &types.MsgUpdateParams{Authority: k.GetAuthority(), Params: params})
+		require.Equal(t, govv1.StatusPassed, proposal.Status, proposal.FailedReason)
+		stored, err := k.GetParams(ctx)
+		require.NoError(t, err)
+		require.Equal(t, params, stored)
+
+		// the event has as many subscriptions as it can
+		_, err = call(owner, precompile.SubscribeMethod, types.EventSecretStored, good, uint64(50_000))
+		require.Error(t, err)
+
+		// the looping callback uses all its gas, so the block gas runs out
+		// before the last callback
+		require.NoError(t, k.Notify(ctx, types.EventSecretStored, nil))
+		succeeded, failed := deliver()
+		require.Zero(t, succeeded)
+		require.Len(t, failed, 3)
+		require.Equal(t, types.ErrBlockGasExhausted.Error(), failed[2])
+	})
+}
+
+func mustPack(t *testing.T, user common.Address, credits uint64) []byte {
+	t.Helper()
+	data, err := bridgetypes.CreditGrantedData.Pack(user, credits)
+	require.NoError(t, err)
+	return data
+}
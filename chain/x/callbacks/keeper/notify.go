@@ -0,0 +1,180 @@
+package keeper
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"mirrorvault/x/callbacks/types"
+)
+
+// Notify records that event happened, with its data, for the end blocker to
+// call the contracts subscribed to it. The module notifying only pays for
+// the record: the callbacks are not charged to its tx, see
+// DeliverNotifications. Events nothing is subscribed to are not recorded.
+func (k Keeper) Notify(ctx context.Context, event string, data []byte) error {
+	if err := types.ValidateEvent(event); err != nil {
+		return err
+	}
+	store := k.storeService.OpenKVStore(ctx)
+	iter, err := store.Iterator(types.EventPrefix(event), storetypes.PrefixEndBytes(types.EventPrefix(event)))
+	if err != nil {
+		return err
+	}
+	subscribed := iter.Valid()
+	if err := iter.Close(); err != nil || !subscribed {
+		return err
+	}
+
+	countBz, err := store.Get(types.NotificationCountKey)
+	if err != nil {
+		return err
+	}
+	var count uint64
+	if countBz != nil {
+		count = binary.BigEndian.Uint64(countBz)
+	}
+	if err := store.Set(types.NotificationKey(count), k.cdc.MustMarshal(&types.Notification{Event: event, Data: data})); err != nil {
+		return err
+	}
+
+	return store.Set(types.NotificationCountKey, binary.BigEndian.AppendUint64(nil, count+1))
+}
+
+// DeliverNotifications calls onCosmosEvent(event, data) on the contracts
+// subscribed to the events notified in the block, in order, from the module
+// address. Each callback runs in its own cache context with the gas limit of
+// its subscription: a callback that reverts, runs out of gas or panics has
+// its changes discarded and is skipped, so a contract cannot halt the block.
+// The callbacks are charged to no tx, the block pays for them: together they
+// use up to the max block gas of the params, and those past it are skipped.
+func (k Keeper) DeliverNotifications(ctx sdk.Context) error {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+	budget := params.MaxBlockGas
+
+	notifications, err := k.takeNotifications(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range notifications {
+		subs, err := k.Subscriptions(ctx, n.Event)
+		if err != nil {
+			return err
+		}
+		if len(subs) == 0 {
+			continue
+		}
+		input, err := types.HookABI.Pack(types.HookMethod, n.Event, n.Data)
+		if err != nil {
+			return err
+		}
+
+		for _, sub := range subs {
+			var gasUsed uint64
+			if sub.GasLimit > budget {
+				err = types.ErrBlockGasExhausted
+			} else {
+				_, gasUsed, err = k.call(ctx, common.HexToAddress(sub.Contract), sub.GasLimit, input, true)
+				budget -= gasUsed
+			}
+
+			attrs := []sdk.Attribute{
+				sdk.NewAttribute(types.AttributeKeyEvent, n.Event),
+				sdk.NewAttribute(types.AttributeKeyContract, sub.Contract),
+				sdk.NewAttribute(types.AttributeKeyGasUsed, strconv.FormatUint(gasUsed, 10)),
+			}
+			if err != nil {
+				ctx.Logger().With("module", "x/"+types.ModuleName).Info("callback failed", "event", n.Event, "contract", sub.Contract, "error", err)
+				ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeCallbackFailed,
+					append(attrs, sdk.NewAttribute(types.AttributeKeyError, err.Error()))...,
+				))
+				continue
+			}
+			ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeCallback, attrs...))
+		}
+	}
+
+	return nil
+}
+
+// takeNotifications returns and deletes the notifications of the block, so
+// the events the callbacks notify wait for the next block.
+func (k Keeper) takeNotifications(ctx context.Context) ([]types.Notification, error) {
+	store := k.storeService.OpenKVStore(ctx)
+	iter := storetypes.KVStorePrefixIterator(runtime.KVStoreAdapter(store), types.NotificationPrefix)
+
+	var (
+		keys          [][]byte
+		notifications []types.Notification
+	)
+	for ; iter.Valid(); iter.Next() {
+		var n types.Notification
+		if err := k.cdc.Unmarshal(iter.Value(), &n); err != nil {
+			iter.Close()
+			return nil, err
+		}
+		keys = append(keys, iter.Key())
+		notifications = append(notifications, n)
+	}
+	// the keys are deleted once the iterator is closed
+	if err := iter.Close(); err != nil || len(keys) == 0 {
+		return nil, err
+	}
+
+	for _, key := range append(keys, types.NotificationCountKey) {
+		if err := store.Delete(key); err != nil {
+			return nil, err
+		}
+	}
+
+	return notifications, nil
+}
+
+// call calls contract with input from the module address, and returns its
+// output and the gas it used: the whole gas limit when it could not run. Its
+// changes are committed only if commit is set and it succeeds.
+func (k Keeper) call(ctx sdk.Context, contract common.Address, gasLimit uint64, input []byte, commit bool) (ret []byte, gasUsed uint64, err error) {
+	// the EVM consumes the gas of the message on its own meter
+	cacheCtx, write := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter()).CacheContext()
+	defer func() {
+		if r := recover(); r != nil {
+			ret, gasUsed, err = nil, gasLimit, fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	msg := core.Message{
+		From:      k.ModuleAddress(),
+		To:        &contract,
+		Value:     big.NewInt(0),
+		GasLimit:  gasLimit,
+		GasPrice:  big.NewInt(0),
+		GasFeeCap: big.NewInt(0),
+		GasTipCap: big.NewInt(0),
+		Data:      input,
+	}
+	res, err := k.evmKeeper.ApplyMessage(cacheCtx, msg, nil, commit, false)
+	if err != nil {
+		return nil, gasLimit, err
+	}
+	if res.Failed() {
+		return nil, res.GasUsed, fmt.Errorf("%s", res.VmError)
+	}
+	if commit {
+		write()
+	}
+
+	return res.Ret, res.GasUsed, nil
+}
@@ -0,0 +1,150 @@
+package keeper
+
+import (
+	"context"
+	"github.com/ethereum/go-ethereum/common"
+
+	errorsmod "cosmossdk.io/errors"
+	storetypes "cosmossdk.io/store/types"
+
+	"github.com/cosmos/cosmos-sdk/runtime"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"mirrorvault/x/callbacks/types"
+)
+
+// ownerGasLimit caps the gas of the owner call authorizing the owner of a
+// contract.
+const ownerGasLimit = 30_000
+
+// Subscribe subscribes contract to event on behalf of caller, with the gas
+// limit of its callbacks. Subscribing again updates the gas limit. Only the
+// contract itself or its owner can, see authorize.
+func (k Keeper) Subscribe(ctx sdk.Context, caller sdk.AccAddress, event string, contract common.Address, gasLimit uint64) error {
+	if err := types.ValidateEvent(event); err != nil {
+		return err
+	}
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+	if err := types.ValidateGasLimit(gasLimit, params); err != nil {
+		return err
+	}
+	if !k.evmKeeper.IsContract(ctx, contract) {
+		return errorsmod.Wrapf(types.ErrInvalidSubscription, "%s is not a contract", contract)
+	}
+	if err := k.authorize(ctx, caller, contract); err != nil {
+		return err
+	}
+
+	_, found, err := k.GetSubscription(ctx, event, contract)
+	if err != nil {
+		return err
+	}
+	if !found {
+		subs, err := k.Subscriptions(ctx, event)
+		if err != nil {
+			return err
+		}
+		if uint32(len(subs)) >= params.MaxSubscriptions { //nolint:gosec // G115 // bounded by MaxSubscriptions
+			return errorsmod.Wrapf(types.ErrTooManySubscriptions, "%s has %d subscriptions", event, len(subs))
+		}
+	}
+
+	return k.setSubscription(ctx, types.Subscription{
+		Event:    event,
+		Contract: contract.Hex(),
+		Owner:    caller.String(),
+		GasLimit: gasLimit,
+	})
+}
+
+// Unsubscribe ends the subscription of contract to event, on behalf of
+// caller, which must be the contract itself or its owner.
+func (k Keeper) Unsubscribe(ctx sdk.Context, caller sdk.AccAddress, event string, contract common.Address) error {
+	_, found, err := k.GetSubscription(ctx, event, contract)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errorsmod.Wrapf(types.ErrNoSubscription, "%s is not subscribed to %s", contract, event)
+	}
+	if err := k.authorize(ctx, caller, contract); err != nil {
+		return err
+	}
+
+	return k.storeService.OpenKVStore(ctx).Delete(types.SubscriptionKey(event, contract))
+}
+
+// authorize checks caller is contract, or its owner when contract is
+// Ownable: the address its owner() method returns, which is its deployer
+// unless ownership was transferred. The gas of the owner call is charged to
+// ctx, that is to the caller.
+func (k Keeper) authorize(ctx sdk.Context, caller sdk.AccAddress, contract common.Address) error {
+	from := common.BytesToAddress(caller)
+	if from == contract {
+		return nil
+	}
+
+	input, err := types.HookABI.Pack(types.OwnerMethod)
+	if err != nil {
+		return err
+	}
+	ret, gasUsed, err := k.call(ctx, contract, ownerGasLimit, input, false)
+	ctx.GasMeter().ConsumeGas(gasUsed, "contract owner")
+	if err == nil {
+		out, err := types.HookABI.Unpack(types.OwnerMethod, ret)
+		if err == nil && len(out) == 1 && out[0] == from {
+			return nil
+		}
+	}
+
+	return errorsmod.Wrapf(types.ErrUnauthorized, "%s is neither %s nor its owner", from, contract)
+}
+
+// GetSubscription returns the subscription of contract to event, if any.
+func (k Keeper) GetSubscription(ctx context.Context, event string, contract common.Address) (types.Subscription, bool, error) {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.SubscriptionKey(event, contract))
+	if err != nil || bz == nil {
+		return types.Subscription{}, false, err
+	}
+
+	var sub types.Subscription
+	if err := k.cdc.Unmarshal(bz, &sub); err != nil {
+		return types.Subscription{}, false, err
+	}
+
+	return sub, true, nil
+}
+
+// Subscriptions returns the subscriptions to event, by contract address.
+func (k Keeper) Subscriptions(ctx context.Context, event string) ([]types.Subscription, error) {
+	return k.iterate(ctx, types.EventPrefix(event))
+}
+
+// AllSubscriptions returns the subscriptions to all the events.
+func (k Keeper) AllSubscriptions(ctx context.Context) ([]types.Subscription, error) {
+	return k.iterate(ctx, types.SubscriptionPrefix)
+}
+
+func (k Keeper) iterate(ctx context.Context, prefix []byte) ([]types.Subscription, error) {
+	store := runtime.KVStoreAdapter(k.storeService.OpenKVStore(ctx))
+	iter := storetypes.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+
+	var subs []types.Subscription
+	for ; iter.Valid(); iter.Next() {
+		var sub types.Subscription
+		if err := k.cdc.Unmarshal(iter.Value(), &sub); err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+
+	return subs, nil
+}
+
+func (k Keeper) setSubscription(ctx context.Context, sub types.Subscription) error {
+	return k.storeService.OpenKVStore(ctx).Set(types.SubscriptionKey(sub.Event, common.HexToAddress(sub.Contract)), k.cdc.MustMarshal(&sub))
+}
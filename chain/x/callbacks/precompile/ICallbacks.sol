@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: MIT
+pragma solidity >=0.8.17;
+
+/// @dev The address of the callbacks precompile.
+address constant CALLBACKS_PRECOMPILE_ADDRESS = 0x0000000000000000000000000000000000000904;
+
+/// @dev The callbacks precompile.
+ICallbacks constant CALLBACKS_CONTRACT = ICallbacks(CALLBACKS_PRECOMPILE_ADDRESS);
+
+/// @dev A contract subscribed to an event.
+struct Subscription {
+    address target;
+    address owner;
+    uint64 gasLimit;
+}
+
+/// @title Callbacks precompile
+/// @dev Subscribes contracts to the events of the Cosmos modules, e.g.
+/// "secret_stored" when x/vault stores a secret. At the end of the block a
+/// module notifies an event in, its subscribed contracts are called with
+/// onCosmosEvent, from the callbacks module address, within the gas limit of
+/// their subscription. A contract is subscribed by itself, or by its owner
+/// when it is Ownable: the address its owner() method returns.
+/// @custom:address 0x0000000000000000000000000000000000000904
+interface ICallbacks {
+    /// @dev Emitted when owner subscribes target to eventName.
+    event Subscribe(address indexed owner, address indexed target, string eventName, uint64 gasLimit);
+
+    /// @dev Emitted when owner unsubscribes target from eventName.
+    event Unsubscribe(address indexed owner, address indexed target, string eventName);
+
+    /// @dev Subscribes target to eventName, or updates the gas limit of its
+    /// subscription. Reverts if target has no code, the caller is neither
+    /// target nor its owner, the gas limit is above the max governance sets
+    /// or the event has too many subscriptions.
+    function subscribe(string calldata eventName, address target, uint64 gasLimit) external returns (bool success);
+
+    /// @dev Ends the subscription of target to eventName. Reverts if the
+    /// caller is neither target nor its owner.
+    function unsubscribe(string calldata eventName, address target) external returns (bool success);
+
+    /// @dev Returns the subscriptions to eventName.
+    function subscriptions(string calldata eventName) external view returns (Subscription[] memory subscriptions);
+}
+
+/// @title Cosmos callback
+/// @dev Implemented by the contracts subscribed to Cosmos events. Check
+/// msg.sender is the callbacks module address: anyone can call the method.
+interface ICosmosCallback {
+    /// @dev Called when a module notifies eventName, with the data of the
+    /// event. Reverting discards the changes of the call only.
+    function onCosmosEvent(string calldata eventName, bytes calldata data) external;
+}
@@ -0,0 +1,262 @@
+// Package precompile is the callbacks precompile, through which EVM accounts
+// subscribe contracts to the events of the Cosmos modules. A contract is
+// subscribed by itself or by its Ownable owner.
+package precompile
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	cmn "github.com/cosmos/evm/precompiles/common"
+
+	"mirrorvault/x/callbacks/keeper"
+)
+
+// Address is the address of the callbacks precompile, next to the dispatch
+// one.
+var Address = common.HexToAddress("0x0000000000000000000000000000000000000904")
+
+// Methods and events of the callbacks precompile.
+const (
+	SubscribeMethod     = "subscribe"
+	UnsubscribeMethod   = "unsubscribe"
+	SubscriptionsMethod = "subscriptions"
+
+	EventTypeSubscribe   = "Subscribe"
+	EventTypeUnsubscribe = "Unsubscribe"
+)
+
+var _ vm.PrecompiledContract = &Precompile{}
+
+var (
+	// Embed abi json file to the executable binary. Needed when importing as dependency.
+	//
+	//go:embed abi.json
+	f   embed.FS
+	ABI abi.ABI
+)
+
+func init() {
+	var err error
+	ABI, err = cmn.LoadABI(f, "abi.json")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Subscription is a subscription as the subscriptions method returns it.
+type Subscription struct {
+	Target   common.Address `abi:"target"`
+	Owner    common.Address `abi:"owner"`
+	GasLimit uint64         `abi:"gasLimit"`
+}
+
+// Precompile is the callbacks precompile.
+type Precompile struct {
+	cmn.Precompile
+
+	abi.ABI
+	keeper keeper.Keeper
+}
+
+// NewPrecompile returns the callbacks precompile.
+func NewPrecompile(k keeper.Keeper, bankKeeper cmn.BankKeeper) *Precompile {
+	return &Precompile{
+		Precompile: cmn.Precompile{
+			KvGasConfig:           storetypes.KVGasConfig(),
+			TransientKVGasConfig:  storetypes.TransientGasConfig(),
+			ContractAddress:       Address,
+			BalanceHandlerFactory: cmn.NewBalanceHandlerFactory(bankKeeper),
+		},
+		ABI:    ABI,
+		keeper: k,
+	}
+}
+
+// RequiredGas returns the required bare minimum gas to execute the precompile.
+func (p Precompile) RequiredGas(input []byte) uint64 {
+	// NOTE: This check avoid panicking when trying to decode the method ID
+	if len(input) < 4 {
+		return 0
+	}
+
+	method, err := p.MethodById(input[:4])
+	if err != nil {
+		// This should never happen since this method is going to fail during Run
+		return 0
+	}
+
+	return p.Precompile.RequiredGas(input, p.IsTransaction(method))
+}
+
+// Run executes the callbacks methods.
+func (p Precompile) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
+	return p.RunNativeAction(evm, contract, func(ctx sdk.Context) ([]byte, error) {
+		return p.Execute(ctx, evm.StateDB, contract, readonly)
+	})
+}
+
+// Execute executes the callbacks method called by contract.
+func (p Precompile) Execute(ctx sdk.Context, stateDB vm.StateDB, contract *vm.Contract, readOnly bool) ([]byte, error) {
+	method, args, err := cmn.SetupABI(p.ABI, contract, readOnly, p.IsTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case SubscribeMethod:
+		return p.Subscribe(ctx, contract, stateDB, method, args)
+	case UnsubscribeMethod:
+		return p.Unsubscribe(ctx, contract, stateDB, method, args)
+	case SubscriptionsMethod:
+		return p.Subscriptions(ctx, method, args)
+	default:
+		return nil, fmt.Errorf(cmn.ErrUnknownMethod, method.Name)
+	}
+}
+
+// IsTransaction returns whether method changes state: subscribe and
+// unsubscribe do.
+func (Precompile) IsTransaction(method *abi.Method) bool {
+	switch method.Name {
+	case SubscribeMethod, UnsubscribeMethod:
+		return true
+	default:
+		return false
+	}
+}
+
+// Subscribe subscribes a contract to an event on behalf of the caller, the
+// contract or its owner.
+func (p Precompile) Subscribe(ctx sdk.Context, contract *vm.Contract, stateDB vm.StateDB, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 3, len(args))
+	}
+	event, target, err := parseSubscription(args)
+	if err != nil {
+		return nil, err
+	}
+	gasLimit, ok := args[2].(uint64)
+	if !ok {
+		return nil, fmt.Errorf("invalid gas limit: %v", args[2])
+	}
+
+	owner := contract.Caller()
+	if err := p.keeper.Subscribe(ctx, owner.Bytes(), event, target, gasLimit); err != nil {
+		return nil, err
+	}
+
+	data, err := abi.Arguments{p.Events[EventTypeSubscribe].Inputs[2], p.Events[EventTypeSubscribe].Inputs[3]}.Pack(event, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.emit(ctx, stateDB, EventTypeSubscribe, owner, target, data); err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(true)
+}
+
+// Unsubscribe ends a subscription on behalf of the caller, the contract or
+// its owner.
+func (p Precompile) Unsubscribe(ctx sdk.Context, contract *vm.Contract, stateDB vm.StateDB, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 2, len(args))
+	}
+	event, target, err := parseSubscription(args)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := contract.Caller()
+	if err := p.keeper.Unsubscribe(ctx, owner.Bytes(), event, target); err != nil {
+		return nil, err
+	}
+
+	data, err := abi.Arguments{p.Events[EventTypeUnsubscribe].Inputs[2]}.Pack(event)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.emit(ctx, stateDB, EventTypeUnsubscribe, owner, target, data); err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(true)
+}
+
+// Subscriptions returns the subscriptions to an event.
+func (p Precompile) Subscriptions(ctx sdk.Context, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 1, len(args))
+	}
+	event, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid event: %v", args[0])
+	}
+
+	subs, err := p.keeper.Subscriptions(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]Subscription, 0, len(subs))
+	for _, sub := range subs {
+		owner, err := sdk.AccAddressFromBech32(sub.Owner)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, Subscription{
+			Target:   common.HexToAddress(sub.Contract),
+			Owner:    common.BytesToAddress(owner),
+			GasLimit: sub.GasLimit,
+		})
+	}
+
+	return method.Outputs.Pack(out)
+}
+
+// parseSubscription returns the event and target contract of args.
+func parseSubscription(args []interface{}) (string, common.Address, error) {
+	event, ok := args[0].(string)
+	if !ok {
+		return "", common.Address{}, fmt.Errorf("invalid event: %v", args[0])
+	}
+	target, ok := args[1].(common.Address)
+	if !ok {
+		return "", common.Address{}, fmt.Errorf("invalid contract address: %v", args[1])
+	}
+
+	return event, target, nil
+}
+
+// emit adds the log of eventType, indexed by owner and target.
+func (p Precompile) emit(ctx sdk.Context, stateDB vm.StateDB, eventType string, owner, target common.Address, data []byte) error {
+	event := p.Events[eventType]
+	ownerTopic, err := cmn.MakeTopic(owner)
+	if err != nil {
+		return err
+	}
+	targetTopic, err := cmn.MakeTopic(target)
+	if err != nil {
+		return err
+	}
+
+	stateDB.AddLog(&ethtypes.Log{
+		Address: p.Address(),
+		// The first topic is always the signature of the event.
+		Topics:      []common.Hash{event.ID, ownerTopic, targetTopic},
+		Data:        data,
+		BlockNumber: uint64(ctx.BlockHeight()), //nolint:gosec // G115 // block height won't exceed uint64
+	})
+
+	return nil
+}
@@ -0,0 +1,12 @@
+package types
+
+import errorsmod "cosmossdk.io/errors"
+
+// Errors of the callbacks module.
+var (
+	ErrInvalidSubscription  = errorsmod.Register(ModuleName, 2, "invalid subscription")
+	ErrNoSubscription       = errorsmod.Register(ModuleName, 3, "no subscription")
+	ErrUnauthorized         = errorsmod.Register(ModuleName, 4, "neither the contract nor its owner")
+	ErrTooManySubscriptions = errorsmod.Register(ModuleName, 5, "too many subscriptions")
+	ErrBlockGasExhausted    = errorsmod.Register(ModuleName, 6, "callback gas of the block exhausted")
+)
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultGenesis returns the default params and no subscriptions.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{Params: DefaultParams()}
+}
+
+// Validate validates the genesis state.
+func (gs GenesisState) Validate() error {
+	if err := gs.Params.Validate(); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(gs.Subscriptions))
+	perEvent := make(map[string]uint32)
+	for _, s := range gs.Subscriptions {
+		if err := s.Validate(gs.Params); err != nil {
+			return err
+		}
+		key := s.Event + "/" + strings.ToLower(s.Contract)
+		if seen[key] {
+			return fmt.Errorf("duplicate subscription of %s to %s", s.Contract, s.Event)
+		}
+		seen[key] = true
+		if perEvent[s.Event]++; perEvent[s.Event] > gs.Params.MaxSubscriptions {
+			return fmt.Errorf("%s has more than %d subscriptions", s.Event, gs.Params.MaxSubscriptions)
+		}
+	}
+
+	return nil
+}
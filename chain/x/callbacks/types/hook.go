@@ -0,0 +1,41 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// HookMethod is the method of ICosmosCallback the callbacks call.
+const HookMethod = "onCosmosEvent"
+
+// OwnerMethod is the owner method of the Ownable contracts, whose owner can
+// manage their subscriptions.
+const OwnerMethod = "owner"
+
+// HookABI is the ABI of ICosmosCallback, the interface of the subscribed
+// contracts, and of the owner method of Ownable.
+var HookABI abi.ABI
+
+func init() {
+	var err error
+	HookABI, err = abi.JSON(strings.NewReader(`[{
+		"inputs": [
+			{"internalType": "string", "name": "eventName", "type": "string"},
+			{"internalType": "bytes", "name": "data", "type": "bytes"}
+		],
+		"name": "onCosmosEvent",
+		"outputs": [],
+		"stateMutability": "nonpayable",
+		"type": "function"
+	}, {
+		"inputs": [],
+		"name": "owner",
+		"outputs": [{"internalType": "address", "name": "", "type": "address"}],
+		"stateMutability": "view",
+		"type": "function"
+	}]`))
+	if err != nil {
+		panic(err)
+	}
+}
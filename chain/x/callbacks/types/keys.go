@@ -0,0 +1,66 @@
+package types
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ModuleName is the name of the callbacks module.
+	ModuleName = "callbacks"
+
+	// StoreKey is the store key of the callbacks module.
+	StoreKey = ModuleName
+)
+
+var (
+	// ParamsKey is the key of the params.
+	ParamsKey = []byte{0x01}
+
+	// SubscriptionPrefix prefixes the subscriptions, by event and contract.
+	SubscriptionPrefix = []byte{0x02}
+
+	// NotificationCountKey is the key of the number of notifications of the
+	// block. The precompiles notify too, so the notifications are kept in
+	// the store, which the EVM snapshots, until the end blocker deletes them.
+	NotificationCountKey = []byte{0x03}
+
+	// NotificationPrefix prefixes the notifications of the block, in order.
+	NotificationPrefix = []byte{0x04}
+)
+
+// EventPrefix returns the prefix of the subscriptions to event.
+func EventPrefix(event string) []byte {
+	key := append([]byte{}, SubscriptionPrefix...)
+	key = append(key, byte(len(event)))
+	return append(key, event...)
+}
+
+// SubscriptionKey returns the key of the subscription of contract to event.
+func SubscriptionKey(event string, contract common.Address) []byte {
+	return append(EventPrefix(event), contract.Bytes()...)
+}
+
+// NotificationKey returns the key of the nth notification of the block.
+func NotificationKey(n uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, NotificationPrefix...), n)
+}
+
+// Events the modules notify contracts of.
+const (
+	// EventSecretStored is notified by x/vault when it stores a secret, which
+	// is not part of the app yet, so the name is reserved for it.
+	EventSecretStored = "secret_stored"
+)
+
+// Events of the callbacks.
+const (
+	EventTypeCallback       = "callback"
+	EventTypeCallbackFailed = "callback_failed"
+
+	AttributeKeyEvent    = "event"
+	AttributeKeyContract = "contract"
+	AttributeKeyGasUsed  = "gas_used"
+	AttributeKeyError    = "error"
+)
@@ -0,0 +1,33 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// DefaultParams returns callbacks of up to 200k gas, 16 per event and 10M
+// gas per block.
+func DefaultParams() Params {
+	return Params{
+		MaxGasLimit:      200_000,
+		MaxSubscriptions: 16,
+		MaxBlockGas:      10_000_000,
+	}
+}
+
+// Validate checks a callback can run, in a block too, and an event can have
+// subscriptions.
+func (p Params) Validate() error {
+	if p.MaxGasLimit < params.TxGas {
+		return fmt.Errorf("max gas limit must be at least %d, got %d", params.TxGas, p.MaxGasLimit)
+	}
+	if p.MaxSubscriptions == 0 {
+		return fmt.Errorf("max subscriptions must be positive")
+	}
+	if p.MaxBlockGas < p.MaxGasLimit {
+		return fmt.Errorf("max block gas must be at least the max gas limit %d, got %d", p.MaxGasLimit, p.MaxBlockGas)
+	}
+
+	return nil
+}
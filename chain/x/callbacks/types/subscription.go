@@ -0,0 +1,54 @@
+package types
+
+import (
+	"fmt"
+
+	errorsmod "cosmossdk.io/errors"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// maxEventLength bounds the event names, whose length prefixes their keys.
+const maxEventLength = 64
+
+// ValidateEvent checks event is a name of lower case letters and
+// underscores.
+func ValidateEvent(event string) error {
+	if event == "" || len(event) > maxEventLength {
+		return errorsmod.Wrapf(ErrInvalidSubscription, "event must have 1 to %d characters, got %q", maxEventLength, event)
+	}
+	for _, c := range event {
+		if (c < 'a' || c > 'z') && c != '_' {
+			return errorsmod.Wrapf(ErrInvalidSubscription, "invalid event %q, expected lower case letters and underscores", event)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the subscription with the params.
+func (s Subscription) Validate(p Params) error {
+	if err := ValidateEvent(s.Event); err != nil {
+		return err
+	}
+	if !common.IsHexAddress(s.Contract) {
+		return errorsmod.Wrapf(ErrInvalidSubscription, "invalid contract address %q", s.Contract)
+	}
+	if _, err := sdk.AccAddressFromBech32(s.Owner); err != nil {
+		return errorsmod.Wrapf(ErrInvalidSubscription, "invalid owner address %q: %s", s.Owner, err)
+	}
+
+	return ValidateGasLimit(s.GasLimit, p)
+}
+
+// ValidateGasLimit checks a callback can run with gasLimit, within the max
+// of the params.
+func ValidateGasLimit(gasLimit uint64, p Params) error {
+	if gasLimit < params.TxGas || gasLimit > p.MaxGasLimit {
+		return errorsmod.Wrap(ErrInvalidSubscription, fmt.Sprintf("gas limit must be between %d and %d, got %d", params.TxGas, p.MaxGasLimit, gasLimit))
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package keeper
+
+import (
+	"bytes"
+
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"mirrorvault/x/dispatch/types"
+)
+
+// Dispatch executes the message packed in msgAny on behalf of caller and
+// returns its response, packed in an Any. The message must be allowed by
+// the params and signed by caller alone.
+func (k Keeper) Dispatch(ctx sdk.Context, caller sdk.AccAddress, msgAny *codectypes.Any) (*codectypes.Any, error) {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !params.IsAllowed(msgAny.TypeUrl) {
+		return nil, errorsmod.Wrapf(types.ErrNotAllowed, "%s is not in the allowed messages", msgAny.TypeUrl)
+	}
+
+	var msg sdk.Msg
+	if err := k.cdc.UnpackAny(msgAny, &msg); err != nil {
+		return nil, errorsmod.Wrapf(errortypes.ErrInvalidRequest, "cannot decode %s: %s", msgAny.TypeUrl, err)
+	}
+	signers, _, err := k.cdc.GetMsgV1Signers(msg)
+	if err != nil {
+		return nil, err
+	}
+	if len(signers) != 1 || !bytes.Equal(signers[0], caller) {
+		return nil, errorsmod.Wrapf(types.ErrInvalidSigner, "%s must be signed by %s alone", msgAny.TypeUrl, caller)
+	}
+
+	handler := k.router.Handler(msg)
+	if handler == nil {
+		return nil, errorsmod.Wrapf(errortypes.ErrUnknownRequest, "no handler for %s", msgAny.TypeUrl)
+	}
+	res, err := handler(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	// the handler emits on an event manager of its own
+	for _, event := range res.GetEvents() {
+		ctx.EventManager().EmitEvent(sdk.Event(event))
+	}
+	if len(res.MsgResponses) != 1 {
+		return nil, errorsmod.Wrapf(errortypes.ErrLogic, "%s has %d responses", msgAny.TypeUrl, len(res.MsgResponses))
+	}
+
+	return res.MsgResponses[0], nil
+}
@@ -0,0 +1,170 @@
+package keeper_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	storetypes "cosmossdk.io/store/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/app"
+	"mirrorvault/testutil/apptest"
+	dispatchmodule "mirrorvault/x/dispatch"
+	"mirrorvault/x/dispatch/precompile"
+	"mirrorvault/x/dispatch/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+	// nothing can be dispatched
+	require.NoError(t, types.Params{}.Validate())
+
+	msgSend := sdk.MsgTypeURL(&banktypes.MsgSend{})
+	for name, params := range map[string]types.Params{
+		"type url":      {AllowedMessages: []string{"MsgSend"}},
+		"slash":         {AllowedMessages: []string{"/"}},
+		"duplicate":     {AllowedMessages: []string{msgSend, msgSend}},
+		"ethereum tx":   {AllowedMessages: []string{sdk.MsgTypeURL(&evmtypes.MsgEthereumTx{})}},
+		"authz execute": {AllowedMessages: []string{sdk.MsgTypeURL(&authz.MsgExec{})}},
+	} {
+		require.Error(t, params.Validate(), name)
+	}
+}
+
+// TestDispatch dispatches messages through the precompile of an in-memory
+// app. The EVM global config allows a single app per process, so all cases
+// share it.
+func TestDispatch(t *testing.T) {
+	a, ctx, valAddr := apptest.Setup(t, simtestutil.AppOptionsMap{})
+
+	k := a.DispatchKeeper
+	params, err := k.GetParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultParams(), params)
+
+	caller := sdk.AccAddress(valAddr)
+	from := common.BytesToAddress(caller)
+	to := sdk.AccAddress(common.HexToAddress("0x00000000000000000000000000000000000000cc").Bytes())
+	amount := sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 1_000))
+
+	pack := func(msg sdk.Msg) []byte {
+		msgAny, err := codectypes.NewAnyWithValue(msg)
+		require.NoError(t, err)
+		bz, err := msgAny.Marshal()
+		require.NoError(t, err)
+		return bz
+	}
+	dispatch := func(msg sdk.Msg) (*evmtypes.MsgEthereumTxResponse, error) {
+		// a failed call consumes the whole gas cap of the context
+		ctx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		return a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, true, nil, precompile.DispatchMethod, pack(msg))
+	}
+	balance := func(addr sdk.AccAddress) sdk.Coin {
+		return a.BankKeeper.GetBalance(ctx, addr, app.BaseDenom)
+	}
+
+	t.Run("send", func(t *testing.T) {
+		callerBalance := balance(caller)
+		res, err := dispatch(banktypes.NewMsgSend(caller, to, amount))
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		require.Len(t, res.Logs, 1)
+		require.Equal(t, precompile.ABI.Events[precompile.EventTypeDispatch].ID.Hex(), res.Logs[0].Topics[0])
+
+		out, err := precompile.ABI.Unpack(precompile.DispatchMethod, res.Ret)
+		require.NoError(t, err)
+		var resAny codectypes.Any
+		require.NoError(t, resAny.Unmarshal(out[0].([]byte)))
+		require.Equal(t, sdk.MsgTypeURL(&banktypes.MsgSendResponse{}), resAny.TypeUrl)
+
+		require.Equal(t, amount[0], balance(to))
+		require.Equal(t, callerBalance.Sub(amount[0]), balance(caller))
+	})
+
+	t.Run("multi-send", func(t *testing.T) {
+		toBalance := balance(to)
+		msg := banktypes.NewMsgMultiSend(banktypes.NewInput(caller, amount), []banktypes.Output{banktypes.NewOutput(to, amount)})
+		res, err := dispatch(msg)
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		require.Equal(t, toBalance.Add(amount[0]), balance(to))
+	})
+
+	t.Run("reverts", func(t *testing.T) {
+		callerBalance, toBalance := balance(caller), balance(to)
+		for name, msg := range map[string]sdk.Msg{
+			// the caller isn't the signer
+			"signer": banktypes.NewMsgSend(to, caller, amount),
+			// staking has a precompile of its own
+			"not allowed": stakingtypes.NewMsgDelegate(caller.String(), valAddr.String(), amount[0]),
+			"fails":       banktypes.NewMsgSend(caller, to, sdk.NewCoins(callerBalance.AddAmount(callerBalance.Amount))),
+			"invalid":     banktypes.NewMsgSend(caller, to, sdk.Coins{sdk.Coin{Denom: app.BaseDenom}}),
+		} {
+			_, err := dispatch(msg)
+			require.Error(t, err, name)
+		}
+
+		// not a protobuf encoded Any
+		ctx := ctx.WithGasMeter(storetypes.NewInfiniteGasMeter())
+		_, err := a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, true, nil, precompile.DispatchMethod, []byte("send"))
+		require.Error(t, err)
+
+		require.Equal(t, callerBalance, balance(caller))
+		require.Equal(t, toBalance, balance(to))
+	})
+
+	t.Run("governance", func(t *testing.T) {
+		isAllowed := func(typeURL string) bool {
+			res, err := a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, false, nil, precompile.IsAllowedMethod, typeURL)
+			require.NoError(t, err)
+			out, err := precompile.ABI.Unpack(precompile.IsAllowedMethod, res.Ret)
+			require.NoError(t, err)
+			return out[0].(bool)
+		}
+
+		msgSend := sdk.MsgTypeURL(&banktypes.MsgSend{})
+		require.True(t, isAllowed(msgSend))
+
+		params := types.Params{AllowedMessages: []string{sdk.MsgTypeURL(&banktypes.MsgMultiSend{})}}
+		require.Error(t, k.UpdateParams(ctx, caller.String(), params))
+		proposal := apptest.PassProposal(t, a, ctx, &types.MsgUpdateParams{Authority: k.GetAuthority(), Params: params})
+		require.Equal(t, govv1.StatusPassed, proposal.Status, proposal.FailedReason)
+		require.False(t, isAllowed(msgSend))
+
+		// the call reverts with the error of the message
+		res, err := dispatch(banktypes.NewMsgSend(caller, to, amount))
+		require.Error(t, err)
+		reason, err := abi.UnpackRevert(res.Ret)
+		require.NoError(t, err)
+		require.Contains(t, reason, "not in the allowed messages")
+	})
+
+	t.Run("genesis", func(t *testing.T) {
+		// the genesis goes through the codec of the app, as in a genesis file
+		am := dispatchmodule.NewAppModule(k)
+		bz := am.ExportGenesis(ctx, a.AppCodec())
+		require.NoError(t, am.ValidateGenesis(a.AppCodec(), nil, bz))
+
+		imported, _ := ctx.CacheContext()
+		require.NoError(t, k.SetParams(imported, types.DefaultParams()))
+		am.InitGenesis(imported, a.AppCodec(), bz)
+		want, err := k.GetParams(ctx)
+		require.NoError(t, err)
+		got, err := k.GetParams(imported)
+		require.NoError(t, err)
+		require.Equal(t, want, got)
+		require.NotEqual(t, types.DefaultParams(), got)
+	})
+}
@@ -0,0 +1,27 @@
+// SPDX-License-Identifier: MIT
+pragma solidity >=0.8.17;
+
+/// @dev The address of the dispatch precompile.
+address constant DISPATCH_PRECOMPILE_ADDRESS = 0x0000000000000000000000000000000000000903;
+
+/// @dev The dispatch precompile.
+IDispatch constant DISPATCH_CONTRACT = IDispatch(DISPATCH_PRECOMPILE_ADDRESS);
+
+/// @title Dispatch precompile
+/// @dev Executes a native Cosmos message on behalf of the caller, e.g. a
+/// /cosmos.bank.v1beta1.MsgMultiSend from the caller's balance. Messages are
+/// protobuf encoded google.protobuf.Any, of the types governance allows, and
+/// their only signer must be the mirror1... address of the caller.
+/// @custom:address 0x0000000000000000000000000000000000000903
+interface IDispatch {
+    /// @dev Emitted when caller dispatches a message of typeUrl.
+    event Dispatch(address indexed caller, string typeUrl);
+
+    /// @dev Executes message and returns its response, a protobuf encoded
+    /// google.protobuf.Any. Reverts with the error of the message if it is
+    /// not allowed, not signed by the caller or fails.
+    function dispatch(bytes calldata message) external returns (bytes memory response);
+
+    /// @dev Returns whether messages of typeUrl can be dispatched.
+    function isAllowed(string calldata typeUrl) external view returns (bool allowed);
+}
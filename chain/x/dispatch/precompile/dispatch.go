@@ -0,0 +1,194 @@
+// Package precompile is the dispatch precompile, through which EVM accounts
+// and contracts execute the native messages governance allows. The messages
+// run inside the EVM call: they revert with it, and the balance changes they
+// make are seen by the EVM.
+package precompile
+
+import (
+	"embed"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	storetypes "cosmossdk.io/store/types"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	cmn "github.com/cosmos/evm/precompiles/common"
+
+	"mirrorvault/x/dispatch/keeper"
+)
+
+// Address is the address of the dispatch precompile, next to the bech32
+// one.
+var Address = common.HexToAddress("0x0000000000000000000000000000000000000903")
+
+// Methods and events of the dispatch precompile.
+const (
+	DispatchMethod  = "dispatch"
+	IsAllowedMethod = "isAllowed"
+
+	EventTypeDispatch = "Dispatch"
+)
+
+var _ vm.PrecompiledContract = &Precompile{}
+
+var (
+	// Embed abi json file to the executable binary. Needed when importing as dependency.
+	//
+	//go:embed abi.json
+	f   embed.FS
+	ABI abi.ABI
+)
+
+func init() {
+	var err error
+	ABI, err = cmn.LoadABI(f, "abi.json")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Precompile is the dispatch precompile.
+type Precompile struct {
+	cmn.Precompile
+
+	abi.ABI
+	keeper keeper.Keeper
+}
+
+// NewPrecompile returns the dispatch precompile.
+func NewPrecompile(k keeper.Keeper, bankKeeper cmn.BankKeeper) *Precompile {
+	return &Precompile{
+		Precompile: cmn.Precompile{
+			KvGasConfig:           storetypes.KVGasConfig(),
+			TransientKVGasConfig:  storetypes.TransientGasConfig(),
+			ContractAddress:       Address,
+			BalanceHandlerFactory: cmn.NewBalanceHandlerFactory(bankKeeper),
+		},
+		ABI:    ABI,
+		keeper: k,
+	}
+}
+
+// RequiredGas returns the required bare minimum gas to execute the precompile.
+func (p Precompile) RequiredGas(input []byte) uint64 {
+	// NOTE: This check avoid panicking when trying to decode the method ID
+	if len(input) < 4 {
+		return 0
+	}
+
+	method, err := p.MethodById(input[:4])
+	if err != nil {
+		// This should never happen since this method is going to fail during Run
+		return 0
+	}
+
+	return p.Precompile.RequiredGas(input, p.IsTransaction(method))
+}
+
+// Run executes the dispatch methods.
+func (p Precompile) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
+	return p.RunNativeAction(evm, contract, func(ctx sdk.Context) ([]byte, error) {
+		return p.Execute(ctx, evm.StateDB, contract, readonly)
+	})
+}
+
+// Execute executes the dispatch method called by contract.
+func (p Precompile) Execute(ctx sdk.Context, stateDB vm.StateDB, contract *vm.Contract, readOnly bool) ([]byte, error) {
+	method, args, err := cmn.SetupABI(p.ABI, contract, readOnly, p.IsTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case DispatchMethod:
+		return p.Dispatch(ctx, contract, stateDB, method, args)
+	case IsAllowedMethod:
+		return p.IsAllowed(ctx, method, args)
+	default:
+		return nil, fmt.Errorf(cmn.ErrUnknownMethod, method.Name)
+	}
+}
+
+// IsTransaction returns whether method changes state: dispatch does.
+func (Precompile) IsTransaction(method *abi.Method) bool {
+	return method.Name == DispatchMethod
+}
+
+// Dispatch executes the message on behalf of the caller.
+func (p Precompile) Dispatch(ctx sdk.Context, contract *vm.Contract, stateDB vm.StateDB, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 1, len(args))
+	}
+	bz, ok := args[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("invalid message: %v", args[0])
+	}
+	var msgAny codectypes.Any
+	if err := msgAny.Unmarshal(bz); err != nil {
+		return nil, fmt.Errorf("invalid message, expected a protobuf encoded Any: %w", err)
+	}
+
+	caller := contract.Caller()
+	resAny, err := p.keeper.Dispatch(ctx, caller.Bytes(), &msgAny)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resAny.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.emitDispatch(ctx, stateDB, caller, msgAny.TypeUrl); err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(res)
+}
+
+// IsAllowed returns whether messages of a type URL can be dispatched.
+func (p Precompile) IsAllowed(ctx sdk.Context, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 1, len(args))
+	}
+	typeURL, ok := args[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid type URL: %v", args[0])
+	}
+
+	params, err := p.keeper.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(params.IsAllowed(typeURL))
+}
+
+// emitDispatch adds the Dispatch log.
+func (p Precompile) emitDispatch(ctx sdk.Context, stateDB vm.StateDB, caller common.Address, typeURL string) error {
+	event := p.Events[EventTypeDispatch]
+	callerTopic, err := cmn.MakeTopic(caller)
+	if err != nil {
+		return err
+	}
+
+	packed, err := abi.Arguments{event.Inputs[1]}.Pack(typeURL)
+	if err != nil {
+		return err
+	}
+
+	stateDB.AddLog(&ethtypes.Log{
+		Address: p.Address(),
+		// The first topic is always the signature of the event.
+		Topics:      []common.Hash{event.ID, callerTopic},
+		Data:        packed,
+		BlockNumber: uint64(ctx.BlockHeight()), //nolint:gosec // G115 // block height won't exceed uint64
+	})
+
+	return nil
+}
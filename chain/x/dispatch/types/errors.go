@@ -0,0 +1,9 @@
+package types
+
+import errorsmod "cosmossdk.io/errors"
+
+// Errors of the dispatch module.
+var (
+	ErrNotAllowed    = errorsmod.Register(ModuleName, 2, "message not allowed")
+	ErrInvalidSigner = errorsmod.Register(ModuleName, 3, "message signer is not the caller")
+)
@@ -0,0 +1,12 @@
+package types
+
+const (
+	// ModuleName is the name of the dispatch module.
+	ModuleName = "dispatch"
+
+	// StoreKey is the store key of the dispatch module.
+	StoreKey = ModuleName
+)
+
+// ParamsKey is the key of the params.
+var ParamsKey = []byte{0x01}
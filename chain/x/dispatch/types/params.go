@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	sponsortypes "mirrorvault/x/sponsor/types"
+)
+
+// forbiddenMessages can't be allowed: an Ethereum tx would run the EVM
+// inside itself, and authz executions would dispatch messages the allow-list
+// doesn't hold.
+var forbiddenMessages = []string{
+	sdk.MsgTypeURL(&evmtypes.MsgEthereumTx{}),
+	sdk.MsgTypeURL(&authz.MsgExec{}),
+}
+
+// DefaultParams returns the vault messages and the bank sends.
+func DefaultParams() Params {
+	return Params{AllowedMessages: []string{
+		sponsortypes.MsgStoreSecretTypeURL,
+		sdk.MsgTypeURL(&banktypes.MsgSend{}),
+		sdk.MsgTypeURL(&banktypes.MsgMultiSend{}),
+	}}
+}
+
+// Validate checks the allowed messages are type URLs, listed once, and none
+// of the forbidden ones. An empty list turns dispatching off.
+func (p Params) Validate() error {
+	seen := make(map[string]bool, len(p.AllowedMessages))
+	for _, typeURL := range p.AllowedMessages {
+		if !strings.HasPrefix(typeURL, "/") || len(typeURL) == 1 {
+			return fmt.Errorf("invalid allowed message %q, expected a type URL", typeURL)
+		}
+		if slices.Contains(forbiddenMessages, typeURL) {
+			return fmt.Errorf("%s can't be dispatched", typeURL)
+		}
+		if seen[typeURL] {
+			return fmt.Errorf("duplicate allowed message %s", typeURL)
+		}
+		seen[typeURL] = true
+	}
+
+	return nil
+}
+
+// IsAllowed returns whether contracts can dispatch messages of typeURL.
+func (p Params) IsAllowed(typeURL string) bool {
+	return slices.Contains(p.AllowedMessages, typeURL)
+}
@@ -0,0 +1,152 @@
+package feeabs
+
+import (
+	errorsmod "cosmossdk.io/errors"
+
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	errortypes "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth/ante"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/feeabs/keeper"
+	"mirrorvault/x/feeabs/types"
+)
+
+// NativeFeeTx is a tx paying its fee in a fee token, seen as paying the
+// worth of that fee in the EVM denom. The fee checkers, which only know the
+// EVM denom, check it in place of the tx.
+type NativeFeeTx struct {
+	sdk.FeeTx
+	fee sdk.Coins
+}
+
+// NewNativeFeeTx returns feeTx, whose fee is paid in token, with its fee
+// converted to the EVM denom.
+func NewNativeFeeTx(feeTx sdk.FeeTx, token types.FeeToken) NativeFeeTx {
+	native := token.ToNative(feeTx.GetFee().AmountOf(token.Denom))
+
+	return NativeFeeTx{
+		FeeTx: feeTx,
+		fee:   sdk.NewCoins(sdk.NewCoin(evmtypes.GetEVMCoinDenom(), native)),
+	}
+}
+
+// GetFee returns the fee in the EVM denom.
+func (tx NativeFeeTx) GetFee() sdk.Coins {
+	return tx.fee
+}
+
+// GetExtensionOptions returns the extension options of the tx, which hold
+// its max priority price.
+func (tx NativeFeeTx) GetExtensionOptions() []*codectypes.Any {
+	if extTx, ok := tx.FeeTx.(ante.HasExtensionOptionsTx); ok {
+		return extTx.GetExtensionOptions()
+	}
+
+	return nil
+}
+
+// GetNonCriticalExtensionOptions returns the non critical extension options
+// of the tx.
+func (tx NativeFeeTx) GetNonCriticalExtensionOptions() []*codectypes.Any {
+	if extTx, ok := tx.FeeTx.(ante.HasExtensionOptionsTx); ok {
+		return extTx.GetNonCriticalExtensionOptions()
+	}
+
+	return nil
+}
+
+// NativeFeeDecorator runs a fee check decorator on the NativeFeeTx of the
+// txs paying their fee in a fee token, then the next decorator on the tx.
+type NativeFeeDecorator struct {
+	token     types.FeeToken
+	decorator sdk.AnteDecorator
+}
+
+// NewNativeFeeDecorator returns a NativeFeeDecorator running decorator on
+// txs paying their fee in token.
+func NewNativeFeeDecorator(token types.FeeToken, decorator sdk.AnteDecorator) NativeFeeDecorator {
+	return NativeFeeDecorator{token: token, decorator: decorator}
+}
+
+// AnteHandle runs the decorator on the NativeFeeTx of tx.
+func (d NativeFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, errorsmod.Wrap(errortypes.ErrTxDecode, "Tx must be a FeeTx")
+	}
+
+	return d.decorator.AnteHandle(ctx, NewNativeFeeTx(feeTx, d.token), simulate, func(ctx sdk.Context, _ sdk.Tx, simulate bool) (sdk.Context, error) {
+		return next(ctx, tx, simulate)
+	})
+}
+
+// DeductFeeDecorator deducts the fee of txs paying it in a fee token, in
+// place of the SDK DeductFeeDecorator. The fee checker sets the fee of the
+// NativeFeeTx, in the EVM denom; the payer pays its worth in the token and
+// the module pays it to the fee collector from its reserve.
+type DeductFeeDecorator struct {
+	keeper        keeper.Keeper
+	accountKeeper ante.AccountKeeper
+	token         types.FeeToken
+	txFeeChecker  ante.TxFeeChecker
+}
+
+// NewDeductFeeDecorator returns a DeductFeeDecorator for txs paying their fee
+// in token.
+func NewDeductFeeDecorator(k keeper.Keeper, ak ante.AccountKeeper, token types.FeeToken, txFeeChecker ante.TxFeeChecker) DeductFeeDecorator {
+	return DeductFeeDecorator{
+		keeper:        k,
+		accountKeeper: ak,
+		token:         token,
+		txFeeChecker:  txFeeChecker,
+	}
+}
+
+// AnteHandle deducts the fee, then runs the next decorator with the tx
+// priority set by the fee checker.
+func (d DeductFeeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler) (sdk.Context, error) {
+	feeTx, ok := tx.(sdk.FeeTx)
+	if !ok {
+		return ctx, errorsmod.Wrap(errortypes.ErrTxDecode, "Tx must be a FeeTx")
+	}
+	if !simulate && ctx.BlockHeight() > 0 && feeTx.GetGas() == 0 {
+		return ctx, errorsmod.Wrap(errortypes.ErrInvalidGasLimit, "must provide positive gas")
+	}
+	if feeTx.FeeGranter() != nil {
+		return ctx, errortypes.ErrInvalidRequest.Wrap("fee grants are not supported with fee tokens")
+	}
+
+	nativeTx := NewNativeFeeTx(feeTx, d.token)
+	var (
+		fee      = nativeTx.GetFee()
+		priority int64
+		err      error
+	)
+	if !simulate {
+		fee, priority, err = d.txFeeChecker(ctx, nativeTx)
+		if err != nil {
+			return ctx, err
+		}
+	}
+
+	payer := sdk.AccAddress(feeTx.FeePayer())
+	if d.accountKeeper.GetAccount(ctx, payer) == nil {
+		return ctx, errortypes.ErrUnknownAddress.Wrapf("fee payer address: %s does not exist", payer)
+	}
+
+	paid, err := d.keeper.ChargeFee(ctx, payer, d.token, fee.AmountOf(evmtypes.GetEVMCoinDenom()))
+	if err != nil {
+		return ctx, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		sdk.EventTypeTx,
+		sdk.NewAttribute(sdk.AttributeKeyFee, paid.String()),
+		sdk.NewAttribute(sdk.AttributeKeyFeePayer, payer.String()),
+	))
+
+	return next(ctx.WithPriority(priority), tx, simulate)
+}
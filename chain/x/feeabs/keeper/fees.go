@@ -0,0 +1,152 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	erc20types "github.com/cosmos/evm/x/erc20/types"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/feeabs/types"
+)
+
+// FeeToken returns the fee token fee is paid in, if fee is a single coin of
+// a fee token.
+func (k Keeper) FeeToken(ctx sdk.Context, fee sdk.Coins) (types.FeeToken, bool, error) {
+	if len(fee) != 1 {
+		return types.FeeToken{}, false, nil
+	}
+
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return types.FeeToken{}, false, err
+	}
+	token, ok := params.GetToken(fee[0].Denom)
+
+	return token, ok, nil
+}
+
+// FeeTokenByERC20 returns the fee token of the token pair of an ERC20
+// contract.
+func (k Keeper) FeeTokenByERC20(ctx sdk.Context, erc20 common.Address) (types.FeeToken, error) {
+	pair, found := k.erc20Keeper.GetTokenPair(ctx, k.erc20Keeper.GetERC20Map(ctx, erc20))
+	if !found {
+		return types.FeeToken{}, errorsmod.Wrapf(types.ErrTokenPairDisabled, "no token pair for %s", erc20)
+	}
+
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return types.FeeToken{}, err
+	}
+	token, ok := params.GetToken(pair.Denom)
+	if !ok {
+		return types.FeeToken{}, errorsmod.Wrap(types.ErrNotFeeToken, pair.Denom)
+	}
+
+	return token, nil
+}
+
+// ChargeFee takes the amount of token worth fee from payer and pays fee to
+// the fee collector from the reserve of the module. It returns the amount of
+// token charged.
+func (k Keeper) ChargeFee(ctx sdk.Context, payer sdk.AccAddress, token types.FeeToken, fee math.Int) (sdk.Coin, error) {
+	paid := sdk.NewCoin(token.Denom, token.FromNative(fee, true))
+	if err := k.exchange(ctx, payer, paid, fee, func(native sdk.Coins) error {
+		return k.bankKeeper.SendCoinsFromModuleToModule(ctx, types.ModuleName, authtypes.FeeCollectorName, native)
+	}); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	return paid, nil
+}
+
+// RefundFee returns refund, a part of a fee charged by ChargeFee, from the
+// fee collector to the reserve and pays it back to payer in token. It
+// returns the amount of token refunded.
+func (k Keeper) RefundFee(ctx sdk.Context, payer sdk.AccAddress, token types.FeeToken, refund math.Int) (sdk.Coin, error) {
+	refunded := sdk.NewCoin(token.Denom, token.FromNative(refund, false))
+	if !refunded.IsPositive() {
+		return refunded, nil
+	}
+
+	native := sdk.NewCoins(sdk.NewCoin(evmtypes.GetEVMCoinDenom(), refund))
+	if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, types.ModuleName, native); err != nil {
+		return sdk.Coin{}, err
+	}
+	if err := k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, payer, sdk.NewCoins(refunded)); err != nil {
+		return sdk.Coin{}, err
+	}
+
+	return refunded, nil
+}
+
+// Exchange takes amount of token from owner and sends owner its worth in the
+// EVM denom from the reserve of the module, which it returns.
+func (k Keeper) Exchange(ctx sdk.Context, owner sdk.AccAddress, token types.FeeToken, amount math.Int) (math.Int, error) {
+	native := token.ToNative(amount)
+	if err := k.exchange(ctx, owner, sdk.NewCoin(token.Denom, amount), native, func(native sdk.Coins) error {
+		return k.bankKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, owner, native)
+	}); err != nil {
+		return math.Int{}, err
+	}
+
+	ctx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeExchange,
+		sdk.NewAttribute(types.AttributeKeyOwner, owner.String()),
+		sdk.NewAttribute(types.AttributeKeyPaid, sdk.NewCoin(token.Denom, amount).String()),
+		sdk.NewAttribute(types.AttributeKeyNative, sdk.NewCoin(evmtypes.GetEVMCoinDenom(), native).String()),
+	))
+
+	return native, nil
+}
+
+// exchange moves paid from owner to the module, then sends native of the EVM
+// denom from the reserve of the module with send.
+func (k Keeper) exchange(ctx sdk.Context, owner sdk.AccAddress, paid sdk.Coin, native math.Int, send func(sdk.Coins) error) error {
+	if err := k.checkTokenPair(ctx, paid.Denom); err != nil {
+		return err
+	}
+	if !native.IsPositive() {
+		return nil
+	}
+
+	denom := evmtypes.GetEVMCoinDenom()
+	if reserve := k.Reserve(ctx); reserve.Amount.LT(native) {
+		return errorsmod.Wrapf(types.ErrInsufficientReserve, "%s is less than %s%s", reserve, native, denom)
+	}
+
+	if err := k.bankKeeper.SendCoinsFromAccountToModule(ctx, owner, types.ModuleName, sdk.NewCoins(paid)); err != nil {
+		return err
+	}
+
+	return send(sdk.NewCoins(sdk.NewCoin(denom, native)))
+}
+
+// checkTokenPair checks denom is the denom of an enabled token pair.
+func (k Keeper) checkTokenPair(ctx sdk.Context, denom string) error {
+	if !k.erc20Keeper.IsERC20Enabled(ctx) {
+		return errorsmod.Wrap(types.ErrTokenPairDisabled, "erc20 conversions are disabled")
+	}
+
+	if pair, found := k.TokenPair(ctx, denom); !found || !pair.Enabled {
+		return errorsmod.Wrap(types.ErrTokenPairDisabled, denom)
+	}
+
+	return nil
+}
+
+// TokenPair returns the erc20 token pair of denom.
+func (k Keeper) TokenPair(ctx sdk.Context, denom string) (erc20types.TokenPair, bool) {
+	return k.erc20Keeper.GetTokenPair(ctx, k.erc20Keeper.GetTokenPairID(ctx, denom))
+}
+
+// Reserve returns the balance of the module in the EVM denom, which it pays
+// the fees and exchanges of fee tokens with.
+func (k Keeper) Reserve(ctx sdk.Context) sdk.Coin {
+	return k.bankKeeper.GetBalance(ctx, authtypes.NewModuleAddress(types.ModuleName), evmtypes.GetEVMCoinDenom())
+}
@@ -0,0 +1,237 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+
+	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+	erc20types "github.com/cosmos/evm/x/erc20/types"
+
+	"mirrorvault/app"
+	"mirrorvault/testutil/apptest"
+	"mirrorvault/x/feeabs"
+	"mirrorvault/x/feeabs/precompile"
+	"mirrorvault/x/feeabs/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+
+	for name, params := range map[string]types.Params{
+		"denom":     {Tokens: []types.FeeToken{{Denom: "1", Rate: math.LegacyOneDec()}}},
+		"zero rate": {Tokens: []types.FeeToken{{Denom: "uusdc", Rate: math.LegacyZeroDec()}}},
+		"unset":     {Tokens: []types.FeeToken{{Denom: "uusdc"}}},
+		"duplicate": {Tokens: []types.FeeToken{{Denom: "uusdc", Rate: math.LegacyOneDec()}, {Denom: "uusdc", Rate: math.LegacyOneDec()}}},
+	} {
+		require.Error(t, params.Validate(), name)
+	}
+}
+
+func TestFromNative(t *testing.T) {
+	token := types.FeeToken{Denom: "uusdc", Rate: math.LegacyNewDecWithPrec(3, 0)}
+	require.Equal(t, math.NewInt(4), token.FromNative(math.NewInt(10), true))
+	require.Equal(t, math.NewInt(3), token.FromNative(math.NewInt(10), false))
+	require.Equal(t, math.NewInt(30), token.ToNative(math.NewInt(10)))
+
+	// a third of the EVM denom per token
+	token.Rate = math.LegacyOneDec().QuoInt64(3)
+	// the rate is rounded down, so 30 tokens are worth 9.99...
+	require.Equal(t, math.NewInt(9), token.ToNative(math.NewInt(30)))
+	require.Equal(t, math.NewInt(31), token.FromNative(math.NewInt(10), true))
+	require.Equal(t, math.NewInt(30), token.FromNative(math.NewInt(10), false))
+}
+
+// TestFeeTokens pays fees and exchanges tokens on an in-memory app. The EVM
+// global config allows a single app per process, so all cases share it.
+func TestFeeTokens(t *testing.T) {
+	a, ctx, valAddr := apptest.Setup(t, simtestutil.AppOptionsMap{})
+	operator := sdk.AccAddress(valAddr)
+
+	// a token pair of a native coin worth 2umvlt
+	const denom = "uusdc"
+	erc20 := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	require.NoError(t, a.Erc20Keeper.SetToken(ctx, erc20types.NewTokenPair(erc20, denom, erc20types.OWNER_MODULE)))
+	token := types.FeeToken{Denom: denom, Rate: math.LegacyNewDec(2)}
+
+	k := a.FeeAbsKeeper
+	params := types.Params{Tokens: []types.FeeToken{token}}
+	require.Error(t, k.UpdateParams(ctx, operator.String(), params))
+	// governance lists the fee token
+	proposal := apptest.PassProposal(t, a, ctx, &types.MsgUpdateParams{Authority: k.GetAuthority(), Params: params})
+	require.Equal(t, govv1.StatusPassed, proposal.Status, proposal.FailedReason)
+	stored, err := k.GetParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, params, stored)
+
+	_, isFeeToken, err := k.FeeToken(ctx, sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 1)))
+	require.NoError(t, err)
+	require.False(t, isFeeToken)
+	got, isFeeToken, err := k.FeeToken(ctx, sdk.NewCoins(sdk.NewInt64Coin(denom, 1)))
+	require.NoError(t, err)
+	require.True(t, isFeeToken)
+	require.Equal(t, token, got)
+
+	// the sender only holds the token
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	sender := sdk.AccAddress(priv.PubKey().Address())
+	tokens := sdk.NewCoins(sdk.NewInt64Coin(denom, 1_000_000))
+	require.NoError(t, a.BankKeeper.MintCoins(ctx, erc20types.ModuleName, tokens))
+	require.NoError(t, a.BankKeeper.SendCoinsFromModuleToAccount(ctx, erc20types.ModuleName, sender, tokens))
+
+	// the reserve is empty
+	_, err = k.ChargeFee(ctx, sender, token, math.NewInt(10))
+	require.ErrorIs(t, err, types.ErrInsufficientReserve)
+	reserve := sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 400_000))
+	require.NoError(t, a.BankKeeper.SendCoins(ctx, operator, authtypes.NewModuleAddress(types.ModuleName), reserve))
+	require.Equal(t, reserve[0], k.Reserve(ctx))
+
+	t.Run("charge and refund", func(t *testing.T) {
+		ctx, _ := ctx.CacheContext()
+		feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+		collected := a.BankKeeper.GetBalance(ctx, feeCollector, app.BaseDenom).Amount
+
+		paid, err := k.ChargeFee(ctx, sender, token, math.NewInt(11))
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewInt64Coin(denom, 6), paid)
+		require.Equal(t, collected.AddRaw(11), a.BankKeeper.GetBalance(ctx, feeCollector, app.BaseDenom).Amount)
+
+		refunded, err := k.RefundFee(ctx, sender, token, math.NewInt(5))
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewInt64Coin(denom, 2), refunded)
+		require.Equal(t, collected.AddRaw(6), a.BankKeeper.GetBalance(ctx, feeCollector, app.BaseDenom).Amount)
+		require.Equal(t, tokens.AmountOf(denom).SubRaw(4), a.BankKeeper.GetBalance(ctx, sender, denom).Amount)
+
+		// a disabled pair can't pay
+		pair, _ := k.TokenPair(ctx, denom)
+		pair.Enabled = false
+		a.Erc20Keeper.SetTokenPair(ctx, pair)
+		_, err = k.ChargeFee(ctx, sender, token, math.NewInt(11))
+		require.ErrorIs(t, err, types.ErrTokenPairDisabled)
+	})
+
+	t.Run("cosmos tx", func(t *testing.T) {
+		const gasLimit = 200_000
+		baseFee := a.FeeMarketKeeper.GetBaseFee(ctx)
+		// twice the base fee covers its rise, worth half as much in tokens
+		fee := sdk.NewCoin(denom, baseFee.MulInt64(gasLimit).Ceil().TruncateInt())
+		accNum := a.AuthKeeper.GetAccount(ctx, sender).GetAccountNumber()
+
+		txBuilder := a.TxConfig().NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(sender, sender, sdk.NewCoins(sdk.NewInt64Coin(denom, 1)))))
+		txBuilder.SetGasLimit(gasLimit)
+		txBuilder.SetFeeAmount(sdk.NewCoins(fee))
+		signMode := signing.SignMode_SIGN_MODE_DIRECT
+		require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+			PubKey: priv.PubKey(),
+			Data:   &signing.SingleSignatureData{SignMode: signMode},
+		}))
+		sig, err := clienttx.SignWithPrivKey(ctx, signMode, authsigning.SignerData{
+			ChainID:       app.InMemoryChainID,
+			AccountNumber: accNum,
+			Address:       sender.String(),
+			PubKey:        priv.PubKey(),
+		}, txBuilder, priv, a.TxConfig(), 0)
+		require.NoError(t, err)
+		require.NoError(t, txBuilder.SetSignatures(sig))
+		txBytes, err := a.TxConfig().TxEncoder()(txBuilder.GetTx())
+		require.NoError(t, err)
+
+		res, err := a.FinalizeBlock(&abci.RequestFinalizeBlock{Height: 2, Time: time.Now(), Txs: [][]byte{txBytes}})
+		require.NoError(t, err)
+		_, err = a.Commit()
+		require.NoError(t, err)
+		txRes := res.TxResults[0]
+		require.Zero(t, txRes.Code, txRes.Log)
+
+		// without a max priority price, the tx paid its whole fee cap in
+		// tokens, less its unused gas
+		ctx := a.NewUncachedContext(false, cmtproto.Header{Height: 2})
+		charged := token.ToNative(fee.Amount)
+		refund := charged.MulRaw(gasLimit - txRes.GasUsed).QuoRaw(gasLimit)
+		paid := token.FromNative(charged, true).Sub(token.FromNative(refund, false))
+		require.True(t, paid.IsPositive())
+		require.Equal(t, tokens.AmountOf(denom).Sub(paid), a.BankKeeper.GetBalance(ctx, sender, denom).Amount)
+		require.True(t, a.BankKeeper.GetBalance(ctx, sender, app.BaseDenom).IsZero())
+		require.Equal(t, reserve.AmountOf(app.BaseDenom).Sub(charged).Add(refund), k.Reserve(ctx).Amount)
+	})
+
+	t.Run("paymaster", func(t *testing.T) {
+		ctx, _ := apptest.ProposerContext(t, a, 2)
+		from := common.BytesToAddress(sender)
+		balance := a.BankKeeper.GetBalance(ctx, sender, denom).Amount
+
+		res, err := a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, false, nil, precompile.QuoteMethod, erc20, big.NewInt(1000))
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		out, err := precompile.ABI.Unpack(precompile.QuoteMethod, res.Ret)
+		require.NoError(t, err)
+		// 2000umvlt, in wei
+		require.Equal(t, new(big.Int).Mul(big.NewInt(2000), big.NewInt(1e12)), out[0])
+
+		reserveBefore := k.Reserve(ctx).Amount
+		res, err = a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, true, nil, precompile.ExchangeMethod, erc20, big.NewInt(1000))
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		require.Len(t, res.Logs, 1)
+		require.Equal(t, balance.SubRaw(1000), a.BankKeeper.GetBalance(ctx, sender, denom).Amount)
+		require.Equal(t, int64(2000), a.BankKeeper.GetBalance(ctx, sender, app.BaseDenom).Amount.Int64())
+		require.Equal(t, reserveBefore.SubRaw(2000), k.Reserve(ctx).Amount)
+
+		// the EVM denom is not a fee token
+		_, err = a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, false, nil, precompile.QuoteMethod, common.HexToAddress("0x00000000000000000000000000000000000000bb"), big.NewInt(1))
+		require.Error(t, err)
+	})
+
+	t.Run("genesis", func(t *testing.T) {
+		// the genesis goes through the codec of the app, as in a genesis file
+		am := feeabs.NewAppModule(k)
+		bz := am.ExportGenesis(ctx, a.AppCodec())
+		require.NoError(t, am.ValidateGenesis(a.AppCodec(), nil, bz))
+
+		imported, _ := ctx.CacheContext()
+		require.NoError(t, k.SetParams(imported, types.DefaultParams()))
+		am.InitGenesis(imported, a.AppCodec(), bz)
+		got, err := k.GetParams(imported)
+		require.NoError(t, err)
+		require.Equal(t, params, got)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		rtr := mux.NewRouter()
+		feeabs.RegisterRoutes(rtr, k, func() (sdk.Context, error) {
+			return a.NewUncachedContext(false, cmtproto.Header{Height: 2}), nil
+		})
+
+		rec := httptest.NewRecorder()
+		rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, feeabs.Route, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var res feeabs.TokensResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		require.Equal(t, []feeabs.Token{{Denom: denom, Rate: token.Rate, Erc20Address: erc20.Hex(), Enabled: true}}, res.Tokens)
+		require.Equal(t, precompile.Address.Hex(), res.Paymaster)
+		require.Equal(t, authtypes.NewModuleAddress(types.ModuleName).String(), res.ReserveAddress)
+	})
+}
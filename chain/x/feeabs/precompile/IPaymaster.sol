@@ -0,0 +1,26 @@
+// SPDX-License-Identifier: MIT
+pragma solidity >=0.8.17;
+
+/// @dev The address of the paymaster precompile.
+address constant PAYMASTER_PRECOMPILE_ADDRESS = 0x0000000000000000000000000000000000000900;
+
+/// @dev The paymaster precompile.
+IPaymaster constant PAYMASTER_CONTRACT = IPaymaster(PAYMASTER_PRECOMPILE_ADDRESS);
+
+/// @title Paymaster precompile
+/// @dev Exchanges fee tokens, the ERC20 tokens governance accepts for fees,
+/// for the native coin at their governance-set rate, out of the fee
+/// abstraction reserve. A paymaster contract holding fee tokens exchanges
+/// them to pay gas for its users. Amounts of native coin are in wei.
+/// @custom:address 0x0000000000000000000000000000000000000900
+interface IPaymaster {
+    /// @dev Emitted when owner exchanges amount of token for native wei.
+    event Exchange(address indexed owner, address indexed token, uint256 amount, uint256 native);
+
+    /// @dev Returns the native wei amount of token is worth.
+    function quote(address token, uint256 amount) external view returns (uint256 native);
+
+    /// @dev Takes amount of token from the caller and sends it its worth in
+    /// native wei, which it returns.
+    function exchange(address token, uint256 amount) external returns (uint256 native);
+}
@@ -0,0 +1,233 @@
+// Package precompile is the paymaster precompile, through which EVM
+// contracts exchange fee tokens for the native coin out of the fee
+// abstraction reserve. EVM txs pay gas in the native coin only; a paymaster
+// contract holding fee tokens exchanges them to pay for its users.
+//
+// The precompile spends the bank balance of the fee token denom, which is
+// the ERC20 balance for token pairs of native coins and the converted
+// balance for token pairs of ERC20 contracts.
+package precompile
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	cmn "github.com/cosmos/evm/precompiles/common"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/feeabs/keeper"
+)
+
+// Address is the address of the paymaster precompile, next to the Cosmos
+// EVM precompiles at 0x...08xx.
+var Address = common.HexToAddress("0x0000000000000000000000000000000000000900")
+
+// Methods and events of the paymaster precompile.
+const (
+	QuoteMethod    = "quote"
+	ExchangeMethod = "exchange"
+
+	EventTypeExchange = "Exchange"
+)
+
+// maxAmountBits bounds the token amounts, so that their conversions don't
+// overflow.
+const maxAmountBits = 128
+
+var _ vm.PrecompiledContract = &Precompile{}
+
+var (
+	// Embed abi json file to the executable binary. Needed when importing as dependency.
+	//
+	//go:embed abi.json
+	f   embed.FS
+	ABI abi.ABI
+)
+
+func init() {
+	var err error
+	ABI, err = cmn.LoadABI(f, "abi.json")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Precompile is the paymaster precompile.
+type Precompile struct {
+	cmn.Precompile
+
+	abi.ABI
+	keeper keeper.Keeper
+}
+
+// NewPrecompile returns the paymaster precompile. The bank keeper reports
+// the balance changes of the exchanges to the EVM.
+func NewPrecompile(k keeper.Keeper, bankKeeper cmn.BankKeeper) *Precompile {
+	return &Precompile{
+		Precompile: cmn.Precompile{
+			KvGasConfig:           storetypes.KVGasConfig(),
+			TransientKVGasConfig:  storetypes.TransientGasConfig(),
+			ContractAddress:       Address,
+			BalanceHandlerFactory: cmn.NewBalanceHandlerFactory(bankKeeper),
+		},
+		ABI:    ABI,
+		keeper: k,
+	}
+}
+
+// RequiredGas returns the required bare minimum gas to execute the precompile.
+func (p Precompile) RequiredGas(input []byte) uint64 {
+	// NOTE: This check avoid panicking when trying to decode the method ID
+	if len(input) < 4 {
+		return 0
+	}
+
+	method, err := p.MethodById(input[:4])
+	if err != nil {
+		// This should never happen since this method is going to fail during Run
+		return 0
+	}
+
+	return p.Precompile.RequiredGas(input, p.IsTransaction(method))
+}
+
+// Run executes the paymaster methods.
+func (p Precompile) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
+	return p.RunNativeAction(evm, contract, func(ctx sdk.Context) ([]byte, error) {
+		return p.Execute(ctx, evm.StateDB, contract, readonly)
+	})
+}
+
+// Execute executes the paymaster method called by contract.
+func (p Precompile) Execute(ctx sdk.Context, stateDB vm.StateDB, contract *vm.Contract, readOnly bool) ([]byte, error) {
+	method, args, err := cmn.SetupABI(p.ABI, contract, readOnly, p.IsTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case QuoteMethod:
+		return p.Quote(ctx, method, args)
+	case ExchangeMethod:
+		return p.Exchange(ctx, contract, stateDB, method, args)
+	default:
+		return nil, fmt.Errorf(cmn.ErrUnknownMethod, method.Name)
+	}
+}
+
+// IsTransaction returns whether method changes state: only exchange does.
+func (Precompile) IsTransaction(method *abi.Method) bool {
+	return method.Name == ExchangeMethod
+}
+
+// Quote returns the native wei an amount of a fee token is worth.
+func (p Precompile) Quote(ctx sdk.Context, method *abi.Method, args []interface{}) ([]byte, error) {
+	token, amount, err := parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	feeToken, err := p.keeper.FeeTokenByERC20(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(toWei(feeToken.ToNative(amount)))
+}
+
+// Exchange takes an amount of a fee token from the caller and sends it its
+// worth in native wei.
+func (p Precompile) Exchange(ctx sdk.Context, contract *vm.Contract, stateDB vm.StateDB, method *abi.Method, args []interface{}) ([]byte, error) {
+	token, amount, err := parseArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	feeToken, err := p.keeper.FeeTokenByERC20(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	owner := contract.Caller()
+	native, err := p.keeper.Exchange(ctx, owner.Bytes(), feeToken, amount)
+	if err != nil {
+		return nil, err
+	}
+
+	wei := toWei(native)
+	if err := p.emitExchange(ctx, stateDB, owner, token, amount.BigInt(), wei); err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(wei)
+}
+
+// emitExchange adds the Exchange log.
+func (p Precompile) emitExchange(ctx sdk.Context, stateDB vm.StateDB, owner, token common.Address, amount, native *big.Int) error {
+	event := p.Events[EventTypeExchange]
+	topics := make([]common.Hash, 3)
+	// The first topic is always the signature of the event.
+	topics[0] = event.ID
+
+	var err error
+	topics[1], err = cmn.MakeTopic(owner)
+	if err != nil {
+		return err
+	}
+	topics[2], err = cmn.MakeTopic(token)
+	if err != nil {
+		return err
+	}
+
+	packed, err := abi.Arguments{event.Inputs[2], event.Inputs[3]}.Pack(amount, native)
+	if err != nil {
+		return err
+	}
+
+	stateDB.AddLog(&ethtypes.Log{
+		Address:     p.Address(),
+		Topics:      topics,
+		Data:        packed,
+		BlockNumber: uint64(ctx.BlockHeight()), //nolint:gosec // G115 // block height won't exceed uint64
+	})
+
+	return nil
+}
+
+// parseArgs parses the token and amount arguments of the methods.
+func parseArgs(args []interface{}) (common.Address, math.Int, error) {
+	if len(args) != 2 {
+		return common.Address{}, math.Int{}, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 2, len(args))
+	}
+
+	token, ok := args[0].(common.Address)
+	if !ok {
+		return common.Address{}, math.Int{}, fmt.Errorf("invalid token address: %v", args[0])
+	}
+	amount, ok := args[1].(*big.Int)
+	if !ok || amount == nil {
+		return common.Address{}, math.Int{}, fmt.Errorf("invalid amount: %v", args[1])
+	}
+	if amount.BitLen() > maxAmountBits {
+		return common.Address{}, math.Int{}, errors.New("amount too large")
+	}
+
+	return token, math.NewIntFromBigInt(amount), nil
+}
+
+// toWei converts an amount of the EVM denom to the 18 decimals of the EVM.
+func toWei(amount math.Int) *big.Int {
+	return evmtypes.ConvertAmountTo18DecimalsBigInt(amount.BigInt())
+}
@@ -0,0 +1,87 @@
+package feeabs
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+
+	"mirrorvault/x/feeabs/keeper"
+	"mirrorvault/x/feeabs/precompile"
+	"mirrorvault/x/feeabs/types"
+)
+
+// Route is the API server path that serves the fee tokens.
+const Route = "/mirrorvault/feeabs/v1/tokens"
+
+// TokensResponse is the fee tokens, and the reserve their fees are paid
+// from.
+type TokensResponse struct {
+	Tokens []Token `json:"tokens"`
+	// Reserve is the balance of the module in the EVM denom. Anyone can top
+	// it up by sending to ReserveAddress.
+	Reserve        sdk.Coin `json:"reserve"`
+	ReserveAddress string   `json:"reserve_address"`
+	// Paymaster is the address of the paymaster precompile.
+	Paymaster string `json:"paymaster"`
+}
+
+// Token is a fee token, with its ERC20 contract.
+type Token struct {
+	Denom        string         `json:"denom"`
+	Rate         math.LegacyDec `json:"rate"`
+	Erc20Address string         `json:"erc20_address,omitempty"`
+	// Enabled is whether the token pair is registered and enabled, without
+	// which the token can't pay fees.
+	Enabled bool `json:"enabled"`
+}
+
+// RegisterRoutes registers the fee tokens endpoint on the API server router.
+// queryCtx returns a context on the latest committed state.
+func RegisterRoutes(rtr *mux.Router, k keeper.Keeper, queryCtx func() (sdk.Context, error)) {
+	rtr.HandleFunc(Route, func(w http.ResponseWriter, _ *http.Request) {
+		ctx, err := queryCtx()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		params, err := k.GetParams(ctx)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		res := TokensResponse{
+			Tokens:         make([]Token, 0, len(params.Tokens)),
+			Reserve:        k.Reserve(ctx),
+			ReserveAddress: authtypes.NewModuleAddress(types.ModuleName).String(),
+			Paymaster:      precompile.Address.Hex(),
+		}
+		for _, token := range params.Tokens {
+			pair, found := k.TokenPair(ctx, token.Denom)
+			res.Tokens = append(res.Tokens, Token{
+				Denom:        token.Denom,
+				Rate:         token.Rate,
+				Erc20Address: pair.Erc20Address,
+				Enabled:      found && pair.Enabled,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// the dashboard is usually served from another origin
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		_ = json.NewEncoder(w).Encode(res)
+	}).Methods(http.MethodGet)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,10 @@
+package types
+
+import errorsmod "cosmossdk.io/errors"
+
+// Errors of the fee abstraction module.
+var (
+	ErrNotFeeToken         = errorsmod.Register(ModuleName, 2, "not a fee token")
+	ErrTokenPairDisabled   = errorsmod.Register(ModuleName, 3, "token pair not registered or disabled")
+	ErrInsufficientReserve = errorsmod.Register(ModuleName, 4, "insufficient fee abstraction reserve")
+)
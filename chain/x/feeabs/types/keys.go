@@ -0,0 +1,21 @@
+package types
+
+const (
+	// ModuleName is the name of the fee abstraction module.
+	ModuleName = "feeabs"
+
+	// StoreKey is the store key of the fee abstraction module.
+	StoreKey = ModuleName
+)
+
+// ParamsKey is the key of the params.
+var ParamsKey = []byte{0x01}
+
+// Events of the fee abstraction.
+const (
+	EventTypeExchange = "fee_token_exchange"
+
+	AttributeKeyOwner  = "owner"
+	AttributeKeyPaid   = "paid"
+	AttributeKeyNative = "native"
+)
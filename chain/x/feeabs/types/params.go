@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultParams returns no fee tokens: fees are paid in the EVM denom only.
+func DefaultParams() Params {
+	return Params{Tokens: []FeeToken{}}
+}
+
+// Validate checks the fee tokens are valid denoms, listed once, with a
+// positive rate.
+func (p Params) Validate() error {
+	seen := make(map[string]bool, len(p.Tokens))
+	for _, token := range p.Tokens {
+		if err := sdk.ValidateDenom(token.Denom); err != nil {
+			return fmt.Errorf("invalid fee token: %w", err)
+		}
+		if seen[token.Denom] {
+			return fmt.Errorf("duplicate fee token %s", token.Denom)
+		}
+		seen[token.Denom] = true
+		if token.Rate.IsNil() || !token.Rate.IsPositive() {
+			return fmt.Errorf("rate of fee token %s must be positive, got %s", token.Denom, token.Rate)
+		}
+	}
+
+	return nil
+}
+
+// GetToken returns the fee token of denom.
+func (p Params) GetToken(denom string) (FeeToken, bool) {
+	for _, token := range p.Tokens {
+		if token.Denom == denom {
+			return token, true
+		}
+	}
+
+	return FeeToken{}, false
+}
+
+// ToNative returns what amount of the token is worth in the EVM denom,
+// rounded down.
+func (t FeeToken) ToNative(amount math.Int) math.Int {
+	return t.Rate.MulInt(amount).TruncateInt()
+}
+
+// FromNative returns the amount of the token worth amount of the EVM denom,
+// rounded up to cover it or down not to exceed it.
+func (t FeeToken) FromNative(amount math.Int, roundUp bool) math.Int {
+	tokens := math.LegacyNewDecFromInt(amount).Quo(t.Rate)
+	if roundUp {
+		// the quotient is rounded to 18 decimals, check the result
+		out := tokens.Ceil().TruncateInt()
+		if t.ToNative(out).LT(amount) {
+			out = out.AddRaw(1)
+		}
+		return out
+	}
+
+	out := tokens.TruncateInt()
+	if t.Rate.MulInt(out).GT(math.LegacyNewDecFromInt(amount)) {
+		out = out.SubRaw(1)
+	}
+	return out
+}
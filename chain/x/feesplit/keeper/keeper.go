@@ -0,0 +1,139 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	corestore "cosmossdk.io/core/store"
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"mirrorvault/x/feesplit/types"
+)
+
+// Keeper splits the fees of each block and keeps the cumulative burned
+// amount.
+type Keeper struct {
+	storeService corestore.KVStoreService
+	// authority can update the params, the gov module by default
+	authority string
+	cdc       codec.BinaryCodec
+
+	bankKeeper      types.BankKeeper
+	distrKeeper     types.DistributionKeeper
+	stakingKeeper   types.StakingKeeper
+	feeMarketKeeper types.FeeMarketKeeper
+}
+
+// NewKeeper returns the fee split keeper.
+func NewKeeper(
+	storeService corestore.KVStoreService,
+	authority string,
+	cdc codec.BinaryCodec,
+	bankKeeper types.BankKeeper,
+	distrKeeper types.DistributionKeeper,
+	stakingKeeper types.StakingKeeper,
+	feeMarketKeeper types.FeeMarketKeeper,
+) Keeper {
+	return Keeper{
+		storeService:    storeService,
+		authority:       authority,
+		cdc:             cdc,
+		bankKeeper:      bankKeeper,
+		distrKeeper:     distrKeeper,
+		stakingKeeper:   stakingKeeper,
+		feeMarketKeeper: feeMarketKeeper,
+	}
+}
+
+// GetAuthority returns the address that can update the params.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// GetParams returns the params.
+func (k Keeper) GetParams(ctx context.Context) (types.Params, error) {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.ParamsKey)
+	if err != nil || bz == nil {
+		return types.Params{}, err
+	}
+
+	var params types.Params
+	if err := k.cdc.Unmarshal(bz, &params); err != nil {
+		return types.Params{}, err
+	}
+
+	return params, nil
+}
+
+// SetParams validates and sets the params.
+func (k Keeper) SetParams(ctx context.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return k.storeService.OpenKVStore(ctx).Set(types.ParamsKey, k.cdc.MustMarshal(&params))
+}
+
+// UpdateParams sets the params on behalf of authority, which must be the
+// keeper authority. It handles MsgUpdateParams.
+func (k Keeper) UpdateParams(ctx context.Context, authority string, params types.Params) error {
+	if authority != k.authority {
+		return errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, authority)
+	}
+
+	return k.SetParams(ctx, params)
+}
+
+// GetBurned returns the cumulative amount of the EVM denom burned.
+func (k Keeper) GetBurned(ctx context.Context) (math.Int, error) {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.BurnedKey)
+	if err != nil {
+		return math.Int{}, err
+	}
+	if bz == nil {
+		return math.ZeroInt(), nil
+	}
+
+	var burned math.Int
+	if err := burned.Unmarshal(bz); err != nil {
+		return math.Int{}, err
+	}
+
+	return burned, nil
+}
+
+func (k Keeper) setBurned(ctx context.Context, burned math.Int) error {
+	bz, err := burned.Marshal()
+	if err != nil {
+		return err
+	}
+
+	return k.storeService.OpenKVStore(ctx).Set(types.BurnedKey, bz)
+}
+
+// InitGenesis sets the params and burned amount of genState.
+func (k Keeper) InitGenesis(ctx context.Context, genState types.GenesisState) error {
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		return fmt.Errorf("invalid fee split params: %w", err)
+	}
+
+	return k.setBurned(ctx, genState.Burned)
+}
+
+// ExportGenesis returns the params and burned amount.
+func (k Keeper) ExportGenesis(ctx context.Context) (*types.GenesisState, error) {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+	burned, err := k.GetBurned(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GenesisState{Params: params, Burned: burned}, nil
+}
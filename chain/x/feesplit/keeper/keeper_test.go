@@ -0,0 +1,155 @@
+package keeper_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"mirrorvault/app"
+	"mirrorvault/testutil/apptest"
+	"mirrorvault/x/feesplit"
+	"mirrorvault/x/feesplit/keeper"
+	"mirrorvault/x/feesplit/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+
+	for name, params := range map[string]types.Params{
+		"negative":  {BurnFraction: math.LegacyNewDec(-1), CommunityPoolFraction: math.LegacyZeroDec()},
+		"above one": {BurnFraction: math.LegacyNewDec(2), CommunityPoolFraction: math.LegacyZeroDec()},
+		"sum":       {BurnFraction: math.LegacyNewDecWithPrec(6, 1), CommunityPoolFraction: math.LegacyNewDecWithPrec(6, 1)},
+		"unset":     {BurnFraction: math.LegacyOneDec()},
+		"treasury":  {BurnFraction: math.LegacyZeroDec(), CommunityPoolFraction: math.LegacyOneDec(), Treasury: "0x00"},
+	} {
+		require.Error(t, params.Validate(), name)
+	}
+}
+
+// TestSplitFees splits fees on an in-memory app. The EVM global config
+// allows a single app per process, so all cases share it.
+func TestSplitFees(t *testing.T) {
+	a, ctx, valAddr := apptest.Setup(t, simtestutil.AppOptionsMap{})
+
+	k := a.FeeSplitKeeper
+	params, err := k.GetParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultParams(), params)
+
+	params = types.Params{
+		BurnFraction:          math.LegacyNewDecWithPrec(5, 1),
+		CommunityPoolFraction: math.LegacyNewDecWithPrec(25, 2),
+		ProposerTips:          true,
+	}
+	require.Error(t, k.UpdateParams(ctx, sdk.AccAddress(valAddr).String(), params))
+	_, err = keeper.NewMsgServerImpl(k).UpdateParams(ctx, &types.MsgUpdateParams{Authority: sdk.AccAddress(valAddr).String(), Params: params})
+	require.Error(t, err)
+	// governance sets them
+	proposal := apptest.PassProposal(t, a, ctx, &types.MsgUpdateParams{Authority: k.GetAuthority(), Params: params})
+	require.Equal(t, govv1.StatusPassed, proposal.Status, proposal.FailedReason)
+	got, err := k.GetParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, params, got)
+
+	// the fees of a block that used 100000 gas
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+	fees := sdk.NewInt64Coin(app.BaseDenom, 2000)
+	require.NoError(t, a.BankKeeper.SendCoins(ctx, sdk.AccAddress(valAddr), feeCollector, sdk.NewCoins(fees)))
+	blockGas := storetypes.NewGasMeter(10_000_000)
+	blockGas.ConsumeGas(100_000, "txs")
+	ctx = ctx.WithBlockGasMeter(blockGas)
+
+	supply := a.BankKeeper.GetSupply(ctx, app.BaseDenom).Amount
+	feePool, err := a.DistrKeeper.FeePool.Get(ctx)
+	require.NoError(t, err)
+	communityPool := feePool.CommunityPool.AmountOf(app.BaseDenom)
+
+	split, err := k.SplitFees(ctx)
+	require.NoError(t, err)
+
+	baseFee := a.FeeMarketKeeper.GetBaseFee(ctx).MulInt64(100_000).TruncateInt()
+	require.True(t, baseFee.IsPositive())
+	require.Equal(t, fees.Amount, split.Fees)
+	require.Equal(t, baseFee, split.BaseFee)
+	require.Equal(t, baseFee.QuoRaw(2), split.Burned)
+	require.Equal(t, baseFee.QuoRaw(4), split.CommunityPool)
+	require.Equal(t, fees.Amount.Sub(baseFee), split.ProposerTips)
+
+	require.Equal(t, supply.Sub(split.Burned), a.BankKeeper.GetSupply(ctx, app.BaseDenom).Amount)
+	burned, err := k.GetBurned(ctx)
+	require.NoError(t, err)
+	require.Equal(t, split.Burned, burned)
+
+	feePool, err = a.DistrKeeper.FeePool.Get(ctx)
+	require.NoError(t, err)
+	require.Equal(t, communityPool.Add(math.LegacyNewDecFromInt(split.CommunityPool)), feePool.CommunityPool.AmountOf(app.BaseDenom))
+
+	rewards, err := a.DistrKeeper.GetValidatorOutstandingRewards(ctx, valAddr)
+	require.NoError(t, err)
+	require.Equal(t, math.LegacyNewDecFromInt(split.ProposerTips), rewards.Rewards.AmountOf(app.BaseDenom))
+
+	// the rest of the base fee is left for distribution
+	left := baseFee.Sub(split.Burned).Sub(split.CommunityPool)
+	require.Equal(t, left, a.BankKeeper.GetBalance(ctx, feeCollector, app.BaseDenom).Amount)
+
+	// a treasury gets the community share instead
+	treasury := sdk.AccAddress(make([]byte, 20))
+	params.Treasury = treasury.String()
+	require.NoError(t, k.SetParams(ctx, params))
+	split, err = k.SplitFees(ctx)
+	require.NoError(t, err)
+	require.Equal(t, left, split.Fees)
+	require.Equal(t, split.CommunityPool, a.BankKeeper.GetBalance(ctx, treasury, app.BaseDenom).Amount)
+
+	t.Run("genesis", func(t *testing.T) {
+		// the genesis goes through the codec of the app, as in a genesis file
+		am := feesplit.NewAppModule(k)
+		bz := am.ExportGenesis(ctx, a.AppCodec())
+		require.NoError(t, am.ValidateGenesis(a.AppCodec(), nil, bz))
+
+		imported, _ := ctx.CacheContext()
+		require.NoError(t, k.SetParams(imported, types.DefaultParams()))
+		am.InitGenesis(imported, a.AppCodec(), bz)
+		got, err := k.GetParams(imported)
+		require.NoError(t, err)
+		require.Equal(t, params, got)
+		burned, err := k.GetBurned(ctx)
+		require.NoError(t, err)
+		importedBurned, err := k.GetBurned(imported)
+		require.NoError(t, err)
+		require.Equal(t, burned, importedBurned)
+	})
+
+	t.Run("route", func(t *testing.T) {
+		rtr := mux.NewRouter()
+		feesplit.RegisterRoutes(rtr, k, a.BankKeeper, func() (sdk.Context, error) {
+			return ctx, nil
+		})
+
+		rec := httptest.NewRecorder()
+		rtr.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, feesplit.Route, nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var res feesplit.BurnedResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+		burned, err := k.GetBurned(ctx)
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewCoin(app.BaseDenom, burned), res.Burned)
+		require.Equal(t, a.BankKeeper.GetSupply(ctx, app.BaseDenom), res.Supply)
+		require.NotNil(t, res.BurnedDisplay)
+		require.Equal(t, app.DisplayDenom, res.BurnedDisplay.Denom)
+		require.Equal(t, math.LegacyNewDecFromInt(burned).QuoInt64(1_000_000), res.BurnedDisplay.Amount)
+	})
+}
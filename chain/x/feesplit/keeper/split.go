@@ -0,0 +1,121 @@
+package keeper
+
+import (
+	"context"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	distrtypes "github.com/cosmos/cosmos-sdk/x/distribution/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/feesplit/types"
+)
+
+// Split is the split of the fees of a block, in the EVM denom.
+type Split struct {
+	// Fees are all the fees of the block.
+	Fees math.Int
+	// BaseFee is the base fee portion of Fees, the rest are tips.
+	BaseFee       math.Int
+	Burned        math.Int
+	CommunityPool math.Int
+	ProposerTips  math.Int
+}
+
+// SplitFees splits the fees of the block, collected by the fee collector,
+// per the params. It runs in EndBlock, before distribution allocates what
+// remains to the validators at the next BeginBlock.
+//
+// The base fee portion is the base fee times the block gas used, at most the
+// fees. Both EVM and Cosmos txs are charged the base fee and refunded their
+// unused gas, so it is what they paid on top of their tips.
+func (k Keeper) SplitFees(ctx context.Context) (Split, error) {
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	denom := evmtypes.GetEVMCoinDenom()
+	feeCollector := authtypes.NewModuleAddress(authtypes.FeeCollectorName)
+
+	split := Split{
+		Fees:          k.bankKeeper.GetBalance(ctx, feeCollector, denom).Amount,
+		BaseFee:       math.ZeroInt(),
+		Burned:        math.ZeroInt(),
+		CommunityPool: math.ZeroInt(),
+		ProposerTips:  math.ZeroInt(),
+	}
+	if !split.Fees.IsPositive() {
+		return split, nil
+	}
+
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return split, err
+	}
+
+	if baseFee := k.feeMarketKeeper.GetBaseFee(sdkCtx); !baseFee.IsNil() && sdkCtx.BlockGasMeter() != nil {
+		gasUsed := math.NewIntFromUint64(sdkCtx.BlockGasMeter().GasConsumedToLimit())
+		split.BaseFee = math.MinInt(baseFee.MulInt(gasUsed).TruncateInt(), split.Fees)
+	}
+	split.Burned = params.BurnFraction.MulInt(split.BaseFee).TruncateInt()
+	split.CommunityPool = params.CommunityPoolFraction.MulInt(split.BaseFee).TruncateInt()
+
+	if split.Burned.IsPositive() {
+		coins := sdk.NewCoins(sdk.NewCoin(denom, split.Burned))
+		if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, types.ModuleName, coins); err != nil {
+			return split, err
+		}
+		if err := k.bankKeeper.BurnCoins(ctx, types.ModuleName, coins); err != nil {
+			return split, err
+		}
+
+		burned, err := k.GetBurned(ctx)
+		if err != nil {
+			return split, err
+		}
+		if err := k.setBurned(ctx, burned.Add(split.Burned)); err != nil {
+			return split, err
+		}
+	}
+
+	communityAttr := types.AttributeKeyCommunityPool
+	if split.CommunityPool.IsPositive() {
+		coins := sdk.NewCoins(sdk.NewCoin(denom, split.CommunityPool))
+		if params.Treasury != "" {
+			communityAttr = types.AttributeKeyTreasury
+			err = k.bankKeeper.SendCoinsFromModuleToAccount(ctx, authtypes.FeeCollectorName, sdk.MustAccAddressFromBech32(params.Treasury), coins)
+		} else {
+			err = k.distrKeeper.FundCommunityPool(ctx, coins, feeCollector)
+		}
+		if err != nil {
+			return split, err
+		}
+	}
+
+	// without a proposer validator, the tips stay with the other fees
+	var proposer string
+	if tips := split.Fees.Sub(split.BaseFee); params.ProposerTips && tips.IsPositive() {
+		val, err := k.stakingKeeper.GetValidatorByConsAddr(ctx, sdkCtx.BlockHeader().ProposerAddress)
+		if err == nil {
+			coins := sdk.NewCoins(sdk.NewCoin(denom, tips))
+			if err := k.bankKeeper.SendCoinsFromModuleToModule(ctx, authtypes.FeeCollectorName, distrtypes.ModuleName, coins); err != nil {
+				return split, err
+			}
+			if err := k.distrKeeper.AllocateTokensToValidator(ctx, val, sdk.NewDecCoinsFromCoins(coins...)); err != nil {
+				return split, err
+			}
+			split.ProposerTips = tips
+			proposer = val.GetOperator()
+		}
+	}
+
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeFeeSplit,
+		sdk.NewAttribute(types.AttributeKeyBurned, sdk.NewCoin(denom, split.Burned).String()),
+		sdk.NewAttribute(communityAttr, sdk.NewCoin(denom, split.CommunityPool).String()),
+		sdk.NewAttribute(types.AttributeKeyProposerTips, sdk.NewCoin(denom, split.ProposerTips).String()),
+		sdk.NewAttribute(types.AttributeKeyProposer, proposer),
+	))
+
+	return split, nil
+}
@@ -0,0 +1,90 @@
+package feesplit
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/feesplit/keeper"
+	"mirrorvault/x/feesplit/types"
+)
+
+// Route is the API server path that serves the burned supply.
+const Route = "/mirrorvault/feesplit/v1/burned"
+
+// BurnedResponse is the cumulative burned amount and the current supply of
+// the EVM denom, also in its display denom when it has bank metadata.
+type BurnedResponse struct {
+	Burned        sdk.Coin     `json:"burned"`
+	Supply        sdk.Coin     `json:"supply"`
+	BurnedDisplay *sdk.DecCoin `json:"burned_display,omitempty"`
+	SupplyDisplay *sdk.DecCoin `json:"supply_display,omitempty"`
+	Params        types.Params `json:"params"`
+}
+
+// RegisterRoutes registers the burned supply endpoint on the API server
+// router. queryCtx returns a context on the latest committed state.
+func RegisterRoutes(rtr *mux.Router, k keeper.Keeper, bankKeeper types.BankKeeper, queryCtx func() (sdk.Context, error)) {
+	rtr.HandleFunc(Route, func(w http.ResponseWriter, _ *http.Request) {
+		ctx, err := queryCtx()
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		res, err := burnedResponse(ctx, k, bankKeeper)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// the dashboard is usually served from another origin
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		_ = json.NewEncoder(w).Encode(res)
+	}).Methods(http.MethodGet)
+}
+
+func burnedResponse(ctx sdk.Context, k keeper.Keeper, bankKeeper types.BankKeeper) (BurnedResponse, error) {
+	denom := evmtypes.GetEVMCoinDenom()
+	burned, err := k.GetBurned(ctx)
+	if err != nil {
+		return BurnedResponse{}, err
+	}
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return BurnedResponse{}, err
+	}
+
+	res := BurnedResponse{
+		Burned: sdk.NewCoin(denom, burned),
+		Supply: bankKeeper.GetSupply(ctx, denom),
+		Params: params,
+	}
+	if metadata, ok := bankKeeper.GetDenomMetaData(ctx, denom); ok {
+		for _, unit := range metadata.DenomUnits {
+			if unit.Denom != metadata.Display {
+				continue
+			}
+			scale := math.LegacyNewDec(10).Power(uint64(unit.Exponent))
+			burnedDisplay := sdk.NewDecCoinFromDec(unit.Denom, math.LegacyNewDecFromInt(res.Burned.Amount).Quo(scale))
+			supplyDisplay := sdk.NewDecCoinFromDec(unit.Denom, math.LegacyNewDecFromInt(res.Supply.Amount).Quo(scale))
+			res.BurnedDisplay, res.SupplyDisplay = &burnedDisplay, &supplyDisplay
+		}
+	}
+
+	return res, nil
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
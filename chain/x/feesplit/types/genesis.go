@@ -0,0 +1,24 @@
+package types
+
+import (
+	"errors"
+
+	"cosmossdk.io/math"
+)
+
+// DefaultGenesis returns the default params and nothing burned.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{
+		Params: DefaultParams(),
+		Burned: math.ZeroInt(),
+	}
+}
+
+// Validate validates the genesis state.
+func (gs GenesisState) Validate() error {
+	if gs.Burned.IsNil() || gs.Burned.IsNegative() {
+		return errors.New("burned must be a non negative amount")
+	}
+
+	return gs.Params.Validate()
+}
@@ -0,0 +1,27 @@
+package types
+
+const (
+	// ModuleName is the name of the fee split module.
+	ModuleName = "feesplit"
+
+	// StoreKey is the store key of the fee split module.
+	StoreKey = ModuleName
+)
+
+var (
+	// ParamsKey is the key of the params.
+	ParamsKey = []byte{0x01}
+	// BurnedKey is the key of the cumulative burned amount.
+	BurnedKey = []byte{0x02}
+)
+
+// Events of the fee split.
+const (
+	EventTypeFeeSplit = "fee_split"
+
+	AttributeKeyBurned        = "burned"
+	AttributeKeyCommunityPool = "community_pool"
+	AttributeKeyTreasury      = "treasury"
+	AttributeKeyProposerTips  = "proposer_tips"
+	AttributeKeyProposer      = "proposer"
+)
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DefaultParams returns the EIP-1559 split: the base fee is burned and the
+// tips go to the proposer.
+func DefaultParams() Params {
+	return Params{
+		BurnFraction:          math.LegacyOneDec(),
+		CommunityPoolFraction: math.LegacyZeroDec(),
+		ProposerTips:          true,
+	}
+}
+
+// Validate checks the fractions are between 0 and 1 and add up to at most 1.
+func (p Params) Validate() error {
+	for _, f := range []struct {
+		name     string
+		fraction math.LegacyDec
+	}{{"burn fraction", p.BurnFraction}, {"community pool fraction", p.CommunityPoolFraction}} {
+		if f.fraction.IsNil() || f.fraction.IsNegative() || f.fraction.GT(math.LegacyOneDec()) {
+			return fmt.Errorf("%s must be between 0 and 1, got %s", f.name, f.fraction)
+		}
+	}
+	if p.BurnFraction.Add(p.CommunityPoolFraction).GT(math.LegacyOneDec()) {
+		return errors.New("burn and community pool fractions add up to more than 1")
+	}
+	if p.Treasury != "" {
+		if _, err := sdk.AccAddressFromBech32(p.Treasury); err != nil {
+			return fmt.Errorf("invalid treasury: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,174 @@
+// Package cli is the sponsorship CLI.
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cosmossdk.io/x/feegrant"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+
+	"mirrorvault/x/sponsor/types"
+)
+
+// Flags of the sponsorship commands.
+const (
+	FlagSpendLimit      = "spend-limit"
+	FlagPeriod          = "period"
+	FlagAllowedMessages = "allowed-messages"
+	FlagReplace         = "replace"
+)
+
+// GetTxCmd returns the sponsorship tx commands.
+func GetTxCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Sponsor the vault fees of other accounts",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	cmd.AddCommand(
+		NewGrantCmd(),
+		NewRevokeCmd(),
+	)
+
+	return cmd
+}
+
+// NewGrantCmd returns the command sponsoring a list of grantees.
+func NewGrantCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [sponsor_key_or_address] [grantee]...",
+		Short: "Pay the vault fees of grantees, up to a spend limit per period",
+		Long: strings.TrimSpace(fmt.Sprintf(`Grant each grantee a feegrant allowance from the sponsor, restricted to the
+vault messages, which renews its spend limit every period. Grantees then set
+the sponsor as the fee granter of their txs (--fee-granter). The '--from' flag
+is ignored as it is implied from [sponsor].
+
+The allowed messages default to those of the default sponsorship params; set
+them to the current params if governance changed them. They can only be vault
+messages: a sponsorship doesn't pay the fees of other messages. x/vault is not
+part of the app yet, so the grants are rejected until it is.
+
+Example:
+$ %s tx %s grant alice mirror1... mirror1... --spend-limit 100000umvlt --period 24h
+`, version.AppName, types.ModuleName)),
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			allowedMessages, _ := cmd.Flags().GetStringSlice(FlagAllowedMessages)
+			for _, typeURL := range allowedMessages {
+				if !types.IsVaultMessage(typeURL) {
+					return fmt.Errorf("%s is not a vault message, sponsorships only pay the fees of %s* messages", typeURL, types.VaultTypeURLPrefix)
+				}
+			}
+
+			if err := cmd.Flags().Set(flags.FlagFrom, args[0]); err != nil {
+				return err
+			}
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+			for _, typeURL := range allowedMessages {
+				if _, err := clientCtx.InterfaceRegistry.Resolve(typeURL); err != nil {
+					return fmt.Errorf("%s is not a message of the app, its grantees could not use the sponsorship", typeURL)
+				}
+			}
+
+			spendLimitStr, _ := cmd.Flags().GetString(FlagSpendLimit)
+			spendLimit, err := sdk.ParseCoinsNormalized(spendLimitStr)
+			if err != nil {
+				return fmt.Errorf("invalid spend limit: %w", err)
+			}
+			period, _ := cmd.Flags().GetDuration(FlagPeriod)
+			replace, _ := cmd.Flags().GetBool(FlagReplace)
+
+			params := types.Params{AllowedMessages: allowedMessages}
+			if err := params.Validate(); err != nil {
+				return err
+			}
+			allowance, err := params.NewAllowance(spendLimit, period, time.Now())
+			if err != nil {
+				return err
+			}
+
+			sponsor := clientCtx.GetFromAddress()
+			msgs := make([]sdk.Msg, 0, 2*len(args[1:]))
+			for _, arg := range args[1:] {
+				grantee, err := sdk.AccAddressFromBech32(arg)
+				if err != nil {
+					return fmt.Errorf("invalid grantee %s: %w", arg, err)
+				}
+				if replace {
+					msgs = append(msgs, &feegrant.MsgRevokeAllowance{Granter: sponsor.String(), Grantee: grantee.String()})
+				}
+				msg, err := feegrant.NewMsgGrantAllowance(allowance, sponsor, grantee)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, msg)
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+		},
+	}
+
+	cmd.Flags().String(FlagSpendLimit, "", "The fees each grantee can spend per period, e.g. 100000umvlt")
+	cmd.Flags().Duration(FlagPeriod, 24*time.Hour, "The period after which the spend limit renews")
+	cmd.Flags().StringSlice(FlagAllowedMessages, types.DefaultParams().AllowedMessages, "The type URLs of the vault messages whose fees are paid")
+	cmd.Flags().Bool(FlagReplace, false, "Revoke the current allowances of the grantees first, which must all exist")
+	_ = cmd.MarkFlagRequired(FlagSpendLimit)
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
+
+// NewRevokeCmd returns the command ending the sponsorship of grantees.
+func NewRevokeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [sponsor_key_or_address] [grantee]...",
+		Short: "Stop paying the fees of grantees",
+		Long: strings.TrimSpace(fmt.Sprintf(`Revoke the feegrant allowances of the grantees from the sponsor. The '--from'
+flag is ignored as it is implied from [sponsor].
+
+Example:
+$ %s tx %s revoke alice mirror1...
+`, version.AppName, types.ModuleName)),
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cmd.Flags().Set(flags.FlagFrom, args[0]); err != nil {
+				return err
+			}
+			clientCtx, err := client.GetClientTxContext(cmd)
+			if err != nil {
+				return err
+			}
+
+			sponsor := clientCtx.GetFromAddress()
+			msgs := make([]sdk.Msg, 0, len(args[1:]))
+			for _, arg := range args[1:] {
+				grantee, err := sdk.AccAddressFromBech32(arg)
+				if err != nil {
+					return fmt.Errorf("invalid grantee %s: %w", arg, err)
+				}
+				msgs = append(msgs, &feegrant.MsgRevokeAllowance{Granter: sponsor.String(), Grantee: grantee.String()})
+			}
+
+			return tx.GenerateOrBroadcastTxCLI(clientCtx, cmd.Flags(), msgs...)
+		},
+	}
+
+	flags.AddTxFlagsToCmd(cmd)
+
+	return cmd
+}
@@ -0,0 +1,23 @@
+package cli_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"mirrorvault/x/sponsor/client/cli"
+	"mirrorvault/x/sponsor/types"
+)
+
+func TestGrantCmdAllowedMessages(t *testing.T) {
+	cmd := cli.NewGrantCmd()
+	cmd.SetArgs([]string{
+		"alice", "mirror1qqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqq",
+		"--spend-limit", "100000umvlt",
+		"--" + cli.FlagAllowedMessages, types.MsgStoreSecretTypeURL + ",/cosmos.bank.v1beta1.MsgSend",
+	})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	require.ErrorContains(t, cmd.Execute(), "/cosmos.bank.v1beta1.MsgSend is not a vault message")
+}
@@ -0,0 +1,107 @@
+package keeper
+
+import (
+	"context"
+	"fmt"
+
+	corestore "cosmossdk.io/core/store"
+	errorsmod "cosmossdk.io/errors"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"mirrorvault/x/sponsor/types"
+)
+
+// Keeper manages sponsorships, feegrant allowances restricted to the vault
+// messages.
+type Keeper struct {
+	storeService corestore.KVStoreService
+	// authority can update the params, the gov module by default
+	authority string
+	cdc       codec.BinaryCodec
+
+	feegrantKeeper    types.FeegrantKeeper
+	feegrantMsgServer types.FeegrantMsgServer
+	router            baseapp.MessageRouter
+}
+
+// NewKeeper returns the sponsorship keeper. router is the msg service router
+// of the app, which must route the messages sponsorships pay for.
+func NewKeeper(
+	storeService corestore.KVStoreService,
+	authority string,
+	cdc codec.BinaryCodec,
+	feegrantKeeper types.FeegrantKeeper,
+	feegrantMsgServer types.FeegrantMsgServer,
+	router baseapp.MessageRouter,
+) Keeper {
+	return Keeper{
+		storeService:      storeService,
+		authority:         authority,
+		cdc:               cdc,
+		feegrantKeeper:    feegrantKeeper,
+		feegrantMsgServer: feegrantMsgServer,
+		router:            router,
+	}
+}
+
+// GetAuthority returns the address that can update the params.
+func (k Keeper) GetAuthority() string {
+	return k.authority
+}
+
+// GetParams returns the params.
+func (k Keeper) GetParams(ctx context.Context) (types.Params, error) {
+	bz, err := k.storeService.OpenKVStore(ctx).Get(types.ParamsKey)
+	if err != nil || bz == nil {
+		return types.DefaultParams(), err
+	}
+
+	var params types.Params
+	if err := k.cdc.Unmarshal(bz, &params); err != nil {
+		return types.Params{}, err
+	}
+
+	return params, nil
+}
+
+// SetParams validates and sets the params.
+func (k Keeper) SetParams(ctx context.Context, params types.Params) error {
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	return k.storeService.OpenKVStore(ctx).Set(types.ParamsKey, k.cdc.MustMarshal(&params))
+}
+
+// UpdateParams sets the params on behalf of authority, which must be the
+// keeper authority. It backs MsgUpdateParams, with which governance sets
+// the messages sponsorships pay for.
+func (k Keeper) UpdateParams(ctx context.Context, authority string, params types.Params) error {
+	if authority != k.authority {
+		return errorsmod.Wrapf(govtypes.ErrInvalidSigner, "invalid authority; expected %s, got %s", k.authority, authority)
+	}
+
+	return k.SetParams(ctx, params)
+}
+
+// InitGenesis sets the params of genState.
+func (k Keeper) InitGenesis(ctx context.Context, genState types.GenesisState) error {
+	if err := k.SetParams(ctx, genState.Params); err != nil {
+		return fmt.Errorf("invalid sponsorship params: %w", err)
+	}
+
+	return nil
+}
+
+// ExportGenesis returns the params.
+func (k Keeper) ExportGenesis(ctx context.Context) (*types.GenesisState, error) {
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.GenesisState{Params: params}, nil
+}
@@ -0,0 +1,237 @@
+package keeper_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtproto "github.com/cometbft/cometbft/proto/tendermint/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/x/feegrant"
+
+	clienttx "github.com/cosmos/cosmos-sdk/client/tx"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	govv1 "github.com/cosmos/cosmos-sdk/x/gov/types/v1"
+
+	"github.com/cosmos/evm/crypto/ethsecp256k1"
+
+	"mirrorvault/app"
+	"mirrorvault/testutil/apptest"
+	sponsormodule "mirrorvault/x/sponsor"
+	"mirrorvault/x/sponsor/precompile"
+	"mirrorvault/x/sponsor/types"
+)
+
+func TestParamsValidate(t *testing.T) {
+	require.NoError(t, types.DefaultParams().Validate())
+
+	for name, params := range map[string]types.Params{
+		"empty":     {},
+		"type url":  {AllowedMessages: []string{"MsgStoreSecret"}},
+		"slash":     {AllowedMessages: []string{"/"}},
+		"duplicate": {AllowedMessages: []string{types.MsgStoreSecretTypeURL, types.MsgStoreSecretTypeURL}},
+	} {
+		require.Error(t, params.Validate(), name)
+	}
+
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 100))
+	_, err := types.DefaultParams().NewAllowance(spendLimit, 0, time.Now())
+	require.ErrorIs(t, err, types.ErrInvalidSponsorship)
+	_, err = types.DefaultParams().NewAllowance(sdk.NewCoins(), time.Hour, time.Now())
+	require.ErrorIs(t, err, types.ErrInvalidSponsorship)
+
+	require.True(t, types.IsVaultMessage(types.MsgStoreSecretTypeURL))
+	for _, typeURL := range []string{types.VaultTypeURLPrefix, "/cosmos.bank.v1beta1.MsgSend", "/mirrorvault.vault.v2.MsgStoreSecret"} {
+		require.False(t, types.IsVaultMessage(typeURL), typeURL)
+	}
+}
+
+// TestSponsorships sponsors accounts on an in-memory app. The EVM global
+// config allows a single app per process, so all cases share it.
+func TestSponsorships(t *testing.T) {
+	a, ctx, valAddr := apptest.Setup(t, simtestutil.AppOptionsMap{})
+	sponsor := sdk.AccAddress(valAddr)
+
+	k := a.SponsorKeeper
+	params, err := k.GetParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, types.DefaultParams(), params)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+	grantee := sdk.AccAddress(priv.PubKey().Address())
+	spendLimit := sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 100_000))
+
+	// x/vault is not part of the app, so its grantees could not use a
+	// sponsorship of the default params
+	err = k.Sponsor(ctx, sponsor, grantee, spendLimit, time.Hour)
+	require.ErrorIs(t, err, types.ErrInvalidSponsorship)
+	require.ErrorContains(t, err, types.MsgStoreSecretTypeURL)
+
+	// the grantee holds no fees, so bank sends stand in for the vault
+	// messages
+	msgSend := sdk.MsgTypeURL(&banktypes.MsgSend{})
+	params = types.Params{AllowedMessages: []string{msgSend}}
+	require.Error(t, k.UpdateParams(ctx, sponsor.String(), params))
+	proposal := apptest.PassProposal(t, a, ctx, &types.MsgUpdateParams{Authority: k.GetAuthority(), Params: params})
+	require.Equal(t, govv1.StatusPassed, proposal.Status, proposal.FailedReason)
+	stored, err := k.GetParams(ctx)
+	require.NoError(t, err)
+	require.Equal(t, params, stored)
+
+	t.Run("genesis", func(t *testing.T) {
+		// the genesis goes through the codec of the app, as in a genesis file
+		am := sponsormodule.NewAppModule(k)
+		bz := am.ExportGenesis(ctx, a.AppCodec())
+		require.NoError(t, am.ValidateGenesis(a.AppCodec(), nil, bz))
+
+		imported, _ := ctx.CacheContext()
+		require.NoError(t, k.SetParams(imported, types.DefaultParams()))
+		am.InitGenesis(imported, a.AppCodec(), bz)
+		got, err := k.GetParams(imported)
+		require.NoError(t, err)
+		require.Equal(t, params, got)
+	})
+
+	t.Run("sponsor and revoke", func(t *testing.T) {
+		ctx, _ := ctx.CacheContext()
+		require.ErrorIs(t, k.Sponsor(ctx, sponsor, sponsor, spendLimit, time.Hour), types.ErrInvalidSponsorship)
+		_, err := k.Sponsorship(ctx, sponsor, grantee)
+		require.ErrorIs(t, err, types.ErrNoSponsorship)
+
+		require.NoError(t, k.Sponsor(ctx, sponsor, grantee, spendLimit, time.Hour))
+		allowance, err := k.Sponsorship(ctx, sponsor, grantee)
+		require.NoError(t, err)
+		require.Equal(t, spendLimit, allowance.PeriodSpendLimit)
+		require.Equal(t, time.Hour, allowance.Period)
+
+		// sponsoring again replaces the allowance
+		require.NoError(t, k.Sponsor(ctx, sponsor, grantee, spendLimit.MulInt(spendLimit[0].Amount), 2*time.Hour))
+		allowance, err = k.Sponsorship(ctx, sponsor, grantee)
+		require.NoError(t, err)
+		require.Equal(t, 2*time.Hour, allowance.Period)
+
+		require.NoError(t, k.Revoke(ctx, sponsor, grantee))
+		require.ErrorIs(t, k.Revoke(ctx, sponsor, grantee), types.ErrNoSponsorship)
+
+		// plain feegrant allowances are not sponsorships
+		require.NoError(t, a.FeeGrantKeeper.GrantAllowance(ctx, sponsor, grantee, &feegrant.BasicAllowance{SpendLimit: spendLimit}))
+		_, err = k.Sponsorship(ctx, sponsor, grantee)
+		require.ErrorIs(t, err, types.ErrNoSponsorship)
+
+		// every allowed message must be routed
+		require.NoError(t, k.SetParams(ctx, types.Params{AllowedMessages: []string{msgSend, types.MsgStoreSecretTypeURL}}))
+		require.ErrorIs(t, k.Sponsor(ctx, sponsor, grantee, spendLimit, time.Hour), types.ErrInvalidSponsorship)
+	})
+
+	// the feegrant ante handler takes the fee of the allowed messages of a
+	// sponsored grantee from the sponsor
+	t.Run("sponsored tx", func(t *testing.T) {
+		require.NoError(t, k.Sponsor(ctx, sponsor, grantee, spendLimit, time.Hour))
+		amount := sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 1))
+		require.NoError(t, a.BankKeeper.SendCoins(ctx, sponsor, grantee, amount))
+		accNum := a.AuthKeeper.GetAccount(ctx, grantee).GetAccountNumber()
+		sponsorBalance := a.BankKeeper.GetBalance(ctx, sponsor, app.BaseDenom).Amount
+
+		const gasLimit = 200_000
+		fee := sdk.NewCoin(app.BaseDenom, a.FeeMarketKeeper.GetBaseFee(ctx).MulInt64(2*gasLimit).Ceil().TruncateInt())
+		newTx := func(msg sdk.Msg) []byte {
+			txBuilder := a.TxConfig().NewTxBuilder()
+			require.NoError(t, txBuilder.SetMsgs(msg))
+			txBuilder.SetGasLimit(gasLimit)
+			txBuilder.SetFeeAmount(sdk.NewCoins(fee))
+			txBuilder.SetFeeGranter(sponsor)
+
+			signMode := signing.SignMode_SIGN_MODE_DIRECT
+			require.NoError(t, txBuilder.SetSignatures(signing.SignatureV2{
+				PubKey: priv.PubKey(),
+				Data:   &signing.SingleSignatureData{SignMode: signMode},
+			}))
+			sig, err := clienttx.SignWithPrivKey(ctx, signMode, authsigning.SignerData{
+				ChainID:       app.InMemoryChainID,
+				AccountNumber: accNum,
+				Address:       grantee.String(),
+				PubKey:        priv.PubKey(),
+			}, txBuilder, priv, a.TxConfig(), 0)
+			require.NoError(t, err)
+			require.NoError(t, txBuilder.SetSignatures(sig))
+
+			txBytes, err := a.TxConfig().TxEncoder()(txBuilder.GetTx())
+			require.NoError(t, err)
+			return txBytes
+		}
+
+		// the sponsorship doesn't pay for other messages
+		res, err := a.FinalizeBlock(&abci.RequestFinalizeBlock{
+			Height: 2,
+			Time:   time.Now(),
+			Txs: [][]byte{
+				newTx(banktypes.NewMsgMultiSend(banktypes.NewInput(grantee, amount), []banktypes.Output{banktypes.NewOutput(grantee, amount)})),
+				newTx(banktypes.NewMsgSend(grantee, grantee, amount)),
+			},
+		})
+		require.NoError(t, err)
+		_, err = a.Commit()
+		require.NoError(t, err)
+
+		disallowed, sponsored := res.TxResults[0], res.TxResults[1]
+		require.Equal(t, feegrant.ErrMessageNotAllowed.Codespace(), disallowed.Codespace, disallowed.Log)
+		require.Equal(t, feegrant.ErrMessageNotAllowed.ABCICode(), disallowed.Code, disallowed.Log)
+		require.Zero(t, sponsored.Code, sponsored.Log)
+
+		// the sponsor paid the fee, less its unused gas, which is credited
+		// back to the allowance
+		ctx := a.NewUncachedContext(false, cmtproto.Header{Height: 2})
+		refund := fee.Amount.MulRaw(gasLimit - sponsored.GasUsed).QuoRaw(gasLimit)
+		require.Equal(t, sponsorBalance.Sub(fee.Amount).Add(refund), a.BankKeeper.GetBalance(ctx, sponsor, app.BaseDenom).Amount)
+		require.Equal(t, amount[0], a.BankKeeper.GetBalance(ctx, grantee, app.BaseDenom))
+		allowance, err := k.Sponsorship(ctx, sponsor, grantee)
+		require.NoError(t, err)
+		require.Equal(t, spendLimit.Sub(fee).Add(sdk.NewCoin(app.BaseDenom, refund)), allowance.PeriodCanSpend)
+	})
+
+	t.Run("precompile", func(t *testing.T) {
+		ctx, _ := apptest.ProposerContext(t, a, 2)
+		from := common.BytesToAddress(sponsor)
+		to := common.HexToAddress("0x00000000000000000000000000000000000000cc")
+		weiPerUnit := big.NewInt(1e12)
+
+		// 0.5 MVLT a day
+		spendLimitWei := new(big.Int).Mul(big.NewInt(500_000), weiPerUnit)
+		res, err := a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, true, nil, precompile.GrantMethod, to, spendLimitWei, uint64(86400))
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		require.Len(t, res.Logs, 1)
+
+		allowance, err := k.Sponsorship(ctx, sponsor, to.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, sdk.NewCoins(sdk.NewInt64Coin(app.BaseDenom, 500_000)), allowance.PeriodSpendLimit)
+		require.Equal(t, 24*time.Hour, allowance.Period)
+
+		res, err = a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, false, nil, precompile.AllowanceMethod, from, to)
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		out, err := precompile.ABI.Unpack(precompile.AllowanceMethod, res.Ret)
+		require.NoError(t, err)
+		require.Equal(t, spendLimitWei, out[0])
+		require.Equal(t, spendLimitWei, out[1])
+		require.Equal(t, uint64(86400), out[2])
+
+		res, err = a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, true, nil, precompile.RevokeMethod, to)
+		require.NoError(t, err)
+		require.False(t, res.Failed(), res.VmError)
+		_, err = k.Sponsorship(ctx, sponsor, to.Bytes())
+		require.ErrorIs(t, err, types.ErrNoSponsorship)
+
+		// spend limits are whole amounts of umvlt
+		_, err = a.EVMKeeper.CallEVM(ctx, precompile.ABI, from, precompile.Address, true, nil, precompile.GrantMethod, to, big.NewInt(1), uint64(86400))
+		require.Error(t, err)
+	})
+}
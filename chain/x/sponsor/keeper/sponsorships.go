@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	"context"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/x/feegrant"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"mirrorvault/x/sponsor/types"
+)
+
+// Sponsor lets grantee pay the fees of the allowed messages from the
+// account of sponsor, up to spendLimit every period. It replaces any
+// allowance sponsor granted grantee before. The app must route each of the
+// allowed messages: until x/vault is part of the app, sponsorships of the
+// default params, its MsgStoreSecret, are rejected.
+func (k Keeper) Sponsor(ctx context.Context, sponsor, grantee sdk.AccAddress, spendLimit sdk.Coins, period time.Duration) error {
+	if sponsor.Equals(grantee) {
+		return errorsmod.Wrap(types.ErrInvalidSponsorship, "cannot sponsor self")
+	}
+
+	params, err := k.GetParams(ctx)
+	if err != nil {
+		return err
+	}
+	for _, typeURL := range params.AllowedMessages {
+		if k.router.HandlerByTypeURL(typeURL) == nil {
+			return errorsmod.Wrapf(types.ErrInvalidSponsorship, "allowed message %s is not a message of the app", typeURL)
+		}
+	}
+	sdkCtx := sdk.UnwrapSDKContext(ctx)
+	allowance, err := params.NewAllowance(spendLimit, period, sdkCtx.BlockTime())
+	if err != nil {
+		return err
+	}
+
+	// an existing allowance is revoked rather than updated, so that the
+	// pruning queue entry of an expiring one goes with it
+	if existing, err := k.feegrantKeeper.GetAllowance(ctx, sponsor, grantee); err == nil && existing != nil {
+		if err := k.revokeAllowance(ctx, sponsor, grantee); err != nil {
+			return err
+		}
+	}
+	if err := k.feegrantKeeper.GrantAllowance(ctx, sponsor, grantee, allowance); err != nil {
+		return err
+	}
+
+	sdkCtx.EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeSponsor,
+		sdk.NewAttribute(types.AttributeKeySponsor, sponsor.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+		sdk.NewAttribute(types.AttributeKeySpendLimit, spendLimit.String()),
+		sdk.NewAttribute(types.AttributeKeyPeriod, period.String()),
+	))
+
+	return nil
+}
+
+// Revoke ends the sponsorship of grantee by sponsor.
+func (k Keeper) Revoke(ctx context.Context, sponsor, grantee sdk.AccAddress) error {
+	if _, err := k.Sponsorship(ctx, sponsor, grantee); err != nil {
+		return err
+	}
+	if err := k.revokeAllowance(ctx, sponsor, grantee); err != nil {
+		return err
+	}
+
+	sdk.UnwrapSDKContext(ctx).EventManager().EmitEvent(sdk.NewEvent(
+		types.EventTypeRevoke,
+		sdk.NewAttribute(types.AttributeKeySponsor, sponsor.String()),
+		sdk.NewAttribute(types.AttributeKeyGrantee, grantee.String()),
+	))
+
+	return nil
+}
+
+// Sponsorship returns the periodic allowance of the sponsorship of grantee
+// by sponsor. Allowances granted through feegrant directly are not
+// sponsorships.
+func (k Keeper) Sponsorship(ctx context.Context, sponsor, grantee sdk.AccAddress) (*feegrant.PeriodicAllowance, error) {
+	allowance, err := k.feegrantKeeper.GetAllowance(ctx, sponsor, grantee)
+	if err != nil || allowance == nil {
+		return nil, errorsmod.Wrapf(types.ErrNoSponsorship, "%s to %s", sponsor, grantee)
+	}
+
+	allowed, ok := allowance.(*feegrant.AllowedMsgAllowance)
+	if !ok {
+		return nil, errorsmod.Wrapf(types.ErrNoSponsorship, "%s to %s is not restricted to messages", sponsor, grantee)
+	}
+	inner, err := allowed.GetAllowance()
+	if err != nil {
+		return nil, err
+	}
+	periodic, ok := inner.(*feegrant.PeriodicAllowance)
+	if !ok {
+		return nil, errorsmod.Wrapf(types.ErrNoSponsorship, "%s to %s is not periodic", sponsor, grantee)
+	}
+
+	return periodic, nil
+}
+
+func (k Keeper) revokeAllowance(ctx context.Context, sponsor, grantee sdk.AccAddress) error {
+	_, err := k.feegrantMsgServer.RevokeAllowance(ctx, &feegrant.MsgRevokeAllowance{
+		Granter: sponsor.String(),
+		Grantee: grantee.String(),
+	})
+
+	return err
+}
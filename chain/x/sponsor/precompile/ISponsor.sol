@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: MIT
+pragma solidity >=0.8.17;
+
+/// @dev The address of the sponsor precompile.
+address constant SPONSOR_PRECOMPILE_ADDRESS = 0x0000000000000000000000000000000000000901;
+
+/// @dev The sponsor precompile.
+ISponsor constant SPONSOR_CONTRACT = ISponsor(SPONSOR_PRECOMPILE_ADDRESS);
+
+/// @title Sponsor precompile
+/// @dev Sponsors the vault fees of other accounts: the caller grants a
+/// grantee a feegrant allowance restricted to the vault messages, which
+/// renews its spend limit every period. The grantee sets the caller as the
+/// fee granter of its Cosmos txs. Amounts are in wei of the native coin and
+/// must be whole amounts of its 6 decimals bank denom; periods are in
+/// seconds.
+/// @custom:address 0x0000000000000000000000000000000000000901
+interface ISponsor {
+    /// @dev Emitted when sponsor sponsors grantee.
+    event Sponsor(address indexed sponsor, address indexed grantee, uint256 spendLimit, uint64 period);
+
+    /// @dev Emitted when sponsor stops sponsoring grantee.
+    event Revoke(address indexed sponsor, address indexed grantee);
+
+    /// @dev Pays the vault fees of grantee from the caller, up to spendLimit
+    /// every period, replacing any allowance the caller granted it before.
+    function grant(address grantee, uint256 spendLimit, uint64 period) external returns (bool success);
+
+    /// @dev Stops paying the fees of grantee.
+    function revoke(address grantee) external returns (bool success);
+
+    /// @dev Returns the sponsorship of grantee by sponsor: its spend limit per
+    /// period, what is left to spend in the current period, the period and
+    /// the unix time the spend limit renews at.
+    function allowance(address sponsor, address grantee)
+        external
+        view
+        returns (uint256 spendLimit, uint256 canSpend, uint64 period, uint64 periodReset);
+}
@@ -0,0 +1,290 @@
+// Package precompile is the sponsor precompile, through which EVM accounts
+// and contracts sponsor the vault fees of other accounts. EVM txs pay gas
+// themselves; the sponsorships pay the fees of the Cosmos txs of the
+// grantees, which set the sponsor as their fee granter.
+package precompile
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+
+	sdkmath "cosmossdk.io/math"
+	storetypes "cosmossdk.io/store/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	cmn "github.com/cosmos/evm/precompiles/common"
+	evmtypes "github.com/cosmos/evm/x/vm/types"
+
+	"mirrorvault/x/sponsor/keeper"
+)
+
+// Address is the address of the sponsor precompile, next to the paymaster.
+var Address = common.HexToAddress("0x0000000000000000000000000000000000000901")
+
+// Methods and events of the sponsor precompile.
+const (
+	GrantMethod     = "grant"
+	RevokeMethod    = "revoke"
+	AllowanceMethod = "allowance"
+
+	EventTypeSponsor = "Sponsor"
+	EventTypeRevoke  = "Revoke"
+)
+
+// maxAmountBits bounds the spend limits, as the paymaster amounts.
+const maxAmountBits = 128
+
+// maxPeriod bounds the periods, in seconds, to those of a time.Duration.
+const maxPeriod = math.MaxInt64 / uint64(time.Second)
+
+var _ vm.PrecompiledContract = &Precompile{}
+
+var (
+	// Embed abi json file to the executable binary. Needed when importing as dependency.
+	//
+	//go:embed abi.json
+	f   embed.FS
+	ABI abi.ABI
+)
+
+func init() {
+	var err error
+	ABI, err = cmn.LoadABI(f, "abi.json")
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Precompile is the sponsor precompile.
+type Precompile struct {
+	cmn.Precompile
+
+	abi.ABI
+	keeper keeper.Keeper
+}
+
+// NewPrecompile returns the sponsor precompile.
+func NewPrecompile(k keeper.Keeper, bankKeeper cmn.BankKeeper) *Precompile {
+	return &Precompile{
+		Precompile: cmn.Precompile{
+			KvGasConfig:           storetypes.KVGasConfig(),
+			TransientKVGasConfig:  storetypes.TransientGasConfig(),
+			ContractAddress:       Address,
+			BalanceHandlerFactory: cmn.NewBalanceHandlerFactory(bankKeeper),
+		},
+		ABI:    ABI,
+		keeper: k,
+	}
+}
+
+// RequiredGas returns the required bare minimum gas to execute the precompile.
+func (p Precompile) RequiredGas(input []byte) uint64 {
+	// NOTE: This check avoid panicking when trying to decode the method ID
+	if len(input) < 4 {
+		return 0
+	}
+
+	method, err := p.MethodById(input[:4])
+	if err != nil {
+		// This should never happen since this method is going to fail during Run
+		return 0
+	}
+
+	return p.Precompile.RequiredGas(input, p.IsTransaction(method))
+}
+
+// Run executes the sponsor methods.
+func (p Precompile) Run(evm *vm.EVM, contract *vm.Contract, readonly bool) ([]byte, error) {
+	return p.RunNativeAction(evm, contract, func(ctx sdk.Context) ([]byte, error) {
+		return p.Execute(ctx, evm.StateDB, contract, readonly)
+	})
+}
+
+// Execute executes the sponsor method called by contract.
+func (p Precompile) Execute(ctx sdk.Context, stateDB vm.StateDB, contract *vm.Contract, readOnly bool) ([]byte, error) {
+	method, args, err := cmn.SetupABI(p.ABI, contract, readOnly, p.IsTransaction)
+	if err != nil {
+		return nil, err
+	}
+
+	switch method.Name {
+	case GrantMethod:
+		return p.Grant(ctx, contract, stateDB, method, args)
+	case RevokeMethod:
+		return p.Revoke(ctx, contract, stateDB, method, args)
+	case AllowanceMethod:
+		return p.Allowance(ctx, method, args)
+	default:
+		return nil, fmt.Errorf(cmn.ErrUnknownMethod, method.Name)
+	}
+}
+
+// IsTransaction returns whether method changes state: grant and revoke do.
+func (Precompile) IsTransaction(method *abi.Method) bool {
+	return method.Name == GrantMethod || method.Name == RevokeMethod
+}
+
+// Grant sponsors grantee from the caller.
+func (p Precompile) Grant(ctx sdk.Context, contract *vm.Contract, stateDB vm.StateDB, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 3 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 3, len(args))
+	}
+	grantee, ok := args[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("invalid grantee address: %v", args[0])
+	}
+	spendLimitWei, ok := args[1].(*big.Int)
+	if !ok || spendLimitWei == nil {
+		return nil, fmt.Errorf("invalid spend limit: %v", args[1])
+	}
+	if spendLimitWei.BitLen() > maxAmountBits {
+		return nil, errors.New("spend limit too large")
+	}
+	period, ok := args[2].(uint64)
+	if !ok || period > maxPeriod {
+		return nil, fmt.Errorf("invalid period: %v", args[2])
+	}
+
+	conversionFactor := evmtypes.GetEVMCoinDecimals().ConversionFactor().BigInt()
+	spendLimit, rem := new(big.Int).QuoRem(spendLimitWei, conversionFactor, new(big.Int))
+	if rem.Sign() != 0 {
+		return nil, errors.New("spend limit must be a whole amount of the bank denom")
+	}
+	coins := sdk.NewCoins(sdk.NewCoin(evmtypes.GetEVMCoinDenom(), sdkmath.NewIntFromBigInt(spendLimit)))
+
+	sponsor := contract.Caller()
+	duration := time.Duration(period) * time.Second //nolint:gosec // G115 // bounded by maxPeriod
+	if err := p.keeper.Sponsor(ctx, sponsor.Bytes(), grantee.Bytes(), coins, duration); err != nil {
+		return nil, err
+	}
+
+	if err := p.emitSponsor(ctx, stateDB, sponsor, grantee, spendLimitWei, period); err != nil {
+		return nil, err
+	}
+
+	return method.Outputs.Pack(true)
+}
+
+// Revoke ends the sponsorship of grantee by the caller.
+func (p Precompile) Revoke(ctx sdk.Context, contract *vm.Contract, stateDB vm.StateDB, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 1, len(args))
+	}
+	grantee, ok := args[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("invalid grantee address: %v", args[0])
+	}
+
+	sponsor := contract.Caller()
+	if err := p.keeper.Revoke(ctx, sponsor.Bytes(), grantee.Bytes()); err != nil {
+		return nil, err
+	}
+
+	event := p.Events[EventTypeRevoke]
+	topics, err := eventTopics(event, sponsor, grantee)
+	if err != nil {
+		return nil, err
+	}
+	stateDB.AddLog(&ethtypes.Log{
+		Address:     p.Address(),
+		Topics:      topics,
+		BlockNumber: uint64(ctx.BlockHeight()), //nolint:gosec // G115 // block height won't exceed uint64
+	})
+
+	return method.Outputs.Pack(true)
+}
+
+// Allowance returns the sponsorship of grantee by sponsor, with the spend
+// limit of the current period.
+func (p Precompile) Allowance(ctx sdk.Context, method *abi.Method, args []interface{}) ([]byte, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf(cmn.ErrInvalidNumberOfArgs, 2, len(args))
+	}
+	sponsor, ok := args[0].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("invalid sponsor address: %v", args[0])
+	}
+	grantee, ok := args[1].(common.Address)
+	if !ok {
+		return nil, fmt.Errorf("invalid grantee address: %v", args[1])
+	}
+
+	allowance, err := p.keeper.Sponsorship(ctx, sponsor.Bytes(), grantee.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// the allowance renews when it is next used after its reset time
+	denom := evmtypes.GetEVMCoinDenom()
+	canSpend := allowance.PeriodCanSpend.AmountOf(denom)
+	reset := allowance.PeriodReset
+	if !ctx.BlockTime().Before(reset) {
+		canSpend = allowance.PeriodSpendLimit.AmountOf(denom)
+		reset = ctx.BlockTime().Add(allowance.Period)
+	}
+
+	return method.Outputs.Pack(
+		toWei(allowance.PeriodSpendLimit.AmountOf(denom).BigInt()),
+		toWei(canSpend.BigInt()),
+		uint64(allowance.Period/time.Second),
+		uint64(reset.Unix()), //nolint:gosec // G115 // the reset time is after the genesis time
+	)
+}
+
+// emitSponsor adds the Sponsor log.
+func (p Precompile) emitSponsor(ctx sdk.Context, stateDB vm.StateDB, sponsor, grantee common.Address, spendLimit *big.Int, period uint64) error {
+	event := p.Events[EventTypeSponsor]
+	topics, err := eventTopics(event, sponsor, grantee)
+	if err != nil {
+		return err
+	}
+
+	packed, err := abi.Arguments{event.Inputs[2], event.Inputs[3]}.Pack(spendLimit, period)
+	if err != nil {
+		return err
+	}
+
+	stateDB.AddLog(&ethtypes.Log{
+		Address:     p.Address(),
+		Topics:      topics,
+		Data:        packed,
+		BlockNumber: uint64(ctx.BlockHeight()), //nolint:gosec // G115 // block height won't exceed uint64
+	})
+
+	return nil
+}
+
+// eventTopics returns the topics of the events, indexed by sponsor and
+// grantee.
+func eventTopics(event abi.Event, sponsor, grantee common.Address) ([]common.Hash, error) {
+	topics := make([]common.Hash, 3)
+	// The first topic is always the signature of the event.
+	topics[0] = event.ID
+
+	var err error
+	topics[1], err = cmn.MakeTopic(sponsor)
+	if err != nil {
+		return nil, err
+	}
+	topics[2], err = cmn.MakeTopic(grantee)
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+// toWei converts an amount of the EVM denom to the 18 decimals of the EVM.
+func toWei(amount *big.Int) *big.Int {
+	return evmtypes.ConvertAmountTo18DecimalsBigInt(amount)
+}
@@ -0,0 +1,9 @@
+package types
+
+import errorsmod "cosmossdk.io/errors"
+
+// Errors of the sponsorship module.
+var (
+	ErrInvalidSponsorship = errorsmod.Register(ModuleName, 2, "invalid sponsorship")
+	ErrNoSponsorship      = errorsmod.Register(ModuleName, 3, "no sponsorship")
+)
@@ -0,0 +1,21 @@
+package types
+
+import (
+	"context"
+
+	"cosmossdk.io/x/feegrant"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeegrantKeeper stores the allowances of the sponsorships.
+type FeegrantKeeper interface {
+	GetAllowance(ctx context.Context, granter, grantee sdk.AccAddress) (feegrant.FeeAllowanceI, error)
+	GrantAllowance(ctx context.Context, granter, grantee sdk.AccAddress, feeAllowance feegrant.FeeAllowanceI) error
+}
+
+// FeegrantMsgServer revokes allowances, which the feegrant keeper only does
+// through its Msg service.
+type FeegrantMsgServer interface {
+	RevokeAllowance(ctx context.Context, msg *feegrant.MsgRevokeAllowance) (*feegrant.MsgRevokeAllowanceResponse, error)
+}
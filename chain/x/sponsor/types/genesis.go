@@ -0,0 +1,11 @@
+package types
+
+// DefaultGenesis returns the default params.
+func DefaultGenesis() *GenesisState {
+	return &GenesisState{Params: DefaultParams()}
+}
+
+// Validate validates the genesis state.
+func (gs GenesisState) Validate() error {
+	return gs.Params.Validate()
+}
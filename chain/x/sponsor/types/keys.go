@@ -0,0 +1,23 @@
+package types
+
+const (
+	// ModuleName is the name of the sponsorship module.
+	ModuleName = "sponsor"
+
+	// StoreKey is the store key of the sponsorship module.
+	StoreKey = ModuleName
+)
+
+// ParamsKey is the key of the params.
+var ParamsKey = []byte{0x01}
+
+// Events of the sponsorships, besides the feegrant events.
+const (
+	EventTypeSponsor = "sponsor"
+	EventTypeRevoke  = "revoke_sponsorship"
+
+	AttributeKeySponsor    = "sponsor"
+	AttributeKeyGrantee    = "grantee"
+	AttributeKeySpendLimit = "spend_limit"
+	AttributeKeyPeriod     = "period"
+)
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	errorsmod "cosmossdk.io/errors"
+	"cosmossdk.io/x/feegrant"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VaultTypeURLPrefix prefixes the type URLs of the vault messages. x/vault
+// is not part of the app yet, so its package is reserved for it.
+const VaultTypeURLPrefix = "/mirrorvault.vault.v1."
+
+// MsgStoreSecretTypeURL is the type URL of the vault message storing a
+// secret, which sponsorships cover by default.
+const MsgStoreSecretTypeURL = VaultTypeURLPrefix + "MsgStoreSecret"
+
+// IsVaultMessage reports whether typeURL is the type URL of a vault message.
+func IsVaultMessage(typeURL string) bool {
+	return strings.HasPrefix(typeURL, VaultTypeURLPrefix) && len(typeURL) > len(VaultTypeURLPrefix)
+}
+
+// DefaultParams returns sponsorships of the vault messages.
+func DefaultParams() Params {
+	return Params{AllowedMessages: []string{MsgStoreSecretTypeURL}}
+}
+
+// Validate checks the allowed messages are type URLs, listed once.
+func (p Params) Validate() error {
+	if len(p.AllowedMessages) == 0 {
+		return fmt.Errorf("allowed messages cannot be empty")
+	}
+
+	seen := make(map[string]bool, len(p.AllowedMessages))
+	for _, typeURL := range p.AllowedMessages {
+		if !strings.HasPrefix(typeURL, "/") || len(typeURL) == 1 {
+			return fmt.Errorf("invalid allowed message %q, expected a type URL", typeURL)
+		}
+		if seen[typeURL] {
+			return fmt.Errorf("duplicate allowed message %s", typeURL)
+		}
+		seen[typeURL] = true
+	}
+
+	return nil
+}
+
+// NewAllowance returns the allowance of a sponsorship: the grantee can spend
+// up to spendLimit in fees of the allowed messages every period, starting
+// at now.
+func (p Params) NewAllowance(spendLimit sdk.Coins, period time.Duration, now time.Time) (*feegrant.AllowedMsgAllowance, error) {
+	if period <= 0 {
+		return nil, errorsmod.Wrapf(ErrInvalidSponsorship, "period must be positive, got %s", period)
+	}
+
+	periodic := &feegrant.PeriodicAllowance{
+		Period:           period,
+		PeriodSpendLimit: spendLimit,
+		PeriodCanSpend:   spendLimit,
+		PeriodReset:      now.Add(period),
+	}
+	if err := periodic.ValidateBasic(); err != nil {
+		return nil, errorsmod.Wrap(ErrInvalidSponsorship, err.Error())
+	}
+
+	return feegrant.NewAllowedMsgAllowance(periodic, p.AllowedMessages)
+}
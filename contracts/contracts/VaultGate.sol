@@ -11,8 +11,10 @@ contract VaultGate {
     address public constant MIRROR_VAULT_PRECOMPILE = 0x0000000000000000000000000000000000000101;
 
     function payToUnlock() external {
-        // Call the precompile. We don’t assume an ABI yet; success is enough.
-        (bool ok, ) = MIRROR_VAULT_PRECOMPILE.call("");
+        // Call the precompile with the user explicitly: the caller may be a
+        // contract, so tx.origin is not the user the Unlocked log names.
+        // A low-level call, since the precompile has no code to check.
+        (bool ok, ) = MIRROR_VAULT_PRECOMPILE.call(abi.encodeWithSignature("unlock(address)", msg.sender));
         require(ok, "precompile call failed");
 
         emit Unlocked(msg.sender);
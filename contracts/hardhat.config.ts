@@ -3,9 +3,10 @@ import "@nomicfoundation/hardhat-toolbox";
 
 const config: HardhatUserConfig = {
   solidity: {
-    version: "0.8.20",
+    version: "0.8.21",
     settings: {
       optimizer: { enabled: true, runs: 200 },
+      evmVersion: "shanghai",
     },
   },
   networks: {
@@ -0,0 +1,23 @@
+// Checks the VaultGate artifact the chain embeds as a predeploy matches the
+// compiled contract. Run `npm run build` first; with --write, copies the
+// compiled artifact to the chain instead.
+import { readFileSync, writeFileSync } from "node:fs";
+
+const compiled = new URL("../artifacts/contracts/VaultGate.sol/VaultGate.json", import.meta.url);
+const predeploy = new URL("../../chain/predeploy/VaultGate.json", import.meta.url);
+
+const artifact = JSON.parse(readFileSync(compiled, "utf8"));
+
+if (process.argv.includes("--write")) {
+  writeFileSync(predeploy, JSON.stringify(artifact, null, 2) + "\n");
+  console.log("chain/predeploy/VaultGate.json updated");
+} else {
+  const embedded = JSON.parse(readFileSync(predeploy, "utf8"));
+  if (embedded.deployedBytecode !== artifact.deployedBytecode) {
+    console.error(
+      "chain/predeploy/VaultGate.json does not match the compiled VaultGate, run `npm run predeploy:write`",
+    );
+    process.exit(1);
+  }
+  console.log("chain/predeploy/VaultGate.json matches the compiled VaultGate");
+}